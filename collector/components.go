@@ -4,6 +4,8 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/elasticsearchexporter"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/basicauthextension"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/healthcheckextension"
+	"github.com/zmoog/collector/exporter/toggltrackexporter"
+	"github.com/zmoog/collector/exporter/wavinsentioexporter"
 	"github.com/zmoog/collector/receiver/shellycloudreceiver"
 	"github.com/zmoog/collector/receiver/toggltrackreceiver"
 	"github.com/zmoog/collector/receiver/wavinsentioreceiver"
@@ -47,6 +49,8 @@ func components() (otelcol.Factories, error) {
 		debugexporter.NewFactory(),
 		otlpexporter.NewFactory(),
 		elasticsearchexporter.NewFactory(),
+		toggltrackexporter.NewFactory(),
+		wavinsentioexporter.NewFactory(),
 	)
 	if err != nil {
 		return otelcol.Factories{}, err
@@ -9,6 +9,8 @@ require (
 	github.com/open-telemetry/opentelemetry-collector-contrib/extension/basicauthextension v0.142.0
 	github.com/open-telemetry/opentelemetry-collector-contrib/extension/healthcheckextension v0.142.0
 	github.com/open-telemetry/opentelemetry-collector-contrib/processor/transformprocessor v0.142.0
+	github.com/zmoog/collector/exporter/toggltrackexporter v0.0.0
+	github.com/zmoog/collector/exporter/wavinsentioexporter v0.0.0
 	github.com/zmoog/collector/receiver/shellycloudreceiver v0.0.0
 	github.com/zmoog/collector/receiver/toggltrackreceiver v0.0.0
 	github.com/zmoog/collector/receiver/wavinsentioreceiver v0.0.0
@@ -56,6 +58,8 @@ require (
 	github.com/go-logr/stdr v1.2.2 // indirect
 	github.com/go-ole/go-ole v1.2.6 // indirect
 	github.com/go-viper/mapstructure/v2 v2.4.0 // indirect
+	github.com/goburrow/modbus v0.1.0 // indirect
+	github.com/goburrow/serial v0.1.0 // indirect
 	github.com/gobwas/glob v0.2.3 // indirect
 	github.com/goccy/go-json v0.10.5 // indirect
 	github.com/golang/groupcache v0.0.0-20210331224755-41bb18bfe9da // indirect
@@ -115,6 +119,7 @@ require (
 	github.com/ua-parser/uap-go v0.0.0-20240611065828-3a4781585db6 // indirect
 	github.com/yusufpapurcu/wmi v1.2.4 // indirect
 	github.com/zeebo/xxh3 v1.0.2 // indirect
+	github.com/zmoog/collector/internal/modbus v0.0.0 // indirect
 	github.com/zmoog/ws/v2 v2.3.0 // indirect
 	github.com/zmoog/zcs v0.3.0 // indirect
 	go.elastic.co/fastjson v1.5.1 // indirect
@@ -152,6 +157,7 @@ require (
 	go.opentelemetry.io/collector/extension/extensiontest v0.142.0 // indirect
 	go.opentelemetry.io/collector/extension/xextension v0.142.0 // indirect
 	go.opentelemetry.io/collector/featuregate v1.48.0 // indirect
+	go.opentelemetry.io/collector/filter v0.142.0 // indirect
 	go.opentelemetry.io/collector/internal/fanoutconsumer v0.142.0 // indirect
 	go.opentelemetry.io/collector/internal/telemetry v0.142.0 // indirect
 	go.opentelemetry.io/collector/pdata v1.48.0 // indirect
@@ -213,10 +219,16 @@ require (
 	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
 
-replace github.com/zmoog/collector/receiver/shellycloudreceiver v0.0.0 => ../receiver/shellycloudreceiver
+replace github.com/zmoog/collector/exporter/toggltrackexporter => ../exporter/toggltrackexporter
 
-replace github.com/zmoog/collector/receiver/toggltrackreceiver v0.0.0 => ../receiver/toggltrackreceiver
+replace github.com/zmoog/collector/exporter/wavinsentioexporter => ../exporter/wavinsentioexporter
 
-replace github.com/zmoog/collector/receiver/wavinsentioreceiver v0.0.0 => ../receiver/wavinsentioreceiver
+replace github.com/zmoog/collector/internal/modbus => ../internal/modbus
 
-replace github.com/zmoog/collector/receiver/zcsazzurroreceiver v0.0.0 => ../receiver/zcsazzurroreceiver
\ No newline at end of file
+replace github.com/zmoog/collector/receiver/shellycloudreceiver => ../receiver/shellycloudreceiver
+
+replace github.com/zmoog/collector/receiver/toggltrackreceiver => ../receiver/toggltrackreceiver
+
+replace github.com/zmoog/collector/receiver/wavinsentioreceiver => ../receiver/wavinsentioreceiver
+
+replace github.com/zmoog/collector/receiver/zcsazzurroreceiver => ../receiver/zcsazzurroreceiver
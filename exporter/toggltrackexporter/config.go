@@ -0,0 +1,71 @@
+package toggltrackexporter
+
+import (
+	"fmt"
+
+	"go.opentelemetry.io/collector/config/configoptional"
+	"go.opentelemetry.io/collector/config/configretry"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+
+	"github.com/zmoog/collector/receiver/toggltrackreceiver/togglapi"
+)
+
+// AttributesConfig maps the log record attributes to the time entry
+// fields. The defaults match the attributes of the Toggl Track receiver,
+// so its log records can be written back as they are. An empty name
+// disables the field.
+type AttributesConfig struct {
+	Description string `mapstructure:"description"`
+	Start       string `mapstructure:"start"`
+	End         string `mapstructure:"end"`
+	// Duration is the duration of the entry in seconds.
+	Duration    string `mapstructure:"duration"`
+	WorkspaceID string `mapstructure:"workspace_id"`
+	ProjectID   string `mapstructure:"project_id"`
+	TaskID      string `mapstructure:"task_id"`
+	Tags        string `mapstructure:"tags"`
+	Billable    string `mapstructure:"billable"`
+	// IdempotencyKey is the attribute identifying the work session. When
+	// empty or missing, the key is derived from the entry fields.
+	IdempotencyKey string `mapstructure:"idempotency_key"`
+}
+
+// IdempotencyConfig configures how the exporter avoids creating the same
+// time entry twice.
+type IdempotencyConfig struct {
+	// CacheSize is the number of idempotency keys of the created entries
+	// the exporter remembers.
+	CacheSize uint32 `mapstructure:"cache_size"`
+	// CheckExisting looks up the entries already tracked at the same
+	// start time before creating a new one, so restarts do not create
+	// duplicates either.
+	CheckExisting bool `mapstructure:"check_existing"`
+}
+
+type Config struct {
+	togglapi.ClientConfig        `mapstructure:",squash"`
+	exporterhelper.TimeoutConfig `mapstructure:",squash"`
+	QueueConfig                  configoptional.Optional[exporterhelper.QueueBatchConfig] `mapstructure:"sending_queue"`
+	BackOffConfig                configretry.BackOffConfig                                `mapstructure:"retry_on_failure"`
+	WorkspaceID                  int                                                      `mapstructure:"workspace_id"`
+	Match                        map[string]string                                        `mapstructure:"match"`
+	Attributes                   AttributesConfig                                         `mapstructure:"attributes"`
+	Idempotency                  IdempotencyConfig                                        `mapstructure:"idempotency"`
+}
+
+func (cfg *Config) Validate() error {
+	if cfg.APIToken == "" {
+		return fmt.Errorf("api_token is required")
+	}
+	if cfg.APIEndpoint == "" {
+		return fmt.Errorf("api_endpoint is required")
+	}
+	if cfg.Attributes.Start == "" && (cfg.Attributes.End == "" || cfg.Attributes.Duration == "") {
+		return fmt.Errorf("attributes.start is required unless both attributes.end and attributes.duration are set")
+	}
+	if cfg.Idempotency.CacheSize == 0 {
+		return fmt.Errorf("idempotency.cache_size must be positive")
+	}
+
+	return nil
+}
@@ -0,0 +1,380 @@
+package toggltrackexporter
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/elastic/go-freelru"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.uber.org/zap"
+
+	"github.com/zmoog/collector/receiver/toggltrackreceiver/togglapi"
+)
+
+// createdWith is the name of the application creating the time
+// entries, required by the Toggl API.
+const createdWith = "zmoog-collector"
+
+// togglTrackExporter creates Toggl time entries from log records.
+type togglTrackExporter struct {
+	cfg    *Config
+	logger *zap.Logger
+	client *togglapi.Client
+	// created maps the idempotency keys of the entries already created
+	// to their IDs, so retries do not create them again.
+	created *freelru.SyncedLRU[string, int]
+}
+
+// newExporter creates a new Toggl Track exporter.
+func newExporter(cfg *Config, settings exporter.Settings) (*togglTrackExporter, error) {
+	created, err := freelru.NewSynced[string, int](cfg.Idempotency.CacheSize, hashString)
+	if err != nil {
+		return nil, err
+	}
+
+	return &togglTrackExporter{
+		cfg:     cfg,
+		logger:  settings.Logger,
+		created: created,
+	}, nil
+}
+
+// hashString from https://github.com/elastic/go-freelru/blob/237b2bf67a116266a3660add83b1809373dc0ac7/shardedlru_test.go#L96C1-L103C2
+func hashString(s string) uint32 {
+	var h uint32
+	for i := 0; i < len(s); i++ {
+		h = h*31 + uint32(s[i])
+	}
+	return h
+}
+
+// start creates the Toggl API client.
+func (e *togglTrackExporter) start(_ context.Context, _ component.Host) error {
+	e.client = togglapi.NewClient(e.cfg.ClientConfig)
+	return nil
+}
+
+// pushLogs creates a time entry for every matching log record.
+//
+// Records that can't become a time entry are dropped. When some entries
+// fail to be created, the error is retryable unless all the failures are
+// permanent: the idempotency keys prevent duplicating the entries of the
+// batch created before the failure.
+func (e *togglTrackExporter) pushLogs(ctx context.Context, ld plog.Logs) error {
+	var errs, permanentErrs error
+
+	for i := 0; i < ld.ResourceLogs().Len(); i++ {
+		resourceLogs := ld.ResourceLogs().At(i)
+		resource := resourceLogs.Resource().Attributes()
+
+		for j := 0; j < resourceLogs.ScopeLogs().Len(); j++ {
+			logRecords := resourceLogs.ScopeLogs().At(j).LogRecords()
+
+			for k := 0; k < logRecords.Len(); k++ {
+				lr := logRecords.At(k)
+				attrs := attributes{record: lr.Attributes(), resource: resource}
+
+				if !e.matches(attrs) {
+					continue
+				}
+
+				entry, err := e.timeEntry(attrs, lr.Timestamp())
+				if err != nil {
+					e.logger.Warn("Dropping log record not representing a time entry", zap.Error(err))
+					continue
+				}
+
+				if err := e.createOnce(ctx, e.idempotencyKey(attrs, entry), entry); err != nil {
+					if isPermanent(err) {
+						permanentErrs = errors.Join(permanentErrs, err)
+					} else {
+						errs = errors.Join(errs, err)
+					}
+				}
+			}
+		}
+	}
+
+	if errs != nil {
+		return errors.Join(errs, permanentErrs)
+	}
+	if permanentErrs != nil {
+		return consumererror.NewPermanent(permanentErrs)
+	}
+	return nil
+}
+
+// createOnce creates the time entry unless an entry with the same
+// idempotency key, or the same fields, already exists.
+func (e *togglTrackExporter) createOnce(ctx context.Context, key string, entry togglapi.NewTimeEntry) error {
+	if id, ok := e.created.Get(key); ok {
+		e.logger.Debug("Skipping time entry already created", zap.String("idempotency_key", key), zap.Int("id", id))
+		return nil
+	}
+
+	if e.cfg.Idempotency.CheckExisting {
+		id, found, err := e.findExisting(ctx, entry)
+		if err != nil {
+			return fmt.Errorf("look up existing time entries: %w", err)
+		}
+		if found {
+			e.logger.Debug("Skipping time entry already tracked", zap.String("idempotency_key", key), zap.Int("id", id))
+			e.created.Add(key, id)
+			return nil
+		}
+	}
+
+	created, err := e.client.CreateTimeEntry(ctx, entry)
+	if err != nil {
+		return fmt.Errorf("create time entry %q: %w", entry.Description, err)
+	}
+	e.created.Add(key, created.ID)
+
+	return nil
+}
+
+// findExisting looks for an entry with the same workspace, description,
+// start and stop among the entries started at the same time.
+func (e *togglTrackExporter) findExisting(ctx context.Context, entry togglapi.NewTimeEntry) (int, bool, error) {
+	entries, err := e.client.ListTimeEntries(ctx, entry.Start, entry.Start.Add(time.Second))
+	if err != nil {
+		return 0, false, err
+	}
+
+	for _, existing := range entries {
+		if existing.Wid == entry.WorkspaceID &&
+			existing.Description == entry.Description &&
+			existing.Start != nil && existing.Start.Equal(entry.Start) &&
+			existing.Stop != nil && existing.Stop.Equal(entry.Stop) {
+			return existing.ID, true, nil
+		}
+	}
+
+	return 0, false, nil
+}
+
+// matches returns true if the attributes have all the configured values.
+func (e *togglTrackExporter) matches(attrs attributes) bool {
+	for name, expected := range e.cfg.Match {
+		v, ok := attrs.get(name)
+		if !ok || v.AsString() != expected {
+			return false
+		}
+	}
+	return true
+}
+
+// timeEntry maps the attributes to a new time entry. When the end is
+// missing, it falls back to the record timestamp; the start and the end
+// can also be computed from the duration.
+func (e *togglTrackExporter) timeEntry(attrs attributes, timestamp pcommon.Timestamp) (togglapi.NewTimeEntry, error) {
+	mapping := e.cfg.Attributes
+	entry := togglapi.NewTimeEntry{
+		CreatedWith: createdWith,
+		WorkspaceID: e.cfg.WorkspaceID,
+	}
+
+	var err error
+	var hasStart, hasEnd, hasDuration bool
+	var duration int64
+
+	if entry.Start, hasStart, err = attrs.time(mapping.Start); err != nil {
+		return entry, err
+	}
+	if entry.Stop, hasEnd, err = attrs.time(mapping.End); err != nil {
+		return entry, err
+	}
+	if !hasEnd && timestamp != 0 {
+		entry.Stop, hasEnd = timestamp.AsTime(), true
+	}
+	if duration, hasDuration, err = attrs.int(mapping.Duration); err != nil {
+		return entry, err
+	}
+
+	switch {
+	case hasStart && hasEnd:
+		entry.Duration = int64(entry.Stop.Sub(entry.Start).Seconds())
+	case hasStart && hasDuration:
+		entry.Duration = duration
+		entry.Stop = entry.Start.Add(time.Duration(duration) * time.Second)
+	case hasEnd && hasDuration:
+		entry.Duration = duration
+		entry.Start = entry.Stop.Add(-time.Duration(duration) * time.Second)
+	default:
+		return entry, fmt.Errorf("two of %q, %q and %q are required", mapping.Start, mapping.End, mapping.Duration)
+	}
+	if entry.Duration <= 0 {
+		return entry, fmt.Errorf("time entry must end after it starts")
+	}
+
+	if wid, ok, err := attrs.int(mapping.WorkspaceID); err != nil {
+		return entry, err
+	} else if ok {
+		entry.WorkspaceID = int(wid)
+	}
+	if entry.WorkspaceID == 0 {
+		return entry, fmt.Errorf("workspace is required: set workspace_id or the %q attribute", mapping.WorkspaceID)
+	}
+
+	if pid, ok, err := attrs.int(mapping.ProjectID); err != nil {
+		return entry, err
+	} else if ok {
+		projectID := int(pid)
+		entry.ProjectID = &projectID
+	}
+	if tid, ok, err := attrs.int(mapping.TaskID); err != nil {
+		return entry, err
+	} else if ok {
+		taskID := int(tid)
+		entry.TaskID = &taskID
+	}
+
+	if v, ok := attrs.get(mapping.Description); ok {
+		entry.Description = v.AsString()
+	}
+	if entry.Billable, _, err = attrs.bool(mapping.Billable); err != nil {
+		return entry, err
+	}
+	entry.Tags = attrs.strings(mapping.Tags)
+
+	return entry, nil
+}
+
+// idempotencyKey returns the configured idempotency key attribute or,
+// when missing, a hash of the entry fields.
+func (e *togglTrackExporter) idempotencyKey(attrs attributes, entry togglapi.NewTimeEntry) string {
+	if v, ok := attrs.get(e.cfg.Attributes.IdempotencyKey); ok && v.AsString() != "" {
+		return v.AsString()
+	}
+
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%d|%s|%s|%s",
+		entry.WorkspaceID,
+		entry.Start.UTC().Format(time.RFC3339),
+		entry.Stop.UTC().Format(time.RFC3339),
+		entry.Description)))
+	return hex.EncodeToString(sum[:])
+}
+
+// isPermanent returns true if retrying the request can't succeed.
+func isPermanent(err error) bool {
+	var statusErr *togglapi.StatusError
+	if !errors.As(err, &statusErr) {
+		return false
+	}
+	return statusErr.StatusCode >= 400 && statusErr.StatusCode < 500 &&
+		statusErr.StatusCode != http.StatusTooManyRequests
+}
+
+// attributes looks up the log record attributes, falling back to the
+// resource attributes.
+type attributes struct {
+	record   pcommon.Map
+	resource pcommon.Map
+}
+
+func (a attributes) get(name string) (pcommon.Value, bool) {
+	if name == "" {
+		return pcommon.Value{}, false
+	}
+	if v, ok := a.record.Get(name); ok {
+		return v, true
+	}
+	return a.resource.Get(name)
+}
+
+// time parses an RFC 3339 string or a Unix timestamp in seconds.
+func (a attributes) time(name string) (time.Time, bool, error) {
+	v, ok := a.get(name)
+	if !ok {
+		return time.Time{}, false, nil
+	}
+	switch v.Type() {
+	case pcommon.ValueTypeInt:
+		return time.Unix(v.Int(), 0).UTC(), true, nil
+	case pcommon.ValueTypeStr:
+		t, err := time.Parse(time.RFC3339, v.Str())
+		if err != nil {
+			return time.Time{}, false, fmt.Errorf("invalid %q attribute: %w", name, err)
+		}
+		return t, true, nil
+	default:
+		return time.Time{}, false, fmt.Errorf("invalid %q attribute type %s", name, v.Type())
+	}
+}
+
+// int parses an integer or a string holding one.
+func (a attributes) int(name string) (int64, bool, error) {
+	v, ok := a.get(name)
+	if !ok {
+		return 0, false, nil
+	}
+	switch v.Type() {
+	case pcommon.ValueTypeInt:
+		return v.Int(), true, nil
+	case pcommon.ValueTypeDouble:
+		return int64(v.Double()), true, nil
+	case pcommon.ValueTypeStr:
+		i, err := strconv.ParseInt(v.Str(), 10, 64)
+		if err != nil {
+			return 0, false, fmt.Errorf("invalid %q attribute: %w", name, err)
+		}
+		return i, true, nil
+	default:
+		return 0, false, fmt.Errorf("invalid %q attribute type %s", name, v.Type())
+	}
+}
+
+// bool parses a boolean or a string holding one.
+func (a attributes) bool(name string) (bool, bool, error) {
+	v, ok := a.get(name)
+	if !ok {
+		return false, false, nil
+	}
+	switch v.Type() {
+	case pcommon.ValueTypeBool:
+		return v.Bool(), true, nil
+	case pcommon.ValueTypeStr:
+		b, err := strconv.ParseBool(v.Str())
+		if err != nil {
+			return false, false, fmt.Errorf("invalid %q attribute: %w", name, err)
+		}
+		return b, true, nil
+	default:
+		return false, false, fmt.Errorf("invalid %q attribute type %s", name, v.Type())
+	}
+}
+
+// strings returns a slice of strings, or a comma-separated string, as a
+// list of strings.
+func (a attributes) strings(name string) []string {
+	v, ok := a.get(name)
+	if !ok {
+		return nil
+	}
+	if v.Type() == pcommon.ValueTypeSlice {
+		values := make([]string, 0, v.Slice().Len())
+		for i := 0; i < v.Slice().Len(); i++ {
+			values = append(values, v.Slice().At(i).AsString())
+		}
+		return values
+	}
+
+	var values []string
+	for _, s := range strings.Split(v.AsString(), ",") {
+		if s = strings.TrimSpace(s); s != "" {
+			values = append(values, s)
+		}
+	}
+	return values
+}
@@ -0,0 +1,239 @@
+package toggltrackexporter
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+
+	"github.com/zmoog/collector/receiver/toggltrackreceiver/togglapi"
+)
+
+// fakeTogglAPI is a fake Toggl Track API storing the created time entries.
+type fakeTogglAPI struct {
+	mu      sync.Mutex
+	entries []map[string]any
+	// status, when set, is the status code of the create requests.
+	status int
+}
+
+func (f *fakeTogglAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	user, password, ok := r.BasicAuth()
+	if !ok || user != "my-token" || password != "api_token" {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	switch {
+	case r.Method == http.MethodGet && r.URL.Path == "/api/v9/me/time_entries":
+		start, _ := time.Parse(time.RFC3339, r.URL.Query().Get("start_date"))
+		end, _ := time.Parse(time.RFC3339, r.URL.Query().Get("end_date"))
+		found := []map[string]any{}
+		for _, e := range f.entries {
+			entryStart, _ := time.Parse(time.RFC3339, e["start"].(string))
+			if !entryStart.Before(start) && entryStart.Before(end) {
+				found = append(found, e)
+			}
+		}
+		_ = json.NewEncoder(w).Encode(found)
+	case r.Method == http.MethodPost && r.URL.Path == "/api/v9/workspaces/100/time_entries":
+		if f.status != 0 {
+			w.WriteHeader(f.status)
+			return
+		}
+		var entry map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&entry); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		entry["id"] = len(f.entries) + 1
+		entry["wid"] = entry["workspace_id"]
+		f.entries = append(f.entries, entry)
+		_ = json.NewEncoder(w).Encode(entry)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func (f *fakeTogglAPI) created() []map[string]any {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]map[string]any(nil), f.entries...)
+}
+
+func TestExporter_PushLogs(t *testing.T) {
+	api := &fakeTogglAPI{}
+	server := httptest.NewServer(api)
+	defer server.Close()
+
+	exp := newTestExporter(t, server.URL, nil)
+
+	logs := plog.NewLogs()
+	lr := logs.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+	lr.Attributes().PutStr("description", "Deploy to production")
+	lr.Attributes().PutStr("start", "2024-01-15T09:00:00Z")
+	lr.Attributes().PutStr("end", "2024-01-15T09:30:00Z")
+	lr.Attributes().PutStr("workspace.id", "100")
+	lr.Attributes().PutInt("project.id", 200)
+	lr.Attributes().PutStr("billable", "true")
+	tags := lr.Attributes().PutEmptySlice("tags")
+	tags.AppendEmpty().SetStr("ci")
+
+	require.NoError(t, exp.pushLogs(context.Background(), logs))
+
+	created := api.created()
+	require.Len(t, created, 1)
+	assert.Equal(t, "Deploy to production", created[0]["description"])
+	assert.Equal(t, "2024-01-15T09:00:00Z", created[0]["start"])
+	assert.Equal(t, "2024-01-15T09:30:00Z", created[0]["stop"])
+	assert.Equal(t, float64(1800), created[0]["duration"])
+	assert.Equal(t, float64(100), created[0]["workspace_id"])
+	assert.Equal(t, float64(200), created[0]["project_id"])
+	assert.Equal(t, true, created[0]["billable"])
+	assert.Equal(t, []any{"ci"}, created[0]["tags"])
+	assert.Equal(t, createdWith, created[0]["created_with"])
+	assert.NotContains(t, created[0], "task_id")
+
+	// A retry of the same batch must not duplicate the entry.
+	require.NoError(t, exp.pushLogs(context.Background(), logs))
+	assert.Len(t, api.created(), 1)
+
+	// Neither must a restarted exporter, which lost the created keys.
+	restarted := newTestExporter(t, server.URL, nil)
+	require.NoError(t, restarted.pushLogs(context.Background(), logs))
+	assert.Len(t, api.created(), 1)
+}
+
+func TestExporter_PushLogsMapping(t *testing.T) {
+	api := &fakeTogglAPI{}
+	server := httptest.NewServer(api)
+	defer server.Close()
+
+	exp := newTestExporter(t, server.URL, func(cfg *Config) {
+		cfg.WorkspaceID = 100
+		cfg.Match = map[string]string{"event.name": "ci.job"}
+		cfg.Attributes = AttributesConfig{
+			Description:    "ci.job.name",
+			Duration:       "ci.job.duration",
+			End:            "ci.job.finished_at",
+			Tags:           "ci.job.labels",
+			IdempotencyKey: "ci.job.id",
+		}
+		cfg.Idempotency.CheckExisting = false
+	})
+
+	logs := plog.NewLogs()
+	resourceLogs := logs.ResourceLogs().AppendEmpty()
+	resourceLogs.Resource().Attributes().PutStr("event.name", "ci.job")
+	logRecords := resourceLogs.ScopeLogs().AppendEmpty().LogRecords()
+
+	job := logRecords.AppendEmpty()
+	job.Attributes().PutStr("ci.job.id", "job-1")
+	job.Attributes().PutStr("ci.job.name", "build")
+	job.Attributes().PutInt("ci.job.duration", 600)
+	job.Attributes().PutStr("ci.job.labels", "ci, build")
+	job.SetTimestamp(pcommon.NewTimestampFromTime(time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)))
+
+	// Same job reported again with a different end time.
+	rerun := logRecords.AppendEmpty()
+	job.CopyTo(rerun)
+	rerun.SetTimestamp(pcommon.NewTimestampFromTime(time.Date(2024, 1, 15, 10, 5, 0, 0, time.UTC)))
+
+	// Not a time entry: no end, no timestamp.
+	incomplete := logRecords.AppendEmpty()
+	incomplete.Attributes().PutStr("ci.job.id", "job-2")
+	incomplete.Attributes().PutInt("ci.job.duration", 60)
+
+	other := logs.ResourceLogs().AppendEmpty()
+	other.Resource().Attributes().PutStr("event.name", "deploy")
+	job.CopyTo(other.ScopeLogs().AppendEmpty().LogRecords().AppendEmpty())
+
+	require.NoError(t, exp.pushLogs(context.Background(), logs))
+
+	created := api.created()
+	require.Len(t, created, 1)
+	assert.Equal(t, "build", created[0]["description"])
+	assert.Equal(t, "2024-01-15T09:50:00Z", created[0]["start"])
+	assert.Equal(t, "2024-01-15T10:00:00Z", created[0]["stop"])
+	assert.Equal(t, []any{"ci", "build"}, created[0]["tags"])
+}
+
+func TestExporter_PushLogsErrors(t *testing.T) {
+	tests := []struct {
+		name              string
+		status            int
+		expectedPermanent bool
+	}{
+		{name: "bad request", status: http.StatusBadRequest, expectedPermanent: true},
+		{name: "rate limited", status: http.StatusTooManyRequests},
+		{name: "server error", status: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			api := &fakeTogglAPI{status: tt.status}
+			server := httptest.NewServer(api)
+			defer server.Close()
+
+			exp := newTestExporter(t, server.URL, func(cfg *Config) {
+				cfg.WorkspaceID = 100
+			})
+
+			logs := plog.NewLogs()
+			lr := logs.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+			lr.Attributes().PutStr("start", "2024-01-15T09:00:00Z")
+			lr.Attributes().PutStr("end", "2024-01-15T09:30:00Z")
+
+			err := exp.pushLogs(context.Background(), logs)
+			require.Error(t, err)
+			assert.Equal(t, tt.expectedPermanent, consumererror.IsPermanent(err))
+
+			var statusErr *togglapi.StatusError
+			require.ErrorAs(t, err, &statusErr)
+			assert.Equal(t, tt.status, statusErr.StatusCode)
+		})
+	}
+}
+
+func TestConfig_Validate(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	assert.EqualError(t, cfg.Validate(), "api_token is required")
+
+	cfg.APIToken = "my-token"
+	assert.NoError(t, cfg.Validate())
+
+	cfg.Attributes.Start = ""
+	assert.NoError(t, cfg.Validate(), "start can be computed from end and duration")
+
+	cfg.Attributes.Duration = ""
+	assert.EqualError(t, cfg.Validate(), "attributes.start is required unless both attributes.end and attributes.duration are set")
+}
+
+func newTestExporter(t *testing.T, endpoint string, configure func(cfg *Config)) *togglTrackExporter {
+	cfg := createDefaultConfig().(*Config)
+	cfg.APIToken = "my-token"
+	cfg.APIEndpoint = endpoint
+	if configure != nil {
+		configure(cfg)
+	}
+	require.NoError(t, cfg.Validate())
+
+	exp, err := newExporter(cfg, exportertest.NewNopSettings(typeStr))
+	require.NoError(t, err)
+	require.NoError(t, exp.start(context.Background(), componenttest.NewNopHost()))
+	return exp
+}
@@ -0,0 +1,79 @@
+package toggltrackexporter
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configoptional"
+	"go.opentelemetry.io/collector/config/configretry"
+	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+
+	"github.com/zmoog/collector/receiver/toggltrackreceiver/togglapi"
+)
+
+var (
+	typeStr = component.MustNewType("toggltrack")
+)
+
+const (
+	DefaultIdempotencyCacheSize = 10000
+)
+
+func createDefaultConfig() component.Config {
+	return &Config{
+		ClientConfig: togglapi.ClientConfig{
+			APIEndpoint: togglapi.DefaultEndpoint,
+		},
+		TimeoutConfig: exporterhelper.NewDefaultTimeoutConfig(),
+		QueueConfig:   configoptional.Some(exporterhelper.NewDefaultQueueConfig()),
+		BackOffConfig: configretry.NewDefaultBackOffConfig(),
+		Attributes: AttributesConfig{
+			Description: "description",
+			Start:       "start",
+			End:         "end",
+			Duration:    "duration",
+			WorkspaceID: "workspace.id",
+			ProjectID:   "project.id",
+			TaskID:      "task.id",
+			Tags:        "tags",
+			Billable:    "billable",
+		},
+		Idempotency: IdempotencyConfig{
+			CacheSize:     DefaultIdempotencyCacheSize,
+			CheckExisting: true,
+		},
+	}
+}
+
+func createLogsExporter(ctx context.Context, settings exporter.Settings, baseCfg component.Config) (exporter.Logs, error) {
+	cfg, ok := baseCfg.(*Config)
+	if !ok {
+		return nil, fmt.Errorf("invalid config type")
+	}
+
+	togglTrackExporter, err := newExporter(cfg, settings)
+	if err != nil {
+		return nil, err
+	}
+
+	return exporterhelper.NewLogs(
+		ctx,
+		settings,
+		cfg,
+		togglTrackExporter.pushLogs,
+		exporterhelper.WithStart(togglTrackExporter.start),
+		exporterhelper.WithTimeout(cfg.TimeoutConfig),
+		exporterhelper.WithQueue(cfg.QueueConfig),
+		exporterhelper.WithRetry(cfg.BackOffConfig),
+	)
+}
+
+func NewFactory() exporter.Factory {
+	return exporter.NewFactory(
+		typeStr,
+		createDefaultConfig,
+		exporter.WithLogs(createLogsExporter, component.StabilityLevelAlpha),
+	)
+}
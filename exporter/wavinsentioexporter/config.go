@@ -0,0 +1,36 @@
+package wavinsentioexporter
+
+import (
+	"fmt"
+
+	"go.opentelemetry.io/collector/config/configoptional"
+	"go.opentelemetry.io/collector/config/configretry"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+
+	"github.com/zmoog/collector/receiver/wavinsentioreceiver/wavinapi"
+)
+
+type Config struct {
+	wavinapi.ClientConfig        `mapstructure:",squash"`
+	exporterhelper.TimeoutConfig `mapstructure:",squash"`
+	QueueConfig                  configoptional.Optional[exporterhelper.QueueBatchConfig] `mapstructure:"sending_queue"`
+	BackOffConfig                configretry.BackOffConfig                                `mapstructure:"retry_on_failure"`
+	// DryRun logs the commands instead of applying them.
+	DryRun bool `mapstructure:"dry_run"`
+}
+
+func (cfg *Config) Validate() error {
+	if cfg.Endpoint == "" {
+		return fmt.Errorf("endpoint is required")
+	}
+	if cfg.Username == "" {
+		return fmt.Errorf("username is required")
+	}
+	if cfg.Password == "" {
+		return fmt.Errorf("password is required")
+	}
+	if cfg.WebApiKey == "" {
+		return fmt.Errorf("web_api_key is required")
+	}
+	return nil
+}
@@ -0,0 +1,330 @@
+package wavinsentioexporter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.uber.org/zap"
+
+	ws2 "github.com/zmoog/ws/v2/ws"
+
+	"github.com/zmoog/collector/receiver/wavinsentioreceiver/wavinapi"
+)
+
+// The attributes of the log records carrying a command. The device and
+// room attributes select the target rooms: when missing, the command
+// applies to all the devices or to all the rooms.
+const (
+	attributeCommand      = "wavinsentio.command"
+	attributeValue        = "wavinsentio.command.value"
+	attributeSerialNumber = "wavinsentio.device.serial_number"
+	attributeRoomID       = "wavinsentio.room.id"
+	attributeRoomTitle    = "wavinsentio.room.title"
+)
+
+// The supported commands.
+const (
+	commandSetpoint     = "setpoint"
+	commandVacationMode = "vacation_mode"
+	commandLockMode     = "lock_mode"
+)
+
+// command is a change of a room setting requested by a log record.
+type command struct {
+	name         string
+	serialNumber string
+	roomID       string
+	roomTitle    string
+	// setpoint is the value of the setpoint command.
+	setpoint float64
+	// mode is the value of the vacation_mode and lock_mode commands.
+	mode string
+}
+
+// wavinsentioExporter applies the commands carried by log records to
+// the Wavin Sentio rooms.
+type wavinsentioExporter struct {
+	cfg    *Config
+	logger *zap.Logger
+	client *wavinapi.Client
+}
+
+// newExporter creates a new Wavin Sentio exporter.
+func newExporter(cfg *Config, settings exporter.Settings) *wavinsentioExporter {
+	return &wavinsentioExporter{
+		cfg:    cfg,
+		logger: settings.Logger,
+	}
+}
+
+// start creates the Wavin Sentio API client.
+func (e *wavinsentioExporter) start(_ context.Context, _ component.Host) error {
+	e.client = wavinapi.NewClient(e.cfg.ClientConfig)
+	return nil
+}
+
+// pushLogs applies the commands of the log records.
+//
+// Records without a command are ignored and invalid commands are
+// dropped. The rooms already in the requested state are skipped, so
+// retrying a batch does not apply a command twice.
+func (e *wavinsentioExporter) pushLogs(ctx context.Context, ld plog.Logs) error {
+	var commands []command
+
+	for i := 0; i < ld.ResourceLogs().Len(); i++ {
+		resourceLogs := ld.ResourceLogs().At(i)
+		resource := resourceLogs.Resource().Attributes()
+
+		for j := 0; j < resourceLogs.ScopeLogs().Len(); j++ {
+			logRecords := resourceLogs.ScopeLogs().At(j).LogRecords()
+
+			for k := 0; k < logRecords.Len(); k++ {
+				attrs := attributes{record: logRecords.At(k).Attributes(), resource: resource}
+				if _, ok := attrs.get(attributeCommand); !ok {
+					continue
+				}
+
+				cmd, err := parseCommand(attrs)
+				if err != nil {
+					e.logger.Warn("Dropping invalid Wavin Sentio command", zap.Error(err))
+					continue
+				}
+				commands = append(commands, cmd)
+			}
+		}
+	}
+
+	if len(commands) == 0 {
+		return nil
+	}
+
+	devices, err := e.client.ListDevices(ctx)
+	if err != nil {
+		err = fmt.Errorf("list devices: %w", err)
+		if isPermanent(err) {
+			return consumererror.NewPermanent(err)
+		}
+		return err
+	}
+
+	var errs, permanentErrs error
+	for _, device := range devices {
+		rooms := e.roomUpdates(device, commands)
+		if len(rooms) == 0 {
+			continue
+		}
+
+		if e.cfg.DryRun {
+			for _, room := range rooms {
+				e.logger.Info("Dry run: skipping Wavin Sentio room update",
+					zap.String("device", device.SerialNumber),
+					zap.String("room", room.ID),
+					zap.Any("setpoint", room.SetpointTemperature),
+					zap.String("vacation_mode", room.VacationMode),
+					zap.String("lock_mode", room.LockMode))
+			}
+			continue
+		}
+
+		if err := e.client.UpdateRooms(ctx, device.LastConfig.Name, rooms); err != nil {
+			err = fmt.Errorf("update rooms of device %s: %w", device.SerialNumber, err)
+			if isPermanent(err) {
+				permanentErrs = errors.Join(permanentErrs, err)
+			} else {
+				errs = errors.Join(errs, err)
+			}
+		}
+	}
+
+	if errs != nil {
+		return errors.Join(errs, permanentErrs)
+	}
+	if permanentErrs != nil {
+		return consumererror.NewPermanent(permanentErrs)
+	}
+	return nil
+}
+
+// roomUpdates returns the updates of the device rooms targeted by the
+// commands, in the order of the rooms. Later commands override the
+// earlier ones.
+func (e *wavinsentioExporter) roomUpdates(device ws2.Device, commands []command) []wavinapi.RoomUpdate {
+	var updates []wavinapi.RoomUpdate
+
+	for _, room := range device.LastConfig.Sentio.Rooms {
+		update := wavinapi.RoomUpdate{ID: room.ID}
+		changed := false
+
+		for _, cmd := range commands {
+			if !cmd.targets(device, room) {
+				continue
+			}
+
+			switch cmd.name {
+			case commandSetpoint:
+				if cmd.setpoint < room.MinSetpointTemperature || cmd.setpoint > room.MaxSetpointTemperature {
+					e.logger.Warn("Skipping setpoint outside the room range",
+						zap.String("room", room.ID),
+						zap.Float64("setpoint", cmd.setpoint),
+						zap.Float64("min", room.MinSetpointTemperature),
+						zap.Float64("max", room.MaxSetpointTemperature))
+					continue
+				}
+				setpoint := cmd.setpoint
+				update.SetpointTemperature, changed = &setpoint, changed || setpoint != room.SetpointTemperature
+			case commandVacationMode:
+				update.VacationMode, changed = cmd.mode, changed || cmd.mode != room.VacationMode
+			case commandLockMode:
+				update.LockMode, changed = cmd.mode, changed || cmd.mode != room.LockMode
+			}
+		}
+
+		if changed {
+			updates = append(updates, update)
+		}
+	}
+
+	return updates
+}
+
+// targets returns true if the command applies to the room.
+func (c command) targets(device ws2.Device, room ws2.Room) bool {
+	if c.serialNumber != "" && c.serialNumber != device.SerialNumber {
+		return false
+	}
+	if c.roomID != "" && c.roomID != room.ID {
+		return false
+	}
+	if c.roomTitle != "" && !strings.EqualFold(c.roomTitle, room.Title) {
+		return false
+	}
+	return true
+}
+
+// parseCommand maps the attributes to a command.
+func parseCommand(attrs attributes) (command, error) {
+	cmd := command{
+		name:         attrs.str(attributeCommand),
+		serialNumber: attrs.str(attributeSerialNumber),
+		roomID:       attrs.str(attributeRoomID),
+		roomTitle:    attrs.str(attributeRoomTitle),
+	}
+
+	value, ok := attrs.get(attributeValue)
+	if !ok {
+		return cmd, fmt.Errorf("%q attribute is required", attributeValue)
+	}
+
+	var err error
+	switch cmd.name {
+	case commandSetpoint:
+		cmd.setpoint, err = parseSetpoint(value)
+	case commandVacationMode:
+		cmd.mode, err = parseMode(value, "VACATION_MODE_", "ON", "OFF")
+	case commandLockMode:
+		cmd.mode, err = parseMode(value, "LOCK_MODE_", "LOCKED", "UNLOCKED")
+	default:
+		err = fmt.Errorf("unknown command %q", cmd.name)
+	}
+
+	return cmd, err
+}
+
+// parseSetpoint parses a number, or a string holding one.
+func parseSetpoint(v pcommon.Value) (float64, error) {
+	switch v.Type() {
+	case pcommon.ValueTypeDouble:
+		return v.Double(), nil
+	case pcommon.ValueTypeInt:
+		return float64(v.Int()), nil
+	case pcommon.ValueTypeStr:
+		setpoint, err := strconv.ParseFloat(v.Str(), 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid setpoint: %w", err)
+		}
+		return setpoint, nil
+	default:
+		return 0, fmt.Errorf("invalid setpoint type %s", v.Type())
+	}
+}
+
+// parseMode parses a boolean, or a string holding a boolean, the mode
+// name (like "on" or "locked"), or the API enum value (like
+// "VACATION_MODE_ON"). It returns the API enum value.
+func parseMode(v pcommon.Value, prefix, on, off string) (string, error) {
+	if v.Type() == pcommon.ValueTypeBool {
+		if v.Bool() {
+			return prefix + on, nil
+		}
+		return prefix + off, nil
+	}
+
+	s := strings.TrimPrefix(strings.ToUpper(v.AsString()), prefix)
+	if b, err := strconv.ParseBool(s); err == nil {
+		if b {
+			return prefix + on, nil
+		}
+		return prefix + off, nil
+	}
+
+	switch s {
+	case on, off:
+		return prefix + s, nil
+	default:
+		return "", fmt.Errorf("invalid mode %q: expected %s or %s", v.AsString(), on, off)
+	}
+}
+
+// isPermanent returns true if retrying the request can't succeed.
+func isPermanent(err error) bool {
+	var authErr *wavinapi.AuthError
+	if errors.As(err, &authErr) {
+		return authErr.Permanent()
+	}
+
+	var statusErr *wavinapi.StatusError
+	if !errors.As(err, &statusErr) {
+		return false
+	}
+
+	switch statusErr.StatusCode {
+	case http.StatusUnauthorized:
+		// The client drops the rejected token, so the retry gets a new
+		// one from the identity provider.
+		return false
+	case http.StatusTooManyRequests:
+		return false
+	default:
+		return statusErr.StatusCode >= 400 && statusErr.StatusCode < 500
+	}
+}
+
+// attributes looks up the log record attributes, falling back to the
+// resource attributes.
+type attributes struct {
+	record   pcommon.Map
+	resource pcommon.Map
+}
+
+func (a attributes) get(name string) (pcommon.Value, bool) {
+	if v, ok := a.record.Get(name); ok {
+		return v, true
+	}
+	return a.resource.Get(name)
+}
+
+func (a attributes) str(name string) string {
+	if v, ok := a.get(name); ok {
+		return v.AsString()
+	}
+	return ""
+}
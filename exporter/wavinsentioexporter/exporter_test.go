@@ -0,0 +1,269 @@
+package wavinsentioexporter
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.opentelemetry.io/collector/pdata/plog"
+
+	"github.com/zmoog/ws/v2/ws/identity"
+
+	"github.com/zmoog/collector/receiver/wavinsentioreceiver/wavinapi"
+)
+
+// staticIdentity is an identity manager returning a fixed token.
+type staticIdentity struct{}
+
+func (staticIdentity) GetToken() (identity.Token, error) {
+	return identity.Token{ID: "my-token"}, nil
+}
+
+// fakeWavinAPI is a fake Wavin Sentio Connect API storing the config
+// updates.
+type fakeWavinAPI struct {
+	mu      sync.Mutex
+	devices []byte
+	updates []map[string]any
+	// status, when set, is the status code of the update requests.
+	status int
+}
+
+func (f *fakeWavinAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost || r.Header.Get("Authorization") != "Bearer my-token" {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	switch r.URL.Path {
+	case "/ListDevices":
+		_, _ = w.Write(f.devices)
+	case "/UpdateConfig":
+		if f.status != 0 {
+			w.WriteHeader(f.status)
+			_, _ = w.Write([]byte(`{"code":"invalid_argument","message":"bad config"}`))
+			return
+		}
+		if r.Header.Get("Connect-Protocol-Version") != "1" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		var update map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&update); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		f.updates = append(f.updates, update)
+		_, _ = w.Write([]byte(`{}`))
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+// updatedRooms returns the rooms of the config updates.
+func (f *fakeWavinAPI) updatedRooms() []any {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var rooms []any
+	for _, update := range f.updates {
+		config := update["config"].(map[string]any)
+		rooms = append(rooms, config["sentio"].(map[string]any)["rooms"].([]any)...)
+	}
+	return rooms
+}
+
+func newFakeWavinAPI(t *testing.T) *fakeWavinAPI {
+	devices, err := os.ReadFile("testdata/devices.json")
+	require.NoError(t, err)
+	return &fakeWavinAPI{devices: devices}
+}
+
+func newTestExporter(t *testing.T, endpoint string, configure func(cfg *Config)) *wavinsentioExporter {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = endpoint
+	cfg.Username = "my-username"
+	cfg.Password = "my-password"
+	cfg.WebApiKey = "my-web-api-key"
+	if configure != nil {
+		configure(cfg)
+	}
+	require.NoError(t, cfg.Validate())
+
+	exp := newExporter(cfg, exportertest.NewNopSettings(typeStr))
+	exp.client = wavinapi.NewClientWithIdentity(staticIdentity{}, endpoint)
+	return exp
+}
+
+// appendCommand appends a log record with the command attributes.
+func appendCommand(logs plog.Logs, attributes map[string]any) {
+	lr := logs.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+	_ = lr.Attributes().FromRaw(attributes)
+}
+
+func TestExporter_PushLogs(t *testing.T) {
+	api := newFakeWavinAPI(t)
+	server := httptest.NewServer(api)
+	defer server.Close()
+
+	exp := newTestExporter(t, server.URL, nil)
+
+	logs := plog.NewLogs()
+	appendCommand(logs, map[string]any{
+		"wavinsentio.command":       "setpoint",
+		"wavinsentio.room.title":    "living room",
+		"wavinsentio.command.value": "22.5",
+	})
+	appendCommand(logs, map[string]any{
+		"wavinsentio.command":       "lock_mode",
+		"wavinsentio.room.id":       "room-2",
+		"wavinsentio.command.value": "unlocked",
+	})
+	// Not a command.
+	appendCommand(logs, map[string]any{"message": "away mode on"})
+
+	require.NoError(t, exp.pushLogs(context.Background(), logs))
+
+	require.Len(t, api.updates, 1)
+	assert.Equal(t, "devices/1234/config", api.updates[0]["config"].(map[string]any)["name"])
+	assert.Equal(t, []any{
+		map[string]any{"id": "room-1", "setpointTemperature": 22.5},
+		map[string]any{"id": "room-2", "lockMode": "LOCK_MODE_UNLOCKED"},
+	}, api.updatedRooms())
+}
+
+func TestExporter_PushLogsAllRooms(t *testing.T) {
+	api := newFakeWavinAPI(t)
+	server := httptest.NewServer(api)
+	defer server.Close()
+
+	exp := newTestExporter(t, server.URL, nil)
+
+	logs := plog.NewLogs()
+	appendCommand(logs, map[string]any{
+		"wavinsentio.command":       "vacation_mode",
+		"wavinsentio.command.value": true,
+	})
+	require.NoError(t, exp.pushLogs(context.Background(), logs))
+
+	assert.Equal(t, []any{
+		map[string]any{"id": "room-1", "vacationMode": "VACATION_MODE_ON"},
+		map[string]any{"id": "room-2", "vacationMode": "VACATION_MODE_ON"},
+	}, api.updatedRooms())
+
+	// The rooms already in the requested state are skipped.
+	logs = plog.NewLogs()
+	appendCommand(logs, map[string]any{
+		"wavinsentio.command":       "lock_mode",
+		"wavinsentio.command.value": "LOCK_MODE_LOCKED",
+	})
+	require.NoError(t, exp.pushLogs(context.Background(), logs))
+
+	assert.Len(t, api.updates, 2)
+	assert.Equal(t, map[string]any{"id": "room-1", "lockMode": "LOCK_MODE_LOCKED"}, api.updatedRooms()[2])
+}
+
+func TestExporter_PushLogsInvalidCommands(t *testing.T) {
+	api := newFakeWavinAPI(t)
+	server := httptest.NewServer(api)
+	defer server.Close()
+
+	exp := newTestExporter(t, server.URL, nil)
+
+	logs := plog.NewLogs()
+	for _, attributes := range []map[string]any{
+		{"wavinsentio.command": "reboot", "wavinsentio.command.value": true},
+		{"wavinsentio.command": "setpoint"},
+		{"wavinsentio.command": "setpoint", "wavinsentio.command.value": "warm"},
+		{"wavinsentio.command": "setpoint", "wavinsentio.command.value": 45},
+		{"wavinsentio.command": "vacation_mode", "wavinsentio.command.value": "maybe"},
+		{"wavinsentio.command": "setpoint", "wavinsentio.device.serial_number": "other", "wavinsentio.command.value": 20},
+	} {
+		appendCommand(logs, attributes)
+	}
+
+	require.NoError(t, exp.pushLogs(context.Background(), logs))
+	assert.Empty(t, api.updates)
+}
+
+func TestExporter_PushLogsDryRun(t *testing.T) {
+	api := newFakeWavinAPI(t)
+	server := httptest.NewServer(api)
+	defer server.Close()
+
+	exp := newTestExporter(t, server.URL, func(cfg *Config) {
+		cfg.DryRun = true
+	})
+
+	logs := plog.NewLogs()
+	appendCommand(logs, map[string]any{
+		"wavinsentio.command":       "vacation_mode",
+		"wavinsentio.command.value": "on",
+	})
+	require.NoError(t, exp.pushLogs(context.Background(), logs))
+	assert.Empty(t, api.updates)
+}
+
+func TestExporter_PushLogsErrors(t *testing.T) {
+	tests := []struct {
+		name              string
+		status            int
+		expectedPermanent bool
+	}{
+		{name: "bad request", status: http.StatusBadRequest, expectedPermanent: true},
+		{name: "token rejected", status: http.StatusUnauthorized},
+		{name: "rate limited", status: http.StatusTooManyRequests},
+		{name: "server error", status: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			api := newFakeWavinAPI(t)
+			api.status = tt.status
+			server := httptest.NewServer(api)
+			defer server.Close()
+
+			exp := newTestExporter(t, server.URL, nil)
+
+			logs := plog.NewLogs()
+			appendCommand(logs, map[string]any{
+				"wavinsentio.command":       "setpoint",
+				"wavinsentio.command.value": 20,
+			})
+
+			err := exp.pushLogs(context.Background(), logs)
+			require.Error(t, err)
+			assert.Equal(t, tt.expectedPermanent, consumererror.IsPermanent(err))
+
+			var statusErr *wavinapi.StatusError
+			require.ErrorAs(t, err, &statusErr)
+			assert.Equal(t, tt.status, statusErr.StatusCode)
+			assert.Equal(t, "invalid_argument", statusErr.Code)
+			assert.Equal(t, "bad config", statusErr.Message)
+		})
+	}
+}
+
+func TestConfig_Validate(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	assert.EqualError(t, cfg.Validate(), "endpoint is required")
+
+	cfg.Endpoint = "https://example.com"
+	cfg.Username = "my-username"
+	cfg.Password = "my-password"
+	assert.EqualError(t, cfg.Validate(), "web_api_key is required")
+
+	cfg.WebApiKey = "my-web-api-key"
+	assert.NoError(t, cfg.Validate())
+}
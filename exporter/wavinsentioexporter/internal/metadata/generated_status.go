@@ -0,0 +1,16 @@
+// Code generated by mdatagen. DO NOT EDIT.
+
+package metadata
+
+import (
+	"go.opentelemetry.io/collector/component"
+)
+
+var (
+	Type      = component.MustNewType("wavinsentio")
+	ScopeName = "github.com/zmoog/collector/wavinsentioexporter"
+)
+
+const (
+	LogsStability = component.StabilityLevelDevelopment
+)
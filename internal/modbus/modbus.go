@@ -0,0 +1,124 @@
+// Package modbus reads the registers of the devices on the local network,
+// like the Wavin Sentio CCU or the ZCS Azzurro inverters, over Modbus TCP.
+package modbus
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	gomodbus "github.com/goburrow/modbus"
+)
+
+const (
+	RegisterTypeInput   = "input"
+	RegisterTypeHolding = "holding"
+)
+
+// Register locates a value in the Modbus registers.
+type Register struct {
+	Address uint16
+	// Type is the register type: input or holding.
+	Type string
+	// Words is the number of registers holding the value: 1, or 2 for a
+	// 32-bit value with the high word first.
+	Words int
+	// Signed is true when the value is a two's complement integer.
+	Signed bool
+	// Scale converts the raw value to the unit of the metric. The raw
+	// value is used when zero.
+	Scale float64
+}
+
+// Validate checks the register type and size.
+func (r Register) Validate() error {
+	switch r.Type {
+	case RegisterTypeInput, RegisterTypeHolding:
+	default:
+		return fmt.Errorf("unknown register type %q", r.Type)
+	}
+	switch r.Words {
+	case 1, 2:
+	default:
+		return errors.New("words must be 1 or 2")
+	}
+	return nil
+}
+
+// Client reads the registers of a device over Modbus TCP. It is safe for
+// concurrent use.
+type Client struct {
+	endpoint string
+	handler  *gomodbus.TCPClientHandler
+	client   gomodbus.Client
+}
+
+// NewClient returns a client of the device at endpoint, the host:port
+// of its Modbus TCP interface. The connection is opened by Connect, or
+// by the first read.
+func NewClient(endpoint string, unitID byte, timeout time.Duration) *Client {
+	handler := gomodbus.NewTCPClientHandler(endpoint)
+	handler.SlaveId = unitID
+	if timeout > 0 {
+		handler.Timeout = timeout
+	}
+
+	return &Client{
+		endpoint: endpoint,
+		handler:  handler,
+		client:   gomodbus.NewClient(handler),
+	}
+}
+
+// Connect connects to the device, to check it can be reached.
+func (c *Client) Connect() error {
+	if err := c.handler.Connect(); err != nil {
+		return fmt.Errorf("connect to %s: %w", c.endpoint, err)
+	}
+	return nil
+}
+
+// Read returns the scaled value of the register.
+func (c *Client) Read(register Register) (float64, error) {
+	var (
+		data []byte
+		err  error
+	)
+	if register.Type == RegisterTypeInput {
+		data, err = c.client.ReadInputRegisters(register.Address, uint16(register.Words))
+	} else {
+		data, err = c.client.ReadHoldingRegisters(register.Address, uint16(register.Words))
+	}
+	if err != nil {
+		return 0, err
+	}
+	if len(data) != 2*register.Words {
+		return 0, fmt.Errorf("register %d: unexpected response length %d", register.Address, len(data))
+	}
+
+	var value float64
+	switch {
+	case register.Words == 1 && register.Signed:
+		value = float64(int16(binary.BigEndian.Uint16(data)))
+	case register.Words == 1:
+		value = float64(binary.BigEndian.Uint16(data))
+	case register.Signed:
+		value = float64(int32(binary.BigEndian.Uint32(data)))
+	default:
+		value = float64(binary.BigEndian.Uint32(data))
+	}
+
+	if register.Scale != 0 {
+		value *= register.Scale
+	}
+
+	// Drop the floating point noise of the scaling, like 21.500000000000004.
+	return math.Round(value*1e6) / 1e6, nil
+}
+
+// Close closes the connection to the device.
+func (c *Client) Close() error {
+	return c.handler.Close()
+}
@@ -0,0 +1,64 @@
+package modbus
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zmoog/collector/internal/modbus/modbustest"
+)
+
+func TestClient_Read(t *testing.T) {
+	server := modbustest.NewServer(t, modbustest.Registers{
+		Holding: map[uint16]uint16{1: 0xfc18, 2: 0xffff, 10: 215},
+		Input:   map[uint16]uint16{1: 48},
+	})
+
+	client := NewClient(server.Addr(), 1, time.Second)
+	defer client.Close()
+	require.NoError(t, client.Connect())
+
+	for _, tt := range []struct {
+		name     string
+		register Register
+		expected float64
+	}{
+		{name: "unsigned", register: Register{Address: 1, Type: RegisterTypeHolding, Words: 1}, expected: 0xfc18},
+		{name: "signed", register: Register{Address: 1, Type: RegisterTypeHolding, Words: 1, Signed: true}, expected: -1000},
+		{name: "scaled", register: Register{Address: 1, Type: RegisterTypeHolding, Words: 1, Signed: true, Scale: 0.1}, expected: -100},
+		{name: "scaling noise", register: Register{Address: 10, Type: RegisterTypeHolding, Words: 1, Scale: 0.1}, expected: 21.5},
+		{name: "32-bit", register: Register{Address: 1, Type: RegisterTypeHolding, Words: 2}, expected: 0xfc18ffff},
+		{name: "32-bit signed", register: Register{Address: 1, Type: RegisterTypeHolding, Words: 2, Signed: true}, expected: -65470465},
+		{name: "input", register: Register{Address: 1, Type: RegisterTypeInput, Words: 1}, expected: 48},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			value, err := client.Read(tt.register)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, value)
+		})
+	}
+
+	_, err := client.Read(Register{Address: 999, Type: RegisterTypeHolding, Words: 1})
+	assert.ErrorContains(t, err, "illegal data address")
+}
+
+func TestClient_ConnectUnreachable(t *testing.T) {
+	// Grab a free port with nothing listening on it.
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	endpoint := listener.Addr().String()
+	require.NoError(t, listener.Close())
+
+	client := NewClient(endpoint, 1, time.Second)
+	defer client.Close()
+	assert.ErrorContains(t, client.Connect(), "connect to "+endpoint)
+}
+
+func TestRegister_Validate(t *testing.T) {
+	assert.NoError(t, Register{Type: RegisterTypeInput, Words: 2}.Validate())
+	assert.EqualError(t, Register{Type: "coil", Words: 1}.Validate(), `unknown register type "coil"`)
+	assert.EqualError(t, Register{Type: RegisterTypeHolding, Words: 3}.Validate(), "words must be 1 or 2")
+}
@@ -0,0 +1,133 @@
+// Package modbustest provides a Modbus TCP server for the tests of the
+// Modbus clients.
+package modbustest
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"io"
+	"net"
+	"os"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// Registers are the values of the registers of a device, by address.
+type Registers struct {
+	Holding map[uint16]uint16
+	Input   map[uint16]uint16
+}
+
+// LoadRegisters reads the registers from a JSON file, with the values by
+// address in the holding and input objects:
+//
+//	{"holding": {"528": 20}, "input": {"100": 215}}
+func LoadRegisters(t testing.TB, path string) Registers {
+	t.Helper()
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	var fixture struct {
+		Holding map[string]uint16 `json:"holding"`
+		Input   map[string]uint16 `json:"input"`
+	}
+	require.NoError(t, json.Unmarshal(data, &fixture))
+
+	return Registers{
+		Holding: parseAddresses(t, fixture.Holding),
+		Input:   parseAddresses(t, fixture.Input),
+	}
+}
+
+func parseAddresses(t testing.TB, fixture map[string]uint16) map[uint16]uint16 {
+	registers := make(map[uint16]uint16, len(fixture))
+	for address, value := range fixture {
+		a, err := strconv.ParseUint(address, 10, 16)
+		require.NoError(t, err)
+		registers[uint16(a)] = value
+	}
+	return registers
+}
+
+// Server is a Modbus TCP server answering the read holding (0x03) and
+// input (0x04) registers requests from fixed registers.
+type Server struct {
+	listener  net.Listener
+	registers Registers
+}
+
+// NewServer starts a server answering with the registers. It stops at
+// the end of the test.
+func NewServer(t testing.TB, registers Registers) *Server {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = listener.Close() })
+
+	s := &Server{listener: listener, registers: registers}
+	go s.serve()
+	return s
+}
+
+// Addr returns the host:port of the server.
+func (s *Server) Addr() string {
+	return s.listener.Addr().String()
+}
+
+func (s *Server) serve() {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		go s.handle(conn)
+	}
+}
+
+func (s *Server) handle(conn net.Conn) {
+	defer conn.Close()
+
+	for {
+		// MBAP header: transaction, protocol, length, unit.
+		header := make([]byte, 7)
+		if _, err := io.ReadFull(conn, header); err != nil {
+			return
+		}
+		pdu := make([]byte, binary.BigEndian.Uint16(header[4:])-1)
+		if _, err := io.ReadFull(conn, pdu); err != nil {
+			return
+		}
+
+		response := s.respond(pdu)
+		binary.BigEndian.PutUint16(header[4:], uint16(len(response)+1))
+		if _, err := conn.Write(append(header, response...)); err != nil {
+			return
+		}
+	}
+}
+
+// respond returns the response PDU, or an illegal data address exception
+// when a register is missing.
+func (s *Server) respond(pdu []byte) []byte {
+	functionCode := pdu[0]
+	registers := s.registers.Input
+	if functionCode == 0x03 {
+		registers = s.registers.Holding
+	}
+
+	address := binary.BigEndian.Uint16(pdu[1:])
+	quantity := binary.BigEndian.Uint16(pdu[3:])
+
+	response := []byte{functionCode, byte(2 * quantity)}
+	for i := uint16(0); i < quantity; i++ {
+		value, ok := registers[address+i]
+		if !ok {
+			return []byte{functionCode | 0x80, 0x02}
+		}
+		response = binary.BigEndian.AppendUint16(response, value)
+	}
+	return response
+}
@@ -0,0 +1,360 @@
+package toggltrackreceiver
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/extension/xextension/storage"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.uber.org/zap"
+
+	"github.com/zmoog/collector/receiver/toggltrackreceiver/togglapi"
+)
+
+const (
+	// budgetEventName identifies the events of projects crossing a
+	// budget threshold.
+	budgetEventName = "toggl.project.budget_threshold"
+
+	// budgetStorageKey is the storage key of the projects utilization.
+	budgetStorageKey = "budget_utilization"
+)
+
+// projectBudget is the budget of a project along with the name of its
+// workspace.
+type projectBudget struct {
+	togglapi.ProjectBudget
+	WorkspaceName string
+}
+
+// estimate returns the estimated time of the project in seconds, if any.
+func (p projectBudget) estimate() (int64, bool) {
+	switch {
+	case p.EstimatedSeconds != nil && *p.EstimatedSeconds > 0:
+		return *p.EstimatedSeconds, true
+	case p.EstimatedHours != nil && *p.EstimatedHours > 0:
+		return *p.EstimatedHours * 3600, true
+	default:
+		return 0, false
+	}
+}
+
+// tracked returns the time tracked on the project in seconds.
+func (p projectBudget) tracked() int64 {
+	switch {
+	case p.ActualSeconds != nil:
+		return *p.ActualSeconds
+	case p.ActualHours != nil:
+		return *p.ActualHours * 3600
+	default:
+		return 0
+	}
+}
+
+// utilization returns the share of the budget used so far: the tracked
+// time over the estimate or, for fixed fee projects, the cost of the
+// tracked time at the project rate over the fee.
+func (p projectBudget) utilization() (float64, bool) {
+	if estimate, ok := p.estimate(); ok {
+		return float64(p.tracked()) / float64(estimate), true
+	}
+	if p.FixedFee != nil && *p.FixedFee > 0 && p.Rate != nil {
+		cost := float64(p.tracked()) / 3600 * *p.Rate
+		return cost / *p.FixedFee, true
+	}
+	return 0, false
+}
+
+// budgetScraper fetches the budgets of the projects of the configured
+// workspaces, or of all the token owner's workspaces.
+type budgetScraper struct {
+	client     *togglapi.Client
+	workspaces []int
+	logger     *zap.Logger
+}
+
+func newBudgetScraper(client *togglapi.Client, workspaces []int, logger *zap.Logger) *budgetScraper {
+	return &budgetScraper{
+		client:     client,
+		workspaces: workspaces,
+		logger:     logger,
+	}
+}
+
+// Scrape returns the budgets of the active projects.
+//
+// A workspace failing does not prevent scraping the others: the error
+// is returned along with the budgets of the remaining workspaces.
+func (s *budgetScraper) Scrape(ctx context.Context) ([]projectBudget, error) {
+	workspaceNames, errs := s.listWorkspaces(ctx)
+
+	var budgets []projectBudget
+	for _, wid := range slices.Sorted(maps.Keys(workspaceNames)) {
+		projects, err := s.client.ListProjectBudgets(ctx, wid)
+		if err != nil {
+			errs = errors.Join(errs, fmt.Errorf("workspace %d: %w", wid, err))
+			continue
+		}
+		for _, p := range projects {
+			budgets = append(budgets, projectBudget{ProjectBudget: p, WorkspaceName: workspaceNames[wid]})
+		}
+	}
+
+	s.logger.Debug("Scraped toggltrack project budgets", zap.Int("projects", len(budgets)))
+
+	return budgets, errs
+}
+
+// listWorkspaces returns the names of the workspaces to scrape by ID.
+func (s *budgetScraper) listWorkspaces(ctx context.Context) (map[int]string, error) {
+	names := make(map[int]string)
+
+	if len(s.workspaces) == 0 {
+		workspaces, err := s.client.ListWorkspaces(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, w := range workspaces {
+			names[w.ID] = w.Name
+		}
+		return names, nil
+	}
+
+	var errs error
+	for _, wid := range s.workspaces {
+		workspace, err := s.client.GetWorkspace(ctx, wid)
+		if err != nil {
+			errs = errors.Join(errs, fmt.Errorf("workspace %d: %w", wid, err))
+			continue
+		}
+		names[wid] = workspace.Name
+	}
+	return names, errs
+}
+
+// budgetMarshaler turns the project budgets into metrics and into log
+// events when a project crosses a threshold.
+type budgetMarshaler struct {
+	// thresholds are the percentages of the budget to warn about,
+	// sorted in ascending order.
+	thresholds []float64
+	// lastUtilization tracks the utilization of each project at the
+	// previous scrape, to detect the thresholds crossed since. It is
+	// persisted with save, so a restart doesn't report again the
+	// thresholds already crossed.
+	lastUtilization map[int]float64
+}
+
+func newBudgetMarshaler(thresholds []float64) *budgetMarshaler {
+	sorted := slices.Clone(thresholds)
+	slices.Sort(sorted)
+	return &budgetMarshaler{
+		thresholds:      sorted,
+		lastUtilization: make(map[int]float64),
+	}
+}
+
+// load restores the projects utilization from the storage.
+func (m *budgetMarshaler) load(ctx context.Context, client storage.Client) error {
+	data, err := client.Get(ctx, budgetStorageKey)
+	if err != nil {
+		return err
+	}
+	if data == nil {
+		return nil
+	}
+	return json.Unmarshal(data, &m.lastUtilization)
+}
+
+// save persists the projects utilization to the storage.
+func (m *budgetMarshaler) save(ctx context.Context, client storage.Client) error {
+	data, err := json.Marshal(m.lastUtilization)
+	if err != nil {
+		return err
+	}
+	return client.Set(ctx, budgetStorageKey, data)
+}
+
+// UnmarshalMetrics turns the project budgets into gauges.
+func (m *budgetMarshaler) UnmarshalMetrics(budgets []projectBudget) (pmetric.Metrics, error) {
+	timestamp := pcommon.NewTimestampFromTime(time.Now())
+	md := pmetric.NewMetrics()
+
+	scopeMetrics := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty()
+	scopeMetrics.Scope().SetName(scopeName)
+	scopeMetrics.Scope().SetVersion(scopeVersion)
+
+	metrics := scopeMetrics.Metrics()
+
+	estimate := metrics.AppendEmpty()
+	estimate.SetName("toggl.project.estimate")
+	estimate.SetDescription("Estimated time of the project")
+	estimate.SetUnit("s")
+	estimateDataPoints := estimate.SetEmptyGauge().DataPoints()
+
+	trackedTotal := metrics.AppendEmpty()
+	trackedTotal.SetName("toggl.project.tracked_total")
+	trackedTotal.SetDescription("Time tracked on the project")
+	trackedTotal.SetUnit("s")
+	trackedTotalDataPoints := trackedTotal.SetEmptyGauge().DataPoints()
+
+	fixedFee := metrics.AppendEmpty()
+	fixedFee.SetName("toggl.project.fixed_fee")
+	fixedFee.SetDescription("Fixed fee of the project")
+	fixedFee.SetUnit("{currency}")
+	fixedFeeDataPoints := fixedFee.SetEmptyGauge().DataPoints()
+
+	utilization := metrics.AppendEmpty()
+	utilization.SetName("toggl.project.budget.utilization")
+	utilization.SetDescription("Share of the project budget used so far")
+	utilization.SetUnit("1")
+	utilizationDataPoints := utilization.SetEmptyGauge().DataPoints()
+
+	for _, b := range budgets {
+		dp := trackedTotalDataPoints.AppendEmpty()
+		dp.SetIntValue(b.tracked())
+		dp.SetTimestamp(timestamp)
+		putProjectAttributes(dp.Attributes(), b)
+
+		if seconds, ok := b.estimate(); ok {
+			dp := estimateDataPoints.AppendEmpty()
+			dp.SetIntValue(seconds)
+			dp.SetTimestamp(timestamp)
+			putProjectAttributes(dp.Attributes(), b)
+		}
+
+		if b.FixedFee != nil && *b.FixedFee > 0 {
+			dp := fixedFeeDataPoints.AppendEmpty()
+			dp.SetDoubleValue(*b.FixedFee)
+			dp.SetTimestamp(timestamp)
+			putProjectAttributes(dp.Attributes(), b)
+			if b.Currency != nil {
+				dp.Attributes().PutStr("toggl.project.currency", *b.Currency)
+			}
+		}
+
+		if ratio, ok := b.utilization(); ok {
+			dp := utilizationDataPoints.AppendEmpty()
+			dp.SetDoubleValue(ratio)
+			dp.SetTimestamp(timestamp)
+			putProjectAttributes(dp.Attributes(), b)
+		}
+	}
+
+	return md, nil
+}
+
+// UnmarshalLogs returns a log event for every project that crossed a
+// threshold since the previous call. When a project jumps over several
+// thresholds at once, only the highest one is reported. Projects seen
+// for the first time, or after a restart without a storage extension,
+// report the highest threshold they are already over.
+func (m *budgetMarshaler) UnmarshalLogs(budgets []projectBudget) (plog.Logs, error) {
+	l := plog.NewLogs()
+
+	scopeLogs := l.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty()
+	scopeLogs.Scope().SetName(scopeName)
+	scopeLogs.Scope().SetVersion(scopeVersion)
+
+	timestamp := pcommon.NewTimestampFromTime(time.Now())
+
+	for _, b := range budgets {
+		ratio, ok := b.utilization()
+		if !ok {
+			continue
+		}
+
+		previous, seen := m.lastUtilization[b.ID]
+		m.lastUtilization[b.ID] = ratio
+
+		threshold, crossed := m.crossedThreshold(previous, seen, ratio)
+		if !crossed {
+			continue
+		}
+
+		lr := scopeLogs.LogRecords().AppendEmpty()
+		lr.SetTimestamp(timestamp)
+		lr.SetObservedTimestamp(timestamp)
+		lr.SetEventName(budgetEventName)
+		if threshold < 100 {
+			lr.SetSeverityNumber(plog.SeverityNumberWarn)
+			lr.SetSeverityText("WARN")
+		} else {
+			lr.SetSeverityNumber(plog.SeverityNumberError)
+			lr.SetSeverityText("ERROR")
+		}
+
+		a := lr.Attributes()
+		putProjectAttributes(a, b)
+		a.PutDouble("toggl.project.budget.threshold", threshold)
+		a.PutDouble("toggl.project.budget.utilization", ratio)
+
+		body := lr.Body().SetEmptyMap()
+		body.PutStr("message", fmt.Sprintf("Project %s used %.0f%% of its budget", b.Name, ratio*100))
+		body.PutDouble("threshold", threshold)
+		body.PutDouble("utilization", ratio)
+		body.PutInt("tracked", b.tracked())
+		if seconds, ok := b.estimate(); ok {
+			body.PutInt("estimate", seconds)
+		}
+		if b.FixedFee != nil && *b.FixedFee > 0 {
+			body.PutDouble("fixed_fee", *b.FixedFee)
+		}
+		project := body.PutEmptyMap("project")
+		project.PutStr("id", strconv.Itoa(b.ID))
+		project.PutStr("name", b.Name)
+		workspace := body.PutEmptyMap("workspace")
+		workspace.PutStr("id", strconv.Itoa(b.WorkspaceID))
+		workspace.PutStr("name", b.WorkspaceName)
+	}
+
+	return l, nil
+}
+
+// crossedThreshold returns the highest threshold the utilization went
+// over since the previous one.
+func (m *budgetMarshaler) crossedThreshold(previous float64, seen bool, current float64) (float64, bool) {
+	for i := len(m.thresholds) - 1; i >= 0; i-- {
+		limit := m.thresholds[i] / 100
+		if current >= limit {
+			return m.thresholds[i], !seen || previous < limit
+		}
+	}
+	return 0, false
+}
+
+func putProjectAttributes(a pcommon.Map, b projectBudget) {
+	a.PutStr("toggl.workspace.id", strconv.Itoa(b.WorkspaceID))
+	a.PutStr("toggl.workspace.name", b.WorkspaceName)
+	a.PutStr("toggl.project.id", strconv.Itoa(b.ID))
+	a.PutStr("toggl.project.name", b.Name)
+}
+
+// getStorageClient returns the client of the storage extension storageID,
+// or a client that does not persist anything when storageID is nil.
+func getStorageClient(ctx context.Context, host component.Host, storageID *component.ID, id component.ID) (storage.Client, error) {
+	if storageID == nil {
+		return storage.NewNopClient(), nil
+	}
+
+	ext, found := host.GetExtensions()[*storageID]
+	if !found {
+		return nil, fmt.Errorf("storage extension %s not found", storageID)
+	}
+
+	storageExt, ok := ext.(storage.Extension)
+	if !ok {
+		return nil, fmt.Errorf("extension %s is not a storage extension", storageID)
+	}
+
+	return storageExt.GetClient(ctx, component.KindReceiver, id, "")
+}
@@ -0,0 +1,293 @@
+package toggltrackreceiver
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/extension/xextension/storage"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.uber.org/zap"
+
+	"github.com/zmoog/collector/receiver/toggltrackreceiver/togglapi"
+)
+
+func TestBudgetScraper_Scrape(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v9/me/workspaces", func(w http.ResponseWriter, _ *http.Request) {
+		writeJSON(w, []map[string]any{{"id": 100, "name": "Agency"}})
+	})
+	mux.HandleFunc("GET /api/v9/workspaces/100", func(w http.ResponseWriter, _ *http.Request) {
+		writeJSON(w, map[string]any{"id": 100, "name": "Agency"})
+	})
+	mux.HandleFunc("GET /api/v9/workspaces/100/projects", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "true", r.URL.Query().Get("active"))
+		writeJSON(w, []map[string]any{
+			{"id": 200, "workspace_id": 100, "name": "Client A", "estimated_seconds": 36000, "actual_seconds": 30600},
+			{"id": 201, "workspace_id": 100, "name": "Client B", "fixed_fee": 1000.0, "rate": 50.0, "currency": "EUR", "actual_seconds": 36000},
+		})
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	tests := []struct {
+		name       string
+		workspaces []int
+	}{
+		{name: "all the token owner's workspaces"},
+		{name: "configured workspaces", workspaces: []int{100}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newBudgetScraper(togglapi.NewClient(togglapi.ClientConfig{APIToken: "my-token", APIEndpoint: server.URL}), tt.workspaces, zap.NewNop())
+
+			budgets, err := s.Scrape(context.Background())
+			require.NoError(t, err)
+			require.Len(t, budgets, 2)
+
+			assert.Equal(t, "Agency", budgets[0].WorkspaceName)
+
+			ratio, ok := budgets[0].utilization()
+			require.True(t, ok)
+			assert.InDelta(t, 0.85, ratio, 1e-9)
+
+			ratio, ok = budgets[1].utilization()
+			require.True(t, ok)
+			assert.InDelta(t, 0.5, ratio, 1e-9, "10h at 50 EUR/h over a 1000 EUR fee")
+		})
+	}
+}
+
+func TestBudgetScraper_ScrapeError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/v9/workspaces/100" {
+			writeJSON(w, map[string]any{"id": 100, "name": "Agency"})
+			return
+		}
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	s := newBudgetScraper(togglapi.NewClient(togglapi.ClientConfig{APIToken: "my-token", APIEndpoint: server.URL}), []int{100, 101}, zap.NewNop())
+
+	budgets, err := s.Scrape(context.Background())
+	assert.Empty(t, budgets)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "workspace 100")
+	assert.Contains(t, err.Error(), "workspace 101")
+}
+
+func TestBudgetMarshaler_UnmarshalMetrics(t *testing.T) {
+	m := newBudgetMarshaler(nil)
+
+	metrics, err := m.UnmarshalMetrics([]projectBudget{
+		newTestBudget(200, 36000, 30600),
+		{
+			ProjectBudget: togglapi.ProjectBudget{
+				ID: 201, WorkspaceID: 100, Name: "Internal",
+				ActualSeconds: int64Ptr(7200),
+			},
+			WorkspaceName: "Agency",
+		},
+	})
+	require.NoError(t, err)
+
+	got := make(map[string]pmetric.NumberDataPointSlice)
+	ms := metrics.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	for i := 0; i < ms.Len(); i++ {
+		got[ms.At(i).Name()] = ms.At(i).Gauge().DataPoints()
+	}
+
+	require.Equal(t, 2, got["toggl.project.tracked_total"].Len())
+	assert.Equal(t, int64(30600), got["toggl.project.tracked_total"].At(0).IntValue())
+
+	require.Equal(t, 1, got["toggl.project.estimate"].Len(), "projects without an estimate have no estimate")
+	assert.Equal(t, int64(36000), got["toggl.project.estimate"].At(0).IntValue())
+
+	require.Equal(t, 1, got["toggl.project.budget.utilization"].Len())
+	assert.InDelta(t, 0.85, got["toggl.project.budget.utilization"].At(0).DoubleValue(), 1e-9)
+
+	assert.Equal(t, 0, got["toggl.project.fixed_fee"].Len())
+
+	attrs := got["toggl.project.estimate"].At(0).Attributes().AsRaw()
+	assert.Equal(t, map[string]any{
+		"toggl.workspace.id":   "100",
+		"toggl.workspace.name": "Agency",
+		"toggl.project.id":     "200",
+		"toggl.project.name":   "Client A",
+	}, attrs)
+}
+
+func TestBudgetMarshaler_UnmarshalLogs(t *testing.T) {
+	m := newBudgetMarshaler([]float64{100, 80})
+
+	tests := []struct {
+		name              string
+		tracked           int64
+		expectedThreshold float64
+		expectedSeverity  plog.SeverityNumber
+	}{
+		{name: "below the thresholds", tracked: 7200},
+		{name: "crossing 80%", tracked: 29000, expectedThreshold: 80, expectedSeverity: plog.SeverityNumberWarn},
+		{name: "still over 80%", tracked: 30000},
+		{name: "crossing 100%", tracked: 36000, expectedThreshold: 100, expectedSeverity: plog.SeverityNumberError},
+		{name: "still over 100%", tracked: 40000},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logs, err := m.UnmarshalLogs([]projectBudget{newTestBudget(200, 36000, tt.tracked)})
+			require.NoError(t, err)
+
+			if tt.expectedThreshold == 0 {
+				assert.Equal(t, 0, logs.LogRecordCount())
+				return
+			}
+			require.Equal(t, 1, logs.LogRecordCount())
+
+			lr := logs.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0)
+			assert.Equal(t, "toggl.project.budget_threshold", lr.EventName())
+			assert.Equal(t, tt.expectedSeverity, lr.SeverityNumber())
+
+			threshold, ok := lr.Attributes().Get("toggl.project.budget.threshold")
+			require.True(t, ok)
+			assert.Equal(t, tt.expectedThreshold, threshold.Double())
+
+			project, ok := lr.Body().Map().Get("project")
+			require.True(t, ok)
+			assert.Equal(t, map[string]any{"id": "200", "name": "Client A"}, project.Map().AsRaw())
+		})
+	}
+}
+
+func TestBudgetMarshaler_UnmarshalLogsFirstScrape(t *testing.T) {
+	m := newBudgetMarshaler([]float64{80, 100})
+
+	logs, err := m.UnmarshalLogs([]projectBudget{newTestBudget(200, 36000, 40000)})
+	require.NoError(t, err)
+	require.Equal(t, 1, logs.LogRecordCount(), "Should only report the highest threshold")
+
+	lr := logs.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0)
+	threshold, _ := lr.Attributes().Get("toggl.project.budget.threshold")
+	assert.Equal(t, float64(100), threshold.Double())
+}
+
+// memoryStorage is a storage extension keeping the data in memory.
+type memoryStorage struct {
+	component.StartFunc
+	component.ShutdownFunc
+	data map[string][]byte
+}
+
+func (m *memoryStorage) GetClient(context.Context, component.Kind, component.ID, string) (storage.Client, error) {
+	return m, nil
+}
+
+func (m *memoryStorage) Get(_ context.Context, key string) ([]byte, error) {
+	return m.data[key], nil
+}
+
+func (m *memoryStorage) Set(_ context.Context, key string, value []byte) error {
+	m.data[key] = value
+	return nil
+}
+
+func (m *memoryStorage) Delete(_ context.Context, key string) error {
+	delete(m.data, key)
+	return nil
+}
+
+func (m *memoryStorage) Batch(context.Context, ...*storage.Operation) error {
+	return nil
+}
+
+func (m *memoryStorage) Close(context.Context) error {
+	return nil
+}
+
+// storageHost is a host with a memory storage extension.
+type storageHost struct {
+	component.Host
+	extensions map[component.ID]component.Component
+}
+
+func (h storageHost) GetExtensions() map[component.ID]component.Component {
+	return h.extensions
+}
+
+func TestBudgetMarshaler_Persistence(t *testing.T) {
+	ctx := context.Background()
+	storageID := component.MustNewID("memory")
+	host := storageHost{
+		Host:       componenttest.NewNopHost(),
+		extensions: map[component.ID]component.Component{storageID: &memoryStorage{data: make(map[string][]byte)}},
+	}
+
+	client, err := getStorageClient(ctx, host, &storageID, component.MustNewID("toggltrack"))
+	require.NoError(t, err)
+
+	m := newBudgetMarshaler([]float64{80, 100})
+	logs, err := m.UnmarshalLogs([]projectBudget{newTestBudget(200, 36000, 30000)})
+	require.NoError(t, err)
+	require.Equal(t, 1, logs.LogRecordCount())
+	require.NoError(t, m.save(ctx, client))
+
+	// A new marshaler, like after a restart, doesn't repeat the alert.
+	restored := newBudgetMarshaler([]float64{80, 100})
+	require.NoError(t, restored.load(ctx, client))
+	logs, err = restored.UnmarshalLogs([]projectBudget{newTestBudget(200, 36000, 31000)})
+	require.NoError(t, err)
+	assert.Equal(t, 0, logs.LogRecordCount())
+
+	// But reports the next threshold.
+	logs, err = restored.UnmarshalLogs([]projectBudget{newTestBudget(200, 36000, 36000)})
+	require.NoError(t, err)
+	assert.Equal(t, 1, logs.LogRecordCount())
+}
+
+func TestGetStorageClient(t *testing.T) {
+	ctx := context.Background()
+	id := component.MustNewID("toggltrack")
+
+	client, err := getStorageClient(ctx, componenttest.NewNopHost(), nil, id)
+	require.NoError(t, err)
+	assert.Equal(t, storage.NewNopClient(), client)
+
+	missing := component.MustNewID("file_storage")
+	_, err = getStorageClient(ctx, componenttest.NewNopHost(), &missing, id)
+	assert.EqualError(t, err, "storage extension file_storage not found")
+}
+
+func TestConfig_ValidateBudgets(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.APIToken = "token"
+	cfg.Budgets.Thresholds = []float64{80, 100}
+	assert.NoError(t, cfg.Validate())
+
+	cfg.Budgets.Thresholds = []float64{0}
+	assert.EqualError(t, cfg.Validate(), "budgets.thresholds must be positive percentages")
+}
+
+func newTestBudget(id int, estimate, tracked int64) projectBudget {
+	return projectBudget{
+		ProjectBudget: togglapi.ProjectBudget{
+			ID:               id,
+			WorkspaceID:      100,
+			Name:             "Client A",
+			EstimatedSeconds: &estimate,
+			ActualSeconds:    &tracked,
+		},
+		WorkspaceName: "Agency",
+	}
+}
+
+func int64Ptr(i int64) *int64 {
+	return &i
+}
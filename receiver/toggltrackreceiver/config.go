@@ -2,9 +2,15 @@ package toggltrackreceiver
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/config/configopaque"
 	"go.opentelemetry.io/collector/scraper/scraperhelper"
+
+	"github.com/zmoog/collector/receiver/toggltrackreceiver/togglapi"
 )
 
 const (
@@ -12,6 +18,26 @@ const (
 	MinLookback           = 1 * time.Hour
 )
 
+const (
+	// ModePoll periodically fetches the time entries from the Toggl API.
+	ModePoll = "poll"
+	// ModeWebhook receives the time entries pushed by a Toggl webhook
+	// subscription.
+	ModeWebhook = "webhook"
+	// ModeTeam periodically fetches the time entries of all the members
+	// of the configured workspaces.
+	ModeTeam = "team"
+)
+
+const (
+	// GroupByDay groups the spans of the entries of the same day (and
+	// user, in team mode) into a trace.
+	GroupByDay = "day"
+	// GroupByProject groups the spans of the entries of the same project
+	// into a trace.
+	GroupByProject = "project"
+)
+
 // mapping is a map of an ID to a name.
 type Mapping map[string]string
 
@@ -23,14 +49,89 @@ type Mappings struct {
 	Tasks      Mapping `mapstructure:"tasks"`
 }
 
+// WebhookConfig configures the HTTP server receiving the events
+// of a Toggl webhook subscription.
+type WebhookConfig struct {
+	confighttp.ServerConfig `mapstructure:",squash"`
+	// Path is the URL path the subscription posts the events to.
+	Path string `mapstructure:"path"`
+	// Secret is the subscription secret Toggl uses to sign the events.
+	Secret configopaque.String `mapstructure:"secret"`
+}
+
+// SplitByDayConfig configures splitting the time entries crossing
+// midnight into one log record per day.
+type SplitByDayConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+	// Timezone is the IANA name of the time zone of the day boundaries.
+	// When empty, the receiver uses the account time zone.
+	Timezone string `mapstructure:"timezone"`
+}
+
+// TracesConfig configures how the time entries are turned into spans.
+type TracesConfig struct {
+	// GroupBy sets which entries share the same trace.
+	GroupBy string `mapstructure:"group_by"`
+}
+
+// BudgetsConfig configures the project budget alerts.
+type BudgetsConfig struct {
+	// Thresholds are the percentages of the project budgets that trigger
+	// a log event when crossed. When empty, no alerts are emitted.
+	Thresholds []float64 `mapstructure:"thresholds"`
+}
+
 type Config struct {
 	scraperhelper.ControllerConfig `mapstructure:",squash"`
-	Lookback                       string   `mapstructure:"lookback"`
-	APIToken                       string   `mapstructure:"api_token"`
-	Mappings                       Mappings `mapstructure:"mappings"`
+	togglapi.ClientConfig          `mapstructure:",squash"`
+	Mode                           string           `mapstructure:"mode"`
+	Lookback                       string           `mapstructure:"lookback"`
+	Workspaces                     []int            `mapstructure:"workspaces"`
+	Mappings                       Mappings         `mapstructure:"mappings"`
+	SplitByDay                     SplitByDayConfig `mapstructure:"split_by_day"`
+	AttributeNaming                string           `mapstructure:"attribute_naming"`
+	Traces                         TracesConfig     `mapstructure:"traces"`
+	Budgets                        BudgetsConfig    `mapstructure:"budgets"`
+	Webhook                        WebhookConfig    `mapstructure:"webhook"`
+	// StorageID is the storage extension used to persist the projects
+	// budget utilization, so a restart doesn't repeat the alerts.
+	StorageID *component.ID `mapstructure:"storage"`
 }
 
 func (cfg *Config) Validate() error {
+	if _, ok := namingProfiles[cfg.AttributeNaming]; !ok {
+		return fmt.Errorf("attribute_naming must be one of %q, %q or %q", NamingFlat, NamingOTel, NamingECS)
+	}
+
+	if cfg.SplitByDay.Timezone != "" {
+		if _, err := time.LoadLocation(cfg.SplitByDay.Timezone); err != nil {
+			return fmt.Errorf("invalid split_by_day.timezone: %w", err)
+		}
+	}
+
+	if cfg.Traces.GroupBy != GroupByDay && cfg.Traces.GroupBy != GroupByProject {
+		return fmt.Errorf("traces.group_by must be one of %q or %q", GroupByDay, GroupByProject)
+	}
+
+	for _, threshold := range cfg.Budgets.Thresholds {
+		if threshold <= 0 {
+			return fmt.Errorf("budgets.thresholds must be positive percentages")
+		}
+	}
+
+	switch cfg.Mode {
+	case ModePoll:
+		return cfg.validatePoll()
+	case ModeWebhook:
+		return cfg.validateWebhook()
+	case ModeTeam:
+		return cfg.validateTeam()
+	default:
+		return fmt.Errorf("mode must be one of %q, %q or %q", ModePoll, ModeWebhook, ModeTeam)
+	}
+}
+
+func (cfg *Config) validatePoll() error {
 	if cfg.CollectionInterval < MinCollectionInterval {
 		return fmt.Errorf("collection_interval must be at least %s", MinCollectionInterval)
 	}
@@ -49,3 +150,31 @@ func (cfg *Config) Validate() error {
 
 	return nil
 }
+
+func (cfg *Config) validateWebhook() error {
+	if cfg.Webhook.Endpoint == "" {
+		return fmt.Errorf("webhook.endpoint is required")
+	}
+	if !strings.HasPrefix(cfg.Webhook.Path, "/") {
+		return fmt.Errorf("webhook.path must start with /")
+	}
+	if cfg.Webhook.Secret == "" {
+		return fmt.Errorf("webhook.secret is required")
+	}
+
+	return nil
+}
+
+func (cfg *Config) validateTeam() error {
+	if err := cfg.validatePoll(); err != nil {
+		return err
+	}
+	if cfg.APIEndpoint == "" {
+		return fmt.Errorf("api_endpoint is required")
+	}
+	if len(cfg.Workspaces) == 0 {
+		return fmt.Errorf("workspaces is required in %s mode", ModeTeam)
+	}
+
+	return nil
+}
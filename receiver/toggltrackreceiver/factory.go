@@ -6,12 +6,14 @@ import (
 	"time"
 
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/confighttp"
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/receiver"
 	"go.opentelemetry.io/collector/scraper"
 	"go.opentelemetry.io/collector/scraper/scraperhelper"
 
 	"github.com/zmoog/collector/receiver/toggltrackreceiver/internal/metadata"
+	"github.com/zmoog/collector/receiver/toggltrackreceiver/togglapi"
 )
 
 var (
@@ -21,14 +23,32 @@ var (
 const (
 	DefaultCollectionInterval = 1 * time.Minute
 	DefaultLookback           = 24 * 30 * time.Hour // 30 days
+	DefaultWebhookEndpoint    = "localhost:8088"
+	DefaultWebhookPath        = "/toggltrack"
 )
 
 func createDefaultConfig() component.Config {
 	cfg := scraperhelper.NewDefaultControllerConfig()
 	cfg.CollectionInterval = DefaultCollectionInterval
+
+	serverCfg := confighttp.NewDefaultServerConfig()
+	serverCfg.Endpoint = DefaultWebhookEndpoint
+
 	return &Config{
 		ControllerConfig: cfg,
+		Mode:             ModePoll,
 		Lookback:         DefaultLookback.String(),
+		ClientConfig: togglapi.ClientConfig{
+			APIEndpoint: togglapi.DefaultEndpoint,
+		},
+		AttributeNaming: NamingFlat,
+		Traces: TracesConfig{
+			GroupBy: GroupByDay,
+		},
+		Webhook: WebhookConfig{
+			ServerConfig: serverCfg,
+			Path:         DefaultWebhookPath,
+		},
 	}
 }
 
@@ -45,7 +65,8 @@ func createScraperFactory(cfg *Config, settings receiver.Settings) scraper.Facto
 			togglTrackScraper := newScraper(cfg, settings)
 			return scraper.NewLogs(
 				togglTrackScraper.scrape,
-				scraper.WithStart(togglTrackScraper.start),
+				scraper.WithStart(togglTrackScraper.startLogs),
+				scraper.WithShutdown(togglTrackScraper.shutdown),
 			)
 		}, component.StabilityLevelAlpha),
 	)
@@ -57,6 +78,10 @@ func createLogsReceiver(ctx context.Context, settings receiver.Settings, baseCfg
 		return nil, fmt.Errorf("invalid config type")
 	}
 
+	if cfg.Mode == ModeWebhook {
+		return newWebhookReceiver(cfg, settings, consumer), nil
+	}
+
 	scraperFactory := createScraperFactory(cfg, settings)
 
 	return scraperhelper.NewLogsController(
@@ -67,10 +92,54 @@ func createLogsReceiver(ctx context.Context, settings receiver.Settings, baseCfg
 	)
 }
 
+func createTracesReceiver(ctx context.Context, settings receiver.Settings, baseCfg component.Config, consumer consumer.Traces) (receiver.Traces, error) {
+	cfg, ok := baseCfg.(*Config)
+	if !ok {
+		return nil, fmt.Errorf("invalid config type")
+	}
+
+	if cfg.Mode == ModeWebhook {
+		return nil, fmt.Errorf("traces are not supported in %s mode", ModeWebhook)
+	}
+
+	return newTracesReceiver(cfg, settings, consumer), nil
+}
+
+func createMetricsReceiver(ctx context.Context, settings receiver.Settings, baseCfg component.Config, consumer consumer.Metrics) (receiver.Metrics, error) {
+	cfg, ok := baseCfg.(*Config)
+	if !ok {
+		return nil, fmt.Errorf("invalid config type")
+	}
+
+	if cfg.Mode == ModeWebhook {
+		return nil, fmt.Errorf("metrics are not supported in %s mode", ModeWebhook)
+	}
+
+	togglTrackScraper := newScraper(cfg, settings)
+
+	metrics, err := scraper.NewMetrics(
+		togglTrackScraper.scrapeMetrics,
+		scraper.WithStart(togglTrackScraper.start),
+		scraper.WithShutdown(togglTrackScraper.shutdown),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return scraperhelper.NewMetricsController(
+		&cfg.ControllerConfig,
+		settings,
+		consumer,
+		scraperhelper.AddScraper(metadata.Type, metrics),
+	)
+}
+
 func NewFactory() receiver.Factory {
 	return receiver.NewFactory(
 		typeStr,
 		createDefaultConfig,
 		receiver.WithLogs(createLogsReceiver, component.StabilityLevelAlpha),
+		receiver.WithTraces(createTracesReceiver, component.StabilityLevelAlpha),
+		receiver.WithMetrics(createMetricsReceiver, component.StabilityLevelAlpha),
 	)
 }
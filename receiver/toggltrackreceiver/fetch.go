@@ -0,0 +1,135 @@
+package toggltrackreceiver
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/jason0x43/go-toggl"
+	"go.uber.org/zap"
+
+	"github.com/zmoog/collector/receiver/toggltrackreceiver/togglapi"
+)
+
+// fetchers holds the fetcher of each receiver configuration. The
+// collector creates a receiver for every signal of a configuration, so
+// the logs, traces and metrics receivers share the same fetcher.
+var fetchers = &fetcherRegistry{fetchers: make(map[*Config]*registeredFetcher)}
+
+// teamData is the result of a team mode fetch.
+type teamData struct {
+	account toggl.Account
+	entries []teamTimeEntry
+}
+
+// fetcher fetches the data from the Toggl API on behalf of the signals
+// of a receiver. The data fetched for a signal is reused by the others
+// until half of the collection interval has passed, so each interval
+// costs a single set of API calls however many signals are enabled.
+type fetcher struct {
+	account *cachedFetch[toggl.Account]
+	team    *cachedFetch[teamData]
+	budgets *cachedFetch[[]projectBudget]
+}
+
+func newFetcher(cfg *Config, logger *zap.Logger) *fetcher {
+	client := togglapi.NewClient(cfg.ClientConfig)
+	accountScraper := NewScraper(cfg.APIToken, logger)
+	workspaceScraper := newWorkspaceScraper(client, cfg.Workspaces, logger)
+	budgetScraper := newBudgetScraper(client, cfg.Workspaces, logger)
+
+	maxAge := cfg.CollectionInterval / 2
+
+	return &fetcher{
+		account: newCachedFetch(maxAge, func(context.Context) (toggl.Account, error) {
+			return accountScraper.Scrape()
+		}),
+		team: newCachedFetch(maxAge, func(ctx context.Context) (teamData, error) {
+			lookback, err := time.ParseDuration(cfg.Lookback)
+			if err != nil {
+				return teamData{}, err
+			}
+
+			end := time.Now()
+			account, entries, err := workspaceScraper.Scrape(ctx, end.Add(-lookback), end)
+			return teamData{account: account, entries: entries}, err
+		}),
+		budgets: newCachedFetch(maxAge, budgetScraper.Scrape),
+	}
+}
+
+// cachedFetch returns the result of the last fetch while it is younger
+// than maxAge, and fetches again afterwards. Concurrent callers wait for
+// the running fetch instead of starting their own.
+type cachedFetch[T any] struct {
+	maxAge time.Duration
+	fetch  func(ctx context.Context) (T, error)
+
+	mu      sync.Mutex
+	fetched time.Time
+	value   T
+	err     error
+}
+
+func newCachedFetch[T any](maxAge time.Duration, fetch func(ctx context.Context) (T, error)) *cachedFetch[T] {
+	return &cachedFetch[T]{maxAge: maxAge, fetch: fetch}
+}
+
+func (c *cachedFetch[T]) get(ctx context.Context) (T, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.fetched.IsZero() && time.Since(c.fetched) < c.maxAge {
+		return c.value, c.err
+	}
+
+	value, err := c.fetch(ctx)
+	if ctx.Err() != nil {
+		// The caller gave up: the next one fetches again.
+		return value, err
+	}
+
+	c.value, c.err, c.fetched = value, err, time.Now()
+	return value, err
+}
+
+type registeredFetcher struct {
+	fetcher *fetcher
+	refs    int
+}
+
+// fetcherRegistry counts the receivers using each fetcher, and drops
+// the fetcher when the last one shuts down.
+type fetcherRegistry struct {
+	mu       sync.Mutex
+	fetchers map[*Config]*registeredFetcher
+}
+
+// acquire returns the fetcher of cfg, creating it on the first call.
+func (r *fetcherRegistry) acquire(cfg *Config, logger *zap.Logger) *fetcher {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	rf, found := r.fetchers[cfg]
+	if !found {
+		rf = &registeredFetcher{fetcher: newFetcher(cfg, logger)}
+		r.fetchers[cfg] = rf
+	}
+	rf.refs++
+	return rf.fetcher
+}
+
+// release drops a reference to the fetcher of cfg.
+func (r *fetcherRegistry) release(cfg *Config) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	rf, found := r.fetchers[cfg]
+	if !found {
+		return
+	}
+	rf.refs--
+	if rf.refs <= 0 {
+		delete(r.fetchers, cfg)
+	}
+}
@@ -0,0 +1,86 @@
+package toggltrackreceiver
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+
+	"github.com/zmoog/collector/receiver/toggltrackreceiver/internal/metadata"
+)
+
+func TestCachedFetch(t *testing.T) {
+	calls := 0
+	c := newCachedFetch(time.Hour, func(context.Context) (int, error) {
+		calls++
+		return calls, nil
+	})
+
+	value, err := c.get(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, 1, value)
+
+	// The other signals get the result of the same interval.
+	value, err = c.get(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, 1, value)
+	assert.Equal(t, 1, calls)
+
+	// The result expires.
+	c.fetched = time.Now().Add(-time.Hour)
+	value, err = c.get(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, 2, value)
+}
+
+func TestCachedFetch_Error(t *testing.T) {
+	calls := 0
+	c := newCachedFetch(time.Hour, func(ctx context.Context) (int, error) {
+		calls++
+		if ctx.Err() != nil {
+			return 0, ctx.Err()
+		}
+		return 0, errors.New("api unavailable")
+	})
+
+	// The errors are shared too, so the signals don't retry the API
+	// within the same interval.
+	_, err := c.get(t.Context())
+	require.EqualError(t, err, "api unavailable")
+	_, err = c.get(t.Context())
+	require.EqualError(t, err, "api unavailable")
+	assert.Equal(t, 1, calls)
+
+	// A fetch interrupted by its caller is not shared.
+	c.fetched = time.Time{}
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+	_, err = c.get(ctx)
+	require.ErrorIs(t, err, context.Canceled)
+	assert.True(t, c.fetched.IsZero())
+}
+
+func TestScraper_SharedFetcher(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.APIToken = "my-api-token"
+	settings := receivertest.NewNopSettings(metadata.Type)
+
+	logs := newScraper(cfg, settings)
+	metrics := newScraper(cfg, settings)
+	assert.Same(t, logs.fetcher, metrics.fetcher)
+
+	other := newScraper(createDefaultConfig().(*Config), settings)
+	assert.NotSame(t, logs.fetcher, other.fetcher)
+	require.NoError(t, other.shutdown(t.Context()))
+
+	require.NoError(t, logs.shutdown(t.Context()))
+	require.NoError(t, logs.shutdown(t.Context()))
+	assert.Contains(t, fetchers.fetchers, cfg, "still used by the metrics")
+
+	require.NoError(t, metrics.shutdown(t.Context()))
+	assert.NotContains(t, fetchers.fetchers, cfg)
+}
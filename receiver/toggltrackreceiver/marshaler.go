@@ -2,13 +2,17 @@ package toggltrackreceiver
 
 import (
 	"fmt"
+	"sort"
 	"strconv"
 	"time"
 
 	"github.com/jason0x43/go-toggl"
 
+	"github.com/zmoog/collector/receiver/toggltrackreceiver/togglapi"
+
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/ptrace"
 )
 
 const (
@@ -19,24 +23,95 @@ const (
 type timeEntryMarshaler struct {
 	mappings          Mappings `mapstructure:"mappings"`
 	lastTimeEntryTime time.Time
+	// lastTimeEntryTimeByUser tracks the last processed entry of
+	// each workspace member in team mode.
+	lastTimeEntryTimeByUser map[int]time.Time
+	// splitByDay splits the entries crossing midnight into one log
+	// record per day.
+	splitByDay bool
+	// location is the configured time zone for the day boundaries; when
+	// nil, the account time zone is used.
+	location *time.Location
+	// naming is the profile used to write the log record attributes.
+	naming namingProfile
+	// groupBy sets which spans share the same trace.
+	groupBy string
 }
 
 func newTimeEntryMarshaler(mappings Mappings) *timeEntryMarshaler {
-	return &timeEntryMarshaler{mappings: mappings}
+	return &timeEntryMarshaler{
+		mappings:                mappings,
+		lastTimeEntryTimeByUser: make(map[int]time.Time),
+		naming:                  namingProfiles[NamingFlat],
+		groupBy:                 GroupByDay,
+	}
+}
+
+// withSplitByDay configures the marshaler to split the entries at the
+// day boundaries. The time zone has already been validated.
+func (m *timeEntryMarshaler) withSplitByDay(cfg SplitByDayConfig) *timeEntryMarshaler {
+	m.splitByDay = cfg.Enabled
+	if cfg.Timezone != "" {
+		m.location, _ = time.LoadLocation(cfg.Timezone)
+	}
+	return m
+}
+
+// withAttributeNaming configures the naming profile of the log record
+// attributes. The name has already been validated.
+func (m *timeEntryMarshaler) withAttributeNaming(name string) *timeEntryMarshaler {
+	if profile, ok := namingProfiles[name]; ok {
+		m.naming = profile
+	}
+	return m
+}
+
+// withTraces configures how the entries are grouped into traces.
+func (m *timeEntryMarshaler) withTraces(cfg TracesConfig) *timeEntryMarshaler {
+	if cfg.GroupBy != "" {
+		m.groupBy = cfg.GroupBy
+	}
+	return m
 }
 
 func (m *timeEntryMarshaler) UnmarshalLogs(account toggl.Account) (plog.Logs, error) {
-	l := plog.NewLogs()
+	return m.logs(m.timeEntries(account)), nil
+}
 
-	resourceLogs := l.ResourceLogs().AppendEmpty()
+// UnmarshalTraces turns the time entries into spans, grouped into one
+// trace per day or per project.
+func (m *timeEntryMarshaler) UnmarshalTraces(account toggl.Account) (ptrace.Traces, error) {
+	return m.traces(m.timeEntries(account), m.timezone(account)), nil
+}
 
-	scopeLogs := resourceLogs.ScopeLogs().AppendEmpty()
-	scopeLogs.Scope().SetName(scopeName)
-	scopeLogs.Scope().SetVersion(scopeVersion)
-	logRecords := scopeLogs.LogRecords()
+// UnmarshalTeamLogs turns the time entries of all the workspace members
+// into logs, adding the user attributes. Entries already processed are
+// skipped per user, so a member's late sync does not hide the entries of
+// the others.
+func (m *timeEntryMarshaler) UnmarshalTeamLogs(account toggl.Account, entries []teamTimeEntry) (plog.Logs, error) {
+	return m.logs(m.teamTimeEntries(account, entries)), nil
+}
 
-	// Unify the observed timestamp for all log records.
-	observedTimestamp := pcommon.NewTimestampFromTime(time.Now())
+// UnmarshalTeamTraces is the traces counterpart of UnmarshalTeamLogs.
+func (m *timeEntryMarshaler) UnmarshalTeamTraces(account toggl.Account, entries []teamTimeEntry) (ptrace.Traces, error) {
+	return m.traces(m.teamTimeEntries(account, entries), m.timezone(account)), nil
+}
+
+// UnmarshalTimeEntry turns a single completed time entry into logs. Unlike
+// UnmarshalLogs, it does not skip entries that stopped before the last
+// processed one: pushed events may update entries we have already seen.
+func (m *timeEntryMarshaler) UnmarshalTimeEntry(e toggl.TimeEntry, account toggl.Account) (plog.Logs, error) {
+	if e.IsRunning() || e.Stop == nil {
+		return m.logs(nil), nil
+	}
+
+	return m.logs(m.appendTimeEntry(nil, e, nil, account)), nil
+}
+
+// timeEntries returns the records of the account's completed time
+// entries we haven't processed yet.
+func (m *timeEntryMarshaler) timeEntries(account toggl.Account) []timeEntryRecord {
+	var records []timeEntryRecord
 
 	// account.TimeEntries is sorted with the latest entries first, so we need
 	// to walk backwards to make skipping already processed entries easier.
@@ -54,40 +129,135 @@ func (m *timeEntryMarshaler) UnmarshalLogs(account toggl.Account) (plog.Logs, er
 		}
 		m.lastTimeEntryTime = *e.Stop
 
-		lr := logRecords.AppendEmpty()
-		lr.SetTimestamp(pcommon.NewTimestampFromTime(*e.Stop))
-		lr.SetObservedTimestamp(observedTimestamp)
+		records = m.appendTimeEntry(records, e, nil, account)
+	}
 
-		a := lr.Attributes()
-		a.PutStr("id", strconv.Itoa(e.ID))
-		a.PutStr("workspace.id", strconv.Itoa(e.Wid))
-		a.PutStr("description", e.Description)
-		a.PutStr("start", e.Start.Format(time.RFC3339))
-		a.PutStr("end", e.Stop.Format(time.RFC3339)) // `end` is ECS compliant
-		a.PutInt("duration", e.Duration)
-		a.PutStr("billable", strconv.FormatBool(e.Billable))
-		if e.Pid != nil {
-			a.PutStr("project.id", strconv.Itoa(*e.Pid))
-		}
-		if e.Tid != nil {
-			a.PutStr("task.id", strconv.Itoa(*e.Tid))
-		}
+	return records
+}
 
-		a.PutStr("workspace.name", lookupName(account.Workspaces, e.Wid))
-		if e.Pid != nil {
-			a.PutStr("project.name", lookupName(account.Projects, *e.Pid))
+// teamTimeEntries returns the records of the workspace members' completed
+// time entries we haven't processed yet.
+func (m *timeEntryMarshaler) teamTimeEntries(account toggl.Account, entries []teamTimeEntry) []timeEntryRecord {
+	completed := make([]teamTimeEntry, 0, len(entries))
+	for _, e := range entries {
+		if e.IsRunning() || e.Stop == nil {
+			// We don't care about running entries
+			continue
 		}
-		if e.Tid != nil {
-			a.PutStr("task.name", lookupName(account.Tasks, *e.Tid))
+		completed = append(completed, e)
+	}
+
+	// The report is not sorted by stop time, so we sort the entries
+	// to make skipping already processed entries easier.
+	sort.SliceStable(completed, func(i, j int) bool {
+		return completed[i].Stop.Before(*completed[j].Stop)
+	})
+
+	var records []timeEntryRecord
+	for _, e := range completed {
+		if !e.Stop.After(m.lastTimeEntryTimeByUser[e.User.ID]) {
+			// We've already processed this entry
+			continue
 		}
+		m.lastTimeEntryTimeByUser[e.User.ID] = *e.Stop
 
-		tags := a.PutEmptySlice("tags")
-		for _, tag := range e.Tags {
-			tags.AppendEmpty().SetStr(tag)
+		records = m.appendTimeEntry(records, e.TimeEntry, &e.User, account)
+	}
+
+	return records
+}
+
+// logs turns the time entry records into log records.
+func (m *timeEntryMarshaler) logs(records []timeEntryRecord) plog.Logs {
+	l := plog.NewLogs()
+
+	resourceLogs := l.ResourceLogs().AppendEmpty()
+
+	scopeLogs := resourceLogs.ScopeLogs().AppendEmpty()
+	scopeLogs.Scope().SetName(scopeName)
+	scopeLogs.Scope().SetVersion(scopeVersion)
+
+	// Unify the observed timestamp for all log records.
+	observedTimestamp := pcommon.NewTimestampFromTime(time.Now())
+
+	for _, r := range records {
+		lr := scopeLogs.LogRecords().AppendEmpty()
+		lr.SetTimestamp(pcommon.NewTimestampFromTime(r.timestamp()))
+		lr.SetObservedTimestamp(observedTimestamp)
+
+		m.naming.write(lr, r)
+	}
+
+	return l
+}
+
+// appendTimeEntry appends the records for the completed time entry e:
+// one record, or one per day when splitting by day. The user is nil
+// outside team mode.
+func (m *timeEntryMarshaler) appendTimeEntry(records []timeEntryRecord, e toggl.TimeEntry, user *togglapi.WorkspaceUser, account toggl.Account) []timeEntryRecord {
+	r := newTimeEntryRecord(e, user, account)
+
+	if !m.splitByDay {
+		return append(records, r)
+	}
+
+	location := m.timezone(account)
+	for _, s := range splitByDay(r.start, r.stop, r.duration, location) {
+		segment := r
+		segment.start = s.start
+		segment.stop = s.stop
+		segment.duration = s.duration
+
+		// Segments of the same entry share the parent ID, but need
+		// their own ID to be stored as separate documents.
+		segment.id = fmt.Sprintf("%d-%s", e.ID, s.start.Format(time.DateOnly))
+		segment.parentID = r.id
+		segment.timezone = location.String()
+
+		records = append(records, segment)
+	}
+
+	return records
+}
+
+// timezone returns the time zone of the day boundaries: the configured
+// one, or the account one, or UTC as a last resort.
+func (m *timeEntryMarshaler) timezone(account toggl.Account) *time.Location {
+	if m.location != nil {
+		return m.location
+	}
+	if account.Timezone != "" {
+		if location, err := time.LoadLocation(account.Timezone); err == nil {
+			return location
 		}
 	}
+	return time.UTC
+}
 
-	return l, nil
+// newTimeEntryRecord creates the record of the completed time entry e,
+// looking up the workspace, project and task names in account.
+func newTimeEntryRecord(e toggl.TimeEntry, user *togglapi.WorkspaceUser, account toggl.Account) timeEntryRecord {
+	r := timeEntryRecord{
+		id:            strconv.Itoa(e.ID),
+		description:   e.Description,
+		start:         *e.Start,
+		stop:          *e.Stop,
+		duration:      e.Duration,
+		billable:      e.Billable,
+		workspaceID:   strconv.Itoa(e.Wid),
+		workspaceName: lookupName(account.Workspaces, e.Wid),
+		tags:          e.Tags,
+		user:          user,
+	}
+	if e.Pid != nil {
+		r.projectID = strconv.Itoa(*e.Pid)
+		r.projectName = lookupName(account.Projects, *e.Pid)
+	}
+	if e.Tid != nil {
+		r.taskID = strconv.Itoa(*e.Tid)
+		r.taskName = lookupName(account.Tasks, *e.Tid)
+	}
+	return r
 }
 
 // entityWithIDAndName is a constraint for types that have ID and Name fields.
@@ -97,22 +267,30 @@ type entityWithIDAndName interface {
 
 // lookupName is a generic function that looks up an entity by ID and returns its name.
 func lookupName[T entityWithIDAndName](entities []T, id int) string {
+	if name, ok := findName(entities, id); ok {
+		return name
+	}
+	return fmt.Sprintf("Unknown (%d)", id)
+}
+
+// findName looks up an entity by ID and returns its name, if found.
+func findName[T entityWithIDAndName](entities []T, id int) (string, bool) {
 	for _, entity := range entities {
 		// Use type assertion to access ID and Name fields
 		switch e := any(entity).(type) {
 		case toggl.Workspace:
 			if e.ID == id {
-				return e.Name
+				return e.Name, true
 			}
 		case toggl.Project:
 			if e.ID == id {
-				return e.Name
+				return e.Name, true
 			}
 		case toggl.Task:
 			if e.ID == id {
-				return e.Name
+				return e.Name, true
 			}
 		}
 	}
-	return fmt.Sprintf("Unknown (%d)", id)
+	return "", false
 }
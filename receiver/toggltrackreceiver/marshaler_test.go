@@ -7,7 +7,10 @@ import (
 	"github.com/jason0x43/go-toggl"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/plog"
+
+	"github.com/zmoog/collector/receiver/toggltrackreceiver/togglapi"
 )
 
 func TestTimeEntryMarshaler_UnmarshalLogs(t *testing.T) {
@@ -96,7 +99,7 @@ func TestTimeEntryMarshaler_UnmarshalLogs(t *testing.T) {
 
 				billable, ok := attrs.Get("billable")
 				require.True(t, ok)
-				assert.Equal(t, "true", billable.Str())
+				assert.True(t, billable.Bool())
 
 				tags, ok := attrs.Get("tags")
 				require.True(t, ok)
@@ -413,6 +416,369 @@ func TestTimeEntryMarshaler_StatePersistence(t *testing.T) {
 	assert.Equal(t, "2", id.Str(), "Should process the new entry")
 }
 
+func TestTimeEntryMarshaler_UnmarshalTeamLogs(t *testing.T) {
+	m := newTimeEntryMarshaler(Mappings{})
+
+	alice := togglapi.WorkspaceUser{ID: 1, FullName: "Alice", Email: "alice@example.com"}
+	bob := togglapi.WorkspaceUser{ID: 2, FullName: "Bob"}
+
+	account := toggl.Account{
+		Workspaces: []toggl.Workspace{{ID: 100, Name: "Agency"}},
+		Projects:   []toggl.Project{{ID: 200, Name: "Client A"}},
+	}
+
+	entries := []teamTimeEntry{
+		{
+			TimeEntry: toggl.TimeEntry{
+				ID:       11,
+				Wid:      100,
+				Pid:      intPtr(200),
+				Start:    timePtr(time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC)),
+				Stop:     timePtr(time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC)),
+				Duration: 10800,
+			},
+			User: alice,
+		},
+		{
+			TimeEntry: toggl.TimeEntry{
+				ID:       21,
+				Wid:      100,
+				Start:    timePtr(time.Date(2024, 1, 15, 8, 0, 0, 0, time.UTC)),
+				Stop:     timePtr(time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC)),
+				Duration: 3600,
+			},
+			User: bob,
+		},
+		{
+			TimeEntry: toggl.TimeEntry{
+				ID:       22,
+				Wid:      100,
+				Start:    timePtr(time.Date(2024, 1, 15, 13, 0, 0, 0, time.UTC)),
+				Duration: -1,
+			},
+			User: bob,
+		},
+	}
+
+	logs, err := m.UnmarshalTeamLogs(account, entries)
+	require.NoError(t, err)
+
+	logRecords := logs.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords()
+	require.Equal(t, 2, logRecords.Len(), "Should skip the running entry")
+
+	// Entries are emitted in stop time order.
+	first := logRecords.At(0).Attributes()
+	id, _ := first.Get("id")
+	assert.Equal(t, "21", id.Str())
+	userID, _ := first.Get("user.id")
+	assert.Equal(t, "2", userID.Str())
+	userName, _ := first.Get("user.name")
+	assert.Equal(t, "Bob", userName.Str())
+	_, ok := first.Get("user.email")
+	assert.False(t, ok, "Should not have user.email when unknown")
+
+	second := logRecords.At(1).Attributes()
+	userEmail, ok := second.Get("user.email")
+	require.True(t, ok)
+	assert.Equal(t, "alice@example.com", userEmail.Str())
+	projectName, _ := second.Get("project.name")
+	assert.Equal(t, "Client A", projectName.Str())
+
+	// Bob's late entry stops before Alice's last one, but it is still
+	// new for Bob, so it must not be skipped.
+	lateEntries := []teamTimeEntry{
+		entries[0],
+		{
+			TimeEntry: toggl.TimeEntry{
+				ID:       23,
+				Wid:      100,
+				Start:    timePtr(time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)),
+				Stop:     timePtr(time.Date(2024, 1, 15, 11, 0, 0, 0, time.UTC)),
+				Duration: 3600,
+			},
+			User: bob,
+		},
+	}
+
+	logs, err = m.UnmarshalTeamLogs(account, lateEntries)
+	require.NoError(t, err)
+
+	logRecords = logs.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords()
+	require.Equal(t, 1, logRecords.Len(), "Should only process Bob's new entry")
+	id, _ = logRecords.At(0).Attributes().Get("id")
+	assert.Equal(t, "23", id.Str())
+}
+
+func TestTimeEntryMarshaler_SplitByDay(t *testing.T) {
+	rome, err := time.LoadLocation("Europe/Rome")
+	require.NoError(t, err)
+
+	account := toggl.Account{
+		// The account time zone is used when none is configured.
+		Timezone: "Europe/Rome",
+		TimeEntries: []toggl.TimeEntry{
+			{
+				ID:  42,
+				Wid: 100,
+				// 22:00 to 02:00 in Rome (UTC+1 in January).
+				Start:    timePtr(time.Date(2024, 1, 15, 21, 0, 0, 0, time.UTC)),
+				Stop:     timePtr(time.Date(2024, 1, 16, 1, 0, 0, 0, time.UTC)),
+				Duration: 14400,
+			},
+		},
+		Workspaces: []toggl.Workspace{{ID: 100, Name: "My Workspace"}},
+	}
+
+	m := newTimeEntryMarshaler(Mappings{}).withSplitByDay(SplitByDayConfig{Enabled: true})
+
+	logs, err := m.UnmarshalLogs(account)
+	require.NoError(t, err)
+
+	logRecords := logs.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords()
+	require.Equal(t, 2, logRecords.Len(), "Should split the entry at midnight")
+
+	expected := []struct {
+		id        string
+		start     string
+		end       string
+		duration  int64
+		timestamp time.Time
+	}{
+		{"42-2024-01-15", "2024-01-15T22:00:00+01:00", "2024-01-16T00:00:00+01:00", 7200, time.Date(2024, 1, 15, 22, 0, 0, 0, rome)},
+		{"42-2024-01-16", "2024-01-16T00:00:00+01:00", "2024-01-16T02:00:00+01:00", 7200, time.Date(2024, 1, 16, 0, 0, 0, 0, rome)},
+	}
+	for i, e := range expected {
+		lr := logRecords.At(i)
+		assert.Equal(t, e.timestamp.UnixNano(), lr.Timestamp().AsTime().UnixNano())
+
+		attrs := lr.Attributes()
+		id, _ := attrs.Get("id")
+		assert.Equal(t, e.id, id.Str())
+		parentID, _ := attrs.Get("parent.id")
+		assert.Equal(t, "42", parentID.Str())
+		timezone, _ := attrs.Get("timezone")
+		assert.Equal(t, "Europe/Rome", timezone.Str())
+		start, _ := attrs.Get("start")
+		assert.Equal(t, e.start, start.Str())
+		end, _ := attrs.Get("end")
+		assert.Equal(t, e.end, end.Str())
+		duration, _ := attrs.Get("duration")
+		assert.Equal(t, e.duration, duration.Int())
+	}
+
+	// The configured time zone takes precedence over the account one:
+	// in UTC the same entry runs from 21:00 to 01:00, and is split at
+	// the UTC midnight.
+	m = newTimeEntryMarshaler(Mappings{}).withSplitByDay(SplitByDayConfig{Enabled: true, Timezone: "UTC"})
+
+	logs, err = m.UnmarshalLogs(account)
+	require.NoError(t, err)
+
+	logRecords = logs.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords()
+	require.Equal(t, 2, logRecords.Len())
+	timezone, _ := logRecords.At(0).Attributes().Get("timezone")
+	assert.Equal(t, "UTC", timezone.Str())
+	end, _ := logRecords.At(0).Attributes().Get("end")
+	assert.Equal(t, "2024-01-16T00:00:00Z", end.Str())
+	assert.Equal(t, time.Date(2024, 1, 15, 21, 0, 0, 0, time.UTC), logRecords.At(0).Timestamp().AsTime())
+	assert.Equal(t, time.Date(2024, 1, 16, 0, 0, 0, 0, time.UTC), logRecords.At(1).Timestamp().AsTime())
+}
+
+func TestTimeEntryMarshaler_EventSemantics(t *testing.T) {
+	m := newTimeEntryMarshaler(Mappings{})
+	account := toggl.Account{
+		TimeEntries: []toggl.TimeEntry{
+			{
+				ID:          12345,
+				Wid:         100,
+				Pid:         intPtr(200),
+				Description: "Testing feature X",
+				Start:       timePtr(time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC)),
+				Stop:        timePtr(time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC)),
+				Duration:    5400,
+				Billable:    true,
+				Tags:        []string{"development"},
+			},
+		},
+		Workspaces: []toggl.Workspace{{ID: 100, Name: "My Workspace"}},
+		Projects:   []toggl.Project{{ID: 200, Name: "Project Alpha"}},
+	}
+
+	logs, err := m.UnmarshalLogs(account)
+	require.NoError(t, err)
+	lr := logs.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0)
+
+	assert.Equal(t, "toggl.time_entry", lr.EventName())
+	assert.Equal(t, plog.SeverityNumberInfo, lr.SeverityNumber())
+	assert.Equal(t, "INFO", lr.SeverityText())
+
+	require.Equal(t, pcommon.ValueTypeMap, lr.Body().Type())
+	assert.Equal(t, map[string]any{
+		"id":          "12345",
+		"description": "Testing feature X",
+		"start":       "2024-01-15T09:00:00Z",
+		"end":         "2024-01-15T10:30:00Z",
+		"duration":    int64(5400),
+		"billable":    true,
+		"workspace":   map[string]any{"id": "100", "name": "My Workspace"},
+		"project":     map[string]any{"id": "200", "name": "Project Alpha"},
+		"tags":        []any{"development"},
+	}, lr.Body().Map().AsRaw())
+}
+
+func TestTimeEntryMarshaler_AttributeNaming(t *testing.T) {
+	alice := togglapi.WorkspaceUser{ID: 1, FullName: "Alice", Email: "alice@example.com"}
+	account := toggl.Account{
+		Workspaces: []toggl.Workspace{{ID: 100, Name: "My Workspace"}},
+		Tasks:      []toggl.Task{{ID: 300, Name: "Feature Implementation"}},
+	}
+	entries := []teamTimeEntry{
+		{
+			TimeEntry: toggl.TimeEntry{
+				ID:          12345,
+				Wid:         100,
+				Tid:         intPtr(300),
+				Description: "Testing feature X",
+				Start:       timePtr(time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC)),
+				Stop:        timePtr(time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC)),
+				Duration:    5400,
+				Billable:    true,
+			},
+			User: alice,
+		},
+	}
+
+	tests := []struct {
+		naming   string
+		expected map[string]any
+	}{
+		{
+			naming: NamingFlat,
+			expected: map[string]any{
+				"id":             "12345",
+				"description":    "Testing feature X",
+				"start":          "2024-01-15T09:00:00Z",
+				"end":            "2024-01-15T10:30:00Z",
+				"duration":       int64(5400),
+				"billable":       true,
+				"workspace.id":   "100",
+				"workspace.name": "My Workspace",
+				"task.id":        "300",
+				"task.name":      "Feature Implementation",
+				"tags":           []any{},
+				"user.id":        "1",
+				"user.name":      "Alice",
+				"user.email":     "alice@example.com",
+			},
+		},
+		{
+			naming: NamingOTel,
+			expected: map[string]any{
+				"toggl.time_entry.id":          "12345",
+				"toggl.time_entry.description": "Testing feature X",
+				"toggl.time_entry.start":       "2024-01-15T09:00:00Z",
+				"toggl.time_entry.end":         "2024-01-15T10:30:00Z",
+				"toggl.time_entry.duration":    int64(5400),
+				"toggl.time_entry.billable":    true,
+				"toggl.workspace.id":           "100",
+				"toggl.workspace.name":         "My Workspace",
+				"toggl.task.id":                "300",
+				"toggl.task.name":              "Feature Implementation",
+				"toggl.time_entry.tags":        []any{},
+				"user.id":                      "1",
+				"user.name":                    "Alice",
+				"user.email":                   "alice@example.com",
+			},
+		},
+		{
+			naming: NamingECS,
+			expected: map[string]any{
+				"event.id":          "12345",
+				"message":           "Testing feature X",
+				"event.start":       "2024-01-15T09:00:00Z",
+				"event.end":         "2024-01-15T10:30:00Z",
+				"event.duration":    int64(5400 * time.Second),
+				"toggl.billable":    true,
+				"organization.id":   "100",
+				"organization.name": "My Workspace",
+				"toggl.task.id":     "300",
+				"toggl.task.name":   "Feature Implementation",
+				"tags":              []any{},
+				"user.id":           "1",
+				"user.full_name":    "Alice",
+				"user.email":        "alice@example.com",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.naming, func(t *testing.T) {
+			m := newTimeEntryMarshaler(Mappings{}).withAttributeNaming(tt.naming)
+
+			logs, err := m.UnmarshalTeamLogs(account, entries)
+			require.NoError(t, err)
+			lr := logs.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0)
+
+			assert.Equal(t, tt.expected, lr.Attributes().AsRaw())
+
+			// The body does not depend on the naming profile.
+			user, ok := lr.Body().Map().Get("user")
+			require.True(t, ok)
+			assert.Equal(t, map[string]any{"id": "1", "name": "Alice", "email": "alice@example.com"}, user.Map().AsRaw())
+		})
+	}
+}
+
+func TestConfig_ValidateAttributeNaming(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.APIToken = "token"
+	assert.NoError(t, cfg.Validate())
+
+	cfg.AttributeNaming = "camel"
+	assert.EqualError(t, cfg.Validate(), `attribute_naming must be one of "flat", "otel" or "ecs"`)
+}
+
+func TestSplitByDay(t *testing.T) {
+	rome, err := time.LoadLocation("Europe/Rome")
+	require.NoError(t, err)
+
+	t.Run("within a single day", func(t *testing.T) {
+		start := time.Date(2024, 1, 15, 9, 0, 0, 0, rome)
+		segments := splitByDay(start, start.Add(time.Hour), 3600, rome)
+		require.Len(t, segments, 1)
+		assert.Equal(t, int64(3600), segments[0].duration)
+	})
+
+	t.Run("across several days", func(t *testing.T) {
+		start := time.Date(2024, 1, 15, 12, 0, 0, 0, rome)
+		stop := time.Date(2024, 1, 17, 12, 0, 0, 0, rome)
+		segments := splitByDay(start, stop, 172800, rome)
+		require.Len(t, segments, 3)
+		assert.Equal(t, []int64{43200, 86400, 43200}, []int64{segments[0].duration, segments[1].duration, segments[2].duration})
+	})
+
+	t.Run("across a DST change", func(t *testing.T) {
+		// Clocks go back from 03:00 to 02:00 on 2024-10-27 in Rome,
+		// so 00:00 to 04:00 lasts five hours.
+		start := time.Date(2024, 10, 26, 23, 0, 0, 0, rome)
+		stop := time.Date(2024, 10, 27, 4, 0, 0, 0, rome)
+		segments := splitByDay(start, stop, 21600, rome)
+		require.Len(t, segments, 2)
+		assert.Equal(t, int64(3600), segments[0].duration)
+		assert.Equal(t, int64(18000), segments[1].duration)
+	})
+
+	t.Run("durations add up to the entry duration", func(t *testing.T) {
+		start := time.Date(2024, 1, 15, 23, 59, 59, 0, rome)
+		stop := time.Date(2024, 1, 16, 0, 0, 2, 0, rome)
+		// The tracked duration may differ from the interval, e.g.
+		// with rounding enabled in the workspace.
+		segments := splitByDay(start, stop, 4, rome)
+		require.Len(t, segments, 2)
+		assert.Equal(t, int64(4), segments[0].duration+segments[1].duration)
+	})
+}
+
 // Helper functions
 func intPtr(i int) *int {
 	return &i
@@ -0,0 +1,258 @@
+package toggltrackreceiver
+
+import (
+	"strconv"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+
+	"github.com/zmoog/collector/receiver/toggltrackreceiver/togglapi"
+)
+
+const (
+	// NamingFlat keeps the original flat attribute names.
+	NamingFlat = "flat"
+	// NamingOTel uses namespaced attribute names following the
+	// OpenTelemetry semantic conventions style.
+	NamingOTel = "otel"
+	// NamingECS uses Elastic Common Schema field names.
+	NamingECS = "ecs"
+
+	// eventName identifies the time entry events.
+	eventName = "toggl.time_entry"
+)
+
+// timeEntryRecord holds the fields of a time entry log record before
+// they are written with the configured naming profile.
+type timeEntryRecord struct {
+	id            string
+	description   string
+	start         time.Time
+	stop          time.Time
+	duration      int64 // seconds
+	billable      bool
+	workspaceID   string
+	workspaceName string
+	projectID     string // empty when the entry has no project
+	projectName   string
+	taskID        string // empty when the entry has no task
+	taskName      string
+	tags          []string
+	user          *togglapi.WorkspaceUser // nil outside team mode
+	parentID      string                  // empty unless splitting by day
+	timezone      string                  // empty unless splitting by day
+}
+
+// timestamp returns the time of the record: the stop time of an entry,
+// or the start time of a segment, whose stop time is the next midnight
+// when it is not the last one.
+func (r timeEntryRecord) timestamp() time.Time {
+	if r.parentID != "" {
+		return r.start
+	}
+	return r.stop
+}
+
+// attributeKeys holds the attribute keys of a naming profile.
+type attributeKeys struct {
+	id            string
+	description   string
+	start         string
+	end           string
+	duration      string
+	billable      string
+	workspaceID   string
+	workspaceName string
+	projectID     string
+	projectName   string
+	taskID        string
+	taskName      string
+	tags          string
+	userID        string
+	userName      string
+	userEmail     string
+	parentID      string
+	timezone      string
+}
+
+// namingProfile defines how the time entry fields are written as
+// log record attributes.
+type namingProfile struct {
+	keys attributeKeys
+	// durationUnit is the unit of the duration attribute.
+	durationUnit time.Duration
+}
+
+var namingProfiles = map[string]namingProfile{
+	NamingFlat: {
+		keys: attributeKeys{
+			id:            "id",
+			description:   "description",
+			start:         "start",
+			end:           "end", // `end` is ECS compliant
+			duration:      "duration",
+			billable:      "billable",
+			workspaceID:   "workspace.id",
+			workspaceName: "workspace.name",
+			projectID:     "project.id",
+			projectName:   "project.name",
+			taskID:        "task.id",
+			taskName:      "task.name",
+			tags:          "tags",
+			userID:        "user.id",
+			userName:      "user.name",
+			userEmail:     "user.email",
+			parentID:      "parent.id",
+			timezone:      "timezone",
+		},
+		durationUnit: time.Second,
+	},
+	NamingOTel: {
+		keys: attributeKeys{
+			id:            "toggl.time_entry.id",
+			description:   "toggl.time_entry.description",
+			start:         "toggl.time_entry.start",
+			end:           "toggl.time_entry.end",
+			duration:      "toggl.time_entry.duration",
+			billable:      "toggl.time_entry.billable",
+			workspaceID:   "toggl.workspace.id",
+			workspaceName: "toggl.workspace.name",
+			projectID:     "toggl.project.id",
+			projectName:   "toggl.project.name",
+			taskID:        "toggl.task.id",
+			taskName:      "toggl.task.name",
+			tags:          "toggl.time_entry.tags",
+			userID:        "user.id",
+			userName:      "user.name",
+			userEmail:     "user.email",
+			parentID:      "toggl.time_entry.parent_id",
+			timezone:      "toggl.time_entry.timezone",
+		},
+		durationUnit: time.Second,
+	},
+	NamingECS: {
+		keys: attributeKeys{
+			id:            "event.id",
+			description:   "message",
+			start:         "event.start",
+			end:           "event.end",
+			duration:      "event.duration",
+			billable:      "toggl.billable",
+			workspaceID:   "organization.id",
+			workspaceName: "organization.name",
+			projectID:     "toggl.project.id",
+			projectName:   "toggl.project.name",
+			taskID:        "toggl.task.id",
+			taskName:      "toggl.task.name",
+			tags:          "tags",
+			userID:        "user.id",
+			userName:      "user.full_name",
+			userEmail:     "user.email",
+			parentID:      "toggl.parent.id",
+			timezone:      "event.timezone",
+		},
+		// ECS expects event.duration in nanoseconds.
+		durationUnit: time.Nanosecond,
+	},
+}
+
+// write sets the event name, severity, attributes and body of the log
+// record from the time entry record.
+func (p namingProfile) write(lr plog.LogRecord, r timeEntryRecord) {
+	lr.SetEventName(eventName)
+	lr.SetSeverityNumber(plog.SeverityNumberInfo)
+	lr.SetSeverityText("INFO")
+
+	p.writeAttributes(lr.Attributes(), r)
+	writeBody(lr.Body().SetEmptyMap(), r)
+}
+
+func (p namingProfile) writeAttributes(a pcommon.Map, r timeEntryRecord) {
+	k := p.keys
+
+	a.PutStr(k.id, r.id)
+	a.PutStr(k.workspaceID, r.workspaceID)
+	a.PutStr(k.description, r.description)
+	a.PutStr(k.start, r.start.Format(time.RFC3339))
+	a.PutStr(k.end, r.stop.Format(time.RFC3339))
+	a.PutInt(k.duration, r.duration*int64(time.Second/p.durationUnit))
+	a.PutBool(k.billable, r.billable)
+	if r.projectID != "" {
+		a.PutStr(k.projectID, r.projectID)
+	}
+	if r.taskID != "" {
+		a.PutStr(k.taskID, r.taskID)
+	}
+
+	a.PutStr(k.workspaceName, r.workspaceName)
+	if r.projectID != "" {
+		a.PutStr(k.projectName, r.projectName)
+	}
+	if r.taskID != "" {
+		a.PutStr(k.taskName, r.taskName)
+	}
+
+	tags := a.PutEmptySlice(k.tags)
+	for _, tag := range r.tags {
+		tags.AppendEmpty().SetStr(tag)
+	}
+
+	if r.user != nil {
+		a.PutStr(k.userID, strconv.Itoa(r.user.ID))
+		a.PutStr(k.userName, r.user.FullName)
+		if r.user.Email != "" {
+			a.PutStr(k.userEmail, r.user.Email)
+		}
+	}
+
+	if r.parentID != "" {
+		a.PutStr(k.parentID, r.parentID)
+		a.PutStr(k.timezone, r.timezone)
+	}
+}
+
+// writeBody writes the time entry as a structured map with native types.
+// The body has the same shape regardless of the naming profile.
+func writeBody(body pcommon.Map, r timeEntryRecord) {
+	body.PutStr("id", r.id)
+	body.PutStr("description", r.description)
+	body.PutStr("start", r.start.Format(time.RFC3339))
+	body.PutStr("end", r.stop.Format(time.RFC3339))
+	body.PutInt("duration", r.duration)
+	body.PutBool("billable", r.billable)
+
+	workspace := body.PutEmptyMap("workspace")
+	workspace.PutStr("id", r.workspaceID)
+	workspace.PutStr("name", r.workspaceName)
+
+	if r.projectID != "" {
+		project := body.PutEmptyMap("project")
+		project.PutStr("id", r.projectID)
+		project.PutStr("name", r.projectName)
+	}
+	if r.taskID != "" {
+		task := body.PutEmptyMap("task")
+		task.PutStr("id", r.taskID)
+		task.PutStr("name", r.taskName)
+	}
+
+	tags := body.PutEmptySlice("tags")
+	for _, tag := range r.tags {
+		tags.AppendEmpty().SetStr(tag)
+	}
+
+	if r.user != nil {
+		user := body.PutEmptyMap("user")
+		user.PutStr("id", strconv.Itoa(r.user.ID))
+		user.PutStr("name", r.user.FullName)
+		if r.user.Email != "" {
+			user.PutStr("email", r.user.Email)
+		}
+	}
+
+	if r.parentID != "" {
+		body.PutStr("parent_id", r.parentID)
+		body.PutStr("timezone", r.timezone)
+	}
+}
@@ -2,28 +2,41 @@ package toggltrackreceiver
 
 import (
 	"context"
+	"sync"
 
+	"github.com/jason0x43/go-toggl"
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/extension/xextension/storage"
 	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.opentelemetry.io/collector/receiver"
+	"go.opentelemetry.io/collector/scraper/scrapererror"
 	"go.uber.org/zap"
 )
 
 // togglTrackScraper is the struct that contains the TogglTrack scraper.
 type togglTrackScraper struct {
-	cfg       *Config
-	settings  component.TelemetrySettings
-	scraper   *accountScraper
-	marshaler *timeEntryMarshaler
+	cfg             *Config
+	id              component.ID
+	settings        component.TelemetrySettings
+	storageClient   storage.Client
+	fetcher         *fetcher
+	marshaler       *timeEntryMarshaler
+	budgetMarshaler *budgetMarshaler
+	release         sync.Once
 }
 
-// newScraper creates a new TogglTrack scraper.
+// newScraper creates a new TogglTrack scraper. The scrapers of the
+// signals of the same receiver share the fetcher.
 func newScraper(cfg *Config, settings receiver.Settings) *togglTrackScraper {
 	return &togglTrackScraper{
-		cfg:       cfg,
-		settings:  settings.TelemetrySettings,
-		scraper:   NewScraper(cfg.APIToken, settings.Logger),
-		marshaler: newTimeEntryMarshaler(cfg.Mappings),
+		cfg:             cfg,
+		id:              settings.ID,
+		settings:        settings.TelemetrySettings,
+		fetcher:         fetchers.acquire(cfg, settings.Logger),
+		marshaler:       newTimeEntryMarshaler(cfg.Mappings).withSplitByDay(cfg.SplitByDay).withAttributeNaming(cfg.AttributeNaming).withTraces(cfg.Traces),
+		budgetMarshaler: newBudgetMarshaler(cfg.Budgets.Thresholds),
 	}
 }
 
@@ -33,8 +46,54 @@ func (s *togglTrackScraper) start(_ context.Context, host component.Host) error
 	return nil
 }
 
+// startLogs starts the logs scraper and restores the projects budget
+// utilization from the storage.
+func (s *togglTrackScraper) startLogs(ctx context.Context, host component.Host) (err error) {
+	s.storageClient, err = getStorageClient(ctx, host, s.cfg.StorageID, s.id)
+	if err != nil {
+		return err
+	}
+
+	if err := s.budgetMarshaler.load(ctx, s.storageClient); err != nil {
+		s.settings.Logger.Warn("Error loading the project budgets utilization, starting from scratch", zap.Error(err))
+	}
+
+	return s.start(ctx, host)
+}
+
+// shutdown releases the fetcher shared with the other signals and
+// closes the storage client.
+func (s *togglTrackScraper) shutdown(ctx context.Context) error {
+	s.release.Do(func() { fetchers.release(s.cfg) })
+	if s.storageClient != nil {
+		return s.storageClient.Close(ctx)
+	}
+	return nil
+}
+
 // scrape is the main function that scrapes the data from the TogglTrack API.
 func (s *togglTrackScraper) scrape(ctx context.Context) (plog.Logs, error) {
+	logs, err := s.scrapeTimeEntries(ctx)
+	if len(s.cfg.Budgets.Thresholds) == 0 {
+		return logs, err
+	}
+
+	alerts, budgetErr := s.scrapeBudgetAlerts(ctx)
+	alerts.ResourceLogs().MoveAndAppendTo(logs.ResourceLogs())
+	if err == nil && budgetErr != nil {
+		// Keep the time entries even if the budgets are unavailable.
+		return logs, scrapererror.NewPartialScrapeError(budgetErr, 0)
+	}
+
+	return logs, err
+}
+
+// scrapeTimeEntries scrapes the time entries and turns them into logs.
+func (s *togglTrackScraper) scrapeTimeEntries(ctx context.Context) (plog.Logs, error) {
+	if s.cfg.Mode == ModeTeam {
+		return s.scrapeTeam(ctx)
+	}
+
 	// lookback, err := time.ParseDuration(s.cfg.Lookback)
 	// if err != nil {
 	// 	s.settings.Logger.Error("Error parsing lookback duration", zap.Error(err))
@@ -42,7 +101,7 @@ func (s *togglTrackScraper) scrape(ctx context.Context) (plog.Logs, error) {
 	// }
 
 	// _, entries, err := s.scraper.Scrape(time.Now(), lookback)
-	account, err := s.scraper.Scrape()
+	account, err := s.fetcher.account.get(ctx)
 	if err != nil {
 		s.settings.Logger.Error("Error scraping toggltrack", zap.Error(err))
 		return plog.NewLogs(), err
@@ -63,3 +122,127 @@ func (s *togglTrackScraper) scrape(ctx context.Context) (plog.Logs, error) {
 
 	return logs, nil
 }
+
+// scrapeTeam scrapes the time entries of all the members of the
+// configured workspaces.
+func (s *togglTrackScraper) scrapeTeam(ctx context.Context) (plog.Logs, error) {
+	account, entries, scrapeErr := s.scrapeWorkspaces(ctx)
+	if scrapeErr != nil && len(entries) == 0 {
+		return plog.NewLogs(), scrapeErr
+	}
+
+	logs, err := s.marshaler.UnmarshalTeamLogs(account, entries)
+	if err != nil {
+		s.settings.Logger.Error("Error marshaling toggltrack team entries", zap.Error(err))
+		return plog.NewLogs(), err
+	}
+
+	if scrapeErr != nil {
+		// Keep the entries of the workspaces we could scrape.
+		return logs, scrapererror.NewPartialScrapeError(scrapeErr, 0)
+	}
+
+	return logs, nil
+}
+
+// scrapeTraces is the traces counterpart of scrape.
+func (s *togglTrackScraper) scrapeTraces(ctx context.Context) (ptrace.Traces, error) {
+	if s.cfg.Mode == ModeTeam {
+		return s.scrapeTeamTraces(ctx)
+	}
+
+	account, err := s.fetcher.account.get(ctx)
+	if err != nil {
+		s.settings.Logger.Error("Error scraping toggltrack", zap.Error(err))
+		return ptrace.NewTraces(), err
+	}
+
+	s.settings.Logger.Info("Scraped toggltrack entries", zap.Int("count", len(account.TimeEntries)))
+
+	traces, err := s.marshaler.UnmarshalTraces(account)
+	if err != nil {
+		s.settings.Logger.Error("Error marshaling toggltrack entries", zap.Error(err))
+		return ptrace.NewTraces(), err
+	}
+
+	return traces, nil
+}
+
+// scrapeTeamTraces is the traces counterpart of scrapeTeam.
+func (s *togglTrackScraper) scrapeTeamTraces(ctx context.Context) (ptrace.Traces, error) {
+	account, entries, scrapeErr := s.scrapeWorkspaces(ctx)
+	if scrapeErr != nil && len(entries) == 0 {
+		return ptrace.NewTraces(), scrapeErr
+	}
+
+	traces, err := s.marshaler.UnmarshalTeamTraces(account, entries)
+	if err != nil {
+		s.settings.Logger.Error("Error marshaling toggltrack team entries", zap.Error(err))
+		return ptrace.NewTraces(), err
+	}
+
+	if scrapeErr != nil {
+		// Keep the entries of the workspaces we could scrape.
+		return traces, scrapererror.NewPartialScrapeError(scrapeErr, 0)
+	}
+
+	return traces, nil
+}
+
+// scrapeWorkspaces fetches the time entries of the lookback window from
+// the configured workspaces. When some workspaces fail, it returns the
+// entries of the others along with the error.
+func (s *togglTrackScraper) scrapeWorkspaces(ctx context.Context) (toggl.Account, []teamTimeEntry, error) {
+	team, err := s.fetcher.team.get(ctx)
+	if err != nil {
+		s.settings.Logger.Error("Error scraping toggltrack workspaces", zap.Error(err))
+	}
+
+	s.settings.Logger.Info("Scraped toggltrack team entries", zap.Int("count", len(team.entries)))
+
+	return team.account, team.entries, err
+}
+
+// scrapeBudgetAlerts scrapes the project budgets and returns the events
+// of the projects crossing a threshold.
+func (s *togglTrackScraper) scrapeBudgetAlerts(ctx context.Context) (plog.Logs, error) {
+	budgets, scrapeErr := s.fetcher.budgets.get(ctx)
+	if scrapeErr != nil {
+		s.settings.Logger.Error("Error scraping toggltrack project budgets", zap.Error(scrapeErr))
+	}
+
+	logs, err := s.budgetMarshaler.UnmarshalLogs(budgets)
+	if err != nil {
+		return plog.NewLogs(), err
+	}
+
+	if err := s.budgetMarshaler.save(ctx, s.storageClient); err != nil {
+		s.settings.Logger.Warn("Error saving the project budgets utilization", zap.Error(err))
+	}
+
+	return logs, scrapeErr
+}
+
+// scrapeMetrics scrapes the project budgets and turns them into metrics.
+func (s *togglTrackScraper) scrapeMetrics(ctx context.Context) (pmetric.Metrics, error) {
+	budgets, scrapeErr := s.fetcher.budgets.get(ctx)
+	if scrapeErr != nil {
+		s.settings.Logger.Error("Error scraping toggltrack project budgets", zap.Error(scrapeErr))
+		if len(budgets) == 0 {
+			return pmetric.NewMetrics(), scrapeErr
+		}
+	}
+
+	metrics, err := s.budgetMarshaler.UnmarshalMetrics(budgets)
+	if err != nil {
+		s.settings.Logger.Error("Error marshaling toggltrack project budgets", zap.Error(err))
+		return pmetric.NewMetrics(), err
+	}
+
+	if scrapeErr != nil {
+		// Keep the budgets of the workspaces we could scrape.
+		return metrics, scrapererror.NewPartialScrapeError(scrapeErr, 0)
+	}
+
+	return metrics, nil
+}
@@ -1,8 +1,16 @@
 package toggltrackreceiver
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
 	toggl "github.com/jason0x43/go-toggl"
 	"go.uber.org/zap"
+
+	"github.com/zmoog/collector/receiver/toggltrackreceiver/togglapi"
 )
 
 func NewScraper(apiToken string, logger *zap.Logger) *accountScraper {
@@ -73,3 +81,139 @@ func (s *accountScraper) Scrape() (toggl.Account, error) {
 	}
 	return account, nil
 }
+
+// teamTimeEntry is a time entry along with the workspace member who
+// tracked it.
+type teamTimeEntry struct {
+	toggl.TimeEntry
+	User togglapi.WorkspaceUser
+}
+
+// workspaceScraper fetches the time entries of all the members of the
+// configured workspaces. It requires the token of a workspace admin.
+type workspaceScraper struct {
+	client     *togglapi.Client
+	workspaces []int
+	logger     *zap.Logger
+}
+
+func newWorkspaceScraper(client *togglapi.Client, workspaces []int, logger *zap.Logger) *workspaceScraper {
+	return &workspaceScraper{
+		client:     client,
+		workspaces: workspaces,
+		logger:     logger,
+	}
+}
+
+// Scrape returns the time entries of all the workspace members started
+// between start and end. The returned account only carries the
+// workspaces, projects and tasks used to look up the names.
+//
+// A workspace failing does not prevent scraping the others: the error
+// is returned along with the entries of the remaining workspaces.
+func (s *workspaceScraper) Scrape(ctx context.Context, start, end time.Time) (toggl.Account, []teamTimeEntry, error) {
+	var account toggl.Account
+	var entries []teamTimeEntry
+	var errs error
+
+	for _, wid := range s.workspaces {
+		workspaceEntries, err := s.scrapeWorkspace(ctx, wid, start, end, &account)
+		if err != nil {
+			errs = errors.Join(errs, fmt.Errorf("workspace %d: %w", wid, err))
+			continue
+		}
+		entries = append(entries, workspaceEntries...)
+	}
+
+	return account, entries, errs
+}
+
+func (s *workspaceScraper) scrapeWorkspace(ctx context.Context, wid int, start, end time.Time, account *toggl.Account) ([]teamTimeEntry, error) {
+	workspace, err := s.client.GetWorkspace(ctx, wid)
+	if err != nil {
+		return nil, err
+	}
+	users, err := s.client.ListWorkspaceUsers(ctx, wid)
+	if err != nil {
+		return nil, err
+	}
+	projects, err := s.client.ListProjects(ctx, wid)
+	if err != nil {
+		return nil, err
+	}
+	tasks, err := s.client.ListTasks(ctx, wid)
+	if err != nil {
+		return nil, err
+	}
+	tags, err := s.client.ListTags(ctx, wid)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := s.client.SearchTimeEntries(ctx, wid, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	account.Workspaces = append(account.Workspaces, workspace)
+	account.Projects = append(account.Projects, projects...)
+	account.Tasks = append(account.Tasks, tasks...)
+
+	usersByID := make(map[int]togglapi.WorkspaceUser, len(users))
+	for _, u := range users {
+		usersByID[u.ID] = u
+	}
+	tagNames := make(map[int]string, len(tags))
+	for _, t := range tags {
+		tagNames[t.ID] = t.Name
+	}
+
+	var entries []teamTimeEntry
+	for _, row := range rows {
+		user, ok := usersByID[row.UserID]
+		if !ok {
+			// Former members are no longer listed, but their
+			// entries are still in the reports.
+			user = togglapi.WorkspaceUser{ID: row.UserID, FullName: row.Username}
+		}
+
+		tagList := make([]string, 0, len(row.TagIDs))
+		for _, id := range row.TagIDs {
+			if name, ok := tagNames[id]; ok {
+				tagList = append(tagList, name)
+			} else {
+				tagList = append(tagList, strconv.Itoa(id))
+			}
+		}
+
+		for _, re := range row.TimeEntries {
+			entryStart := re.Start
+			duration := re.Seconds
+			if re.Stop == nil {
+				// Running entries have a negative duration.
+				duration = -1
+			}
+			entries = append(entries, teamTimeEntry{
+				TimeEntry: toggl.TimeEntry{
+					ID:          re.ID,
+					Wid:         wid,
+					Pid:         row.ProjectID,
+					Tid:         row.TaskID,
+					Description: row.Description,
+					Start:       &entryStart,
+					Stop:        re.Stop,
+					Duration:    duration,
+					Billable:    row.Billable,
+					Tags:        tagList,
+				},
+				User: user,
+			})
+		}
+	}
+
+	s.logger.Debug("Scraped toggltrack workspace",
+		zap.Int("workspace_id", wid),
+		zap.Int("users", len(users)),
+		zap.Int("entries", len(entries)))
+
+	return entries, nil
+}
@@ -0,0 +1,123 @@
+package toggltrackreceiver
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/zmoog/collector/receiver/toggltrackreceiver/togglapi"
+)
+
+func TestWorkspaceScraper_Scrape(t *testing.T) {
+	var reportRequests []togglapi.ReportRequest
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v9/workspaces/100", func(w http.ResponseWriter, _ *http.Request) {
+		writeJSON(w, map[string]any{"id": 100, "name": "Agency"})
+	})
+	mux.HandleFunc("GET /api/v9/workspaces/100/users", func(w http.ResponseWriter, _ *http.Request) {
+		writeJSON(w, []map[string]any{
+			{"id": 1, "fullname": "Alice", "email": "alice@example.com"},
+		})
+	})
+	mux.HandleFunc("GET /api/v9/workspaces/100/projects", func(w http.ResponseWriter, _ *http.Request) {
+		writeJSON(w, []map[string]any{{"id": 200, "workspace_id": 100, "name": "Client A"}})
+	})
+	mux.HandleFunc("GET /api/v9/workspaces/100/tasks", func(w http.ResponseWriter, _ *http.Request) {
+		writeJSON(w, map[string]any{"data": []map[string]any{}})
+	})
+	mux.HandleFunc("GET /api/v9/workspaces/100/tags", func(w http.ResponseWriter, _ *http.Request) {
+		writeJSON(w, []map[string]any{{"id": 7, "workspace_id": 100, "name": "meeting"}})
+	})
+	mux.HandleFunc("POST /reports/api/v3/workspace/100/search/time_entries", func(w http.ResponseWriter, r *http.Request) {
+		var req togglapi.ReportRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		reportRequests = append(reportRequests, req)
+
+		if req.FirstRowNumber == 0 {
+			w.Header().Set(togglapi.NextRowHeader, "2")
+			writeJSON(w, []map[string]any{{
+				"user_id": 1, "username": "Alice", "project_id": 200, "description": "Kickoff", "tag_ids": []int{7},
+				"time_entries": []map[string]any{
+					{"id": 11, "seconds": 3600, "start": "2024-01-15T09:00:00+00:00", "stop": "2024-01-15T10:00:00+00:00"},
+				},
+			}})
+			return
+		}
+		writeJSON(w, []map[string]any{{
+			// Former members are not listed in the workspace users.
+			"user_id": 3, "username": "Carol", "description": "Review",
+			"time_entries": []map[string]any{
+				{"id": 31, "seconds": 1800, "start": "2024-01-15T11:00:00+00:00", "stop": "2024-01-15T11:30:00+00:00"},
+				{"id": 32, "seconds": -1, "start": "2024-01-15T12:00:00+00:00", "stop": nil},
+			},
+		}})
+	})
+	mux.HandleFunc("/", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	})
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, password, ok := r.BasicAuth()
+		if !ok || user != "my-token" || password != "api_token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		mux.ServeHTTP(w, r)
+	}))
+	defer server.Close()
+
+	s := newWorkspaceScraper(togglapi.NewClient(togglapi.ClientConfig{APIToken: "my-token", APIEndpoint: server.URL}), []int{100}, zap.NewNop())
+
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)
+	account, entries, err := s.Scrape(context.Background(), start, end)
+	require.NoError(t, err)
+
+	require.Len(t, reportRequests, 2, "Should walk all the report pages")
+	assert.Equal(t, "2024-01-01", reportRequests[0].StartDate)
+	assert.Equal(t, "2024-01-31", reportRequests[0].EndDate)
+	assert.Equal(t, 2, reportRequests[1].FirstRowNumber)
+
+	assert.Equal(t, "Agency", lookupName(account.Workspaces, 100))
+	assert.Equal(t, "Client A", lookupName(account.Projects, 200))
+
+	require.Len(t, entries, 3)
+
+	assert.Equal(t, 11, entries[0].ID)
+	assert.Equal(t, 100, entries[0].Wid)
+	assert.Equal(t, []string{"meeting"}, entries[0].Tags)
+	assert.Equal(t, "alice@example.com", entries[0].User.Email)
+
+	assert.Equal(t, 31, entries[1].ID)
+	assert.Equal(t, togglapi.WorkspaceUser{ID: 3, FullName: "Carol"}, entries[1].User)
+
+	assert.True(t, entries[2].IsRunning())
+}
+
+func TestWorkspaceScraper_ScrapeError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	s := newWorkspaceScraper(togglapi.NewClient(togglapi.ClientConfig{APIToken: "not-an-admin", APIEndpoint: server.URL}), []int{100, 101}, zap.NewNop())
+
+	_, entries, err := s.Scrape(context.Background(), time.Now().Add(-time.Hour), time.Now())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "workspace 100")
+	assert.Contains(t, err.Error(), "workspace 101")
+	assert.Empty(t, entries)
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
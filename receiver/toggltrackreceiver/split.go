@@ -0,0 +1,59 @@
+package toggltrackreceiver
+
+import (
+	"time"
+
+	// Embed the time zone database, so the day boundaries work
+	// on images without one.
+	_ "time/tzdata"
+)
+
+// segment is the part of a time entry falling within a single day.
+type segment struct {
+	start    time.Time
+	stop     time.Time
+	duration int64
+}
+
+// splitByDay splits the interval between start and stop at the midnights
+// of the location. Each segment gets a share of duration proportional to
+// its length; the last one gets the rounding remainder, so the segment
+// durations always add up to duration.
+//
+// The segment start and stop times are expressed in the location.
+func splitByDay(start, stop time.Time, duration int64, location *time.Location) []segment {
+	start = start.In(location)
+	stop = stop.In(location)
+
+	total := stop.Sub(start)
+	if total <= 0 {
+		return []segment{{start: start, stop: stop, duration: duration}}
+	}
+
+	var segments []segment
+	var assigned int64
+	for segmentStart := start; segmentStart.Before(stop); {
+		y, mo, d := segmentStart.Date()
+		// time.Date normalizes the day overflow and handles
+		// the days shortened or lengthened by DST changes.
+		segmentStop := time.Date(y, mo, d+1, 0, 0, 0, 0, location)
+		if segmentStop.After(stop) {
+			segmentStop = stop
+		}
+
+		segmentDuration := int64(float64(duration) * float64(segmentStop.Sub(segmentStart)) / float64(total))
+		if !segmentStop.Before(stop) {
+			segmentDuration = duration - assigned
+		}
+		assigned += segmentDuration
+
+		segments = append(segments, segment{
+			start:    segmentStart,
+			stop:     segmentStop,
+			duration: segmentDuration,
+		})
+		segmentStart = segmentStop
+	}
+
+	return segments
+}
@@ -0,0 +1,290 @@
+// Package togglapi is a Toggl Track API client shared by the Toggl Track
+// receiver and exporter.
+package togglapi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jason0x43/go-toggl"
+)
+
+const (
+	// DefaultEndpoint is the endpoint of the Toggl Track API.
+	DefaultEndpoint = "https://api.track.toggl.com"
+
+	// apiPath is the path of the Toggl Track API v9.
+	apiPath = "/api/v9"
+	// reportsPath is the path of the Toggl Track Reports API v3.
+	reportsPath = "/reports/api/v3"
+
+	// NextRowHeader carries the row number of the next page of a
+	// report; it is missing on the last page.
+	NextRowHeader = "X-Next-Row-Number"
+
+	reportPageSize = 1000
+)
+
+// ClientConfig holds the settings to access the Toggl Track API.
+type ClientConfig struct {
+	APIToken    string `mapstructure:"api_token"`
+	APIEndpoint string `mapstructure:"api_endpoint"`
+}
+
+// Client is a Toggl Track API client covering the workspace-level
+// and report endpoints go-toggl does not support.
+type Client struct {
+	httpClient *http.Client
+	endpoint   string
+	apiToken   string
+}
+
+// NewClient creates a new Toggl Track API client.
+func NewClient(cfg ClientConfig) *Client {
+	return &Client{
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+		endpoint:   strings.TrimRight(cfg.APIEndpoint, "/"),
+		apiToken:   cfg.APIToken,
+	}
+}
+
+// StatusError is returned when the API answers with an unexpected
+// status code.
+type StatusError struct {
+	StatusCode int
+	Path       string
+	Body       string
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("unexpected status code %d from %s: %s", e.StatusCode, e.Path, e.Body)
+}
+
+// WorkspaceUser is a member of a workspace.
+type WorkspaceUser struct {
+	ID       int    `json:"id"`
+	Email    string `json:"email"`
+	FullName string `json:"fullname"`
+}
+
+// ProjectBudget is a project along with its estimate and fixed fee.
+type ProjectBudget struct {
+	ID               int      `json:"id"`
+	WorkspaceID      int      `json:"workspace_id"`
+	Name             string   `json:"name"`
+	EstimatedHours   *int64   `json:"estimated_hours"`
+	EstimatedSeconds *int64   `json:"estimated_seconds"`
+	ActualHours      *int64   `json:"actual_hours"`
+	ActualSeconds    *int64   `json:"actual_seconds"`
+	FixedFee         *float64 `json:"fixed_fee"`
+	Rate             *float64 `json:"rate"`
+	Currency         *string  `json:"currency"`
+}
+
+// NewTimeEntry is the body of the request creating a time entry.
+type NewTimeEntry struct {
+	CreatedWith string    `json:"created_with"`
+	WorkspaceID int       `json:"workspace_id"`
+	ProjectID   *int      `json:"project_id,omitempty"`
+	TaskID      *int      `json:"task_id,omitempty"`
+	Description string    `json:"description"`
+	Start       time.Time `json:"start"`
+	Stop        time.Time `json:"stop"`
+	Duration    int64     `json:"duration"`
+	Billable    bool      `json:"billable"`
+	Tags        []string  `json:"tags,omitempty"`
+}
+
+// ReportRow groups the time entries of the detailed report sharing the
+// same user, project, task, description, tags and billable flag.
+type ReportRow struct {
+	UserID      int           `json:"user_id"`
+	Username    string        `json:"username"`
+	ProjectID   *int          `json:"project_id"`
+	TaskID      *int          `json:"task_id"`
+	Billable    bool          `json:"billable"`
+	Description string        `json:"description"`
+	TagIDs      []int         `json:"tag_ids"`
+	TimeEntries []ReportEntry `json:"time_entries"`
+}
+
+// ReportEntry is a time entry of a detailed report row.
+type ReportEntry struct {
+	ID      int        `json:"id"`
+	Seconds int64      `json:"seconds"`
+	Start   time.Time  `json:"start"`
+	Stop    *time.Time `json:"stop"`
+}
+
+// ReportRequest is the body of the detailed report search.
+type ReportRequest struct {
+	StartDate      string `json:"start_date"`
+	EndDate        string `json:"end_date"`
+	PageSize       int    `json:"page_size"`
+	FirstRowNumber int    `json:"first_row_number,omitempty"`
+}
+
+// tasksResponse is the paginated response of the workspace tasks.
+type tasksResponse struct {
+	Data []toggl.Task `json:"data"`
+}
+
+// GetWorkspace returns the workspace with the given ID.
+func (c *Client) GetWorkspace(ctx context.Context, wid int) (toggl.Workspace, error) {
+	var workspace toggl.Workspace
+	err := c.getJSON(ctx, fmt.Sprintf("%s/workspaces/%d", apiPath, wid), nil, &workspace)
+	return workspace, err
+}
+
+// ListWorkspaces returns the workspaces of the token owner.
+func (c *Client) ListWorkspaces(ctx context.Context) ([]toggl.Workspace, error) {
+	var workspaces []toggl.Workspace
+	err := c.getJSON(ctx, apiPath+"/me/workspaces", nil, &workspaces)
+	return workspaces, err
+}
+
+// ListWorkspaceUsers returns all the members of the workspace.
+func (c *Client) ListWorkspaceUsers(ctx context.Context, wid int) ([]WorkspaceUser, error) {
+	var users []WorkspaceUser
+	err := c.getJSON(ctx, fmt.Sprintf("%s/workspaces/%d/users", apiPath, wid), nil, &users)
+	return users, err
+}
+
+// ListProjects returns all the projects of the workspace, including the
+// archived ones, so that old entries still resolve their project name.
+func (c *Client) ListProjects(ctx context.Context, wid int) ([]toggl.Project, error) {
+	var projects []toggl.Project
+	err := c.getJSON(ctx, fmt.Sprintf("%s/workspaces/%d/projects", apiPath, wid), url.Values{"active": {"both"}}, &projects)
+	return projects, err
+}
+
+// ListProjectBudgets returns the active projects of the workspace along
+// with their budget fields.
+func (c *Client) ListProjectBudgets(ctx context.Context, wid int) ([]ProjectBudget, error) {
+	var projects []ProjectBudget
+	err := c.getJSON(ctx, fmt.Sprintf("%s/workspaces/%d/projects", apiPath, wid), url.Values{"active": {"true"}}, &projects)
+	return projects, err
+}
+
+// ListTasks returns all the tasks of the workspace.
+func (c *Client) ListTasks(ctx context.Context, wid int) ([]toggl.Task, error) {
+	var tasks tasksResponse
+	err := c.getJSON(ctx, fmt.Sprintf("%s/workspaces/%d/tasks", apiPath, wid), url.Values{"active": {"both"}}, &tasks)
+	return tasks.Data, err
+}
+
+// ListTags returns all the tags of the workspace.
+func (c *Client) ListTags(ctx context.Context, wid int) ([]toggl.Tag, error) {
+	var tags []toggl.Tag
+	err := c.getJSON(ctx, fmt.Sprintf("%s/workspaces/%d/tags", apiPath, wid), nil, &tags)
+	return tags, err
+}
+
+// ListTimeEntries returns the time entries of the token owner started
+// between start (inclusive) and end (exclusive).
+func (c *Client) ListTimeEntries(ctx context.Context, start, end time.Time) ([]toggl.TimeEntry, error) {
+	var entries []toggl.TimeEntry
+	params := url.Values{
+		"start_date": {start.Format(time.RFC3339)},
+		"end_date":   {end.Format(time.RFC3339)},
+	}
+	err := c.getJSON(ctx, apiPath+"/me/time_entries", params, &entries)
+	return entries, err
+}
+
+// CreateTimeEntry creates a time entry in the workspace.
+func (c *Client) CreateTimeEntry(ctx context.Context, entry NewTimeEntry) (toggl.TimeEntry, error) {
+	var created toggl.TimeEntry
+	_, err := c.postJSON(ctx, fmt.Sprintf("%s/workspaces/%d/time_entries", apiPath, entry.WorkspaceID), entry, &created)
+	return created, err
+}
+
+// SearchTimeEntries returns the detailed report of the time entries of
+// all the workspace users between the start and end dates (inclusive),
+// walking all the report pages.
+func (c *Client) SearchTimeEntries(ctx context.Context, wid int, start, end time.Time) ([]ReportRow, error) {
+	path := fmt.Sprintf("%s/workspace/%d/search/time_entries", reportsPath, wid)
+	request := ReportRequest{
+		StartDate: start.Format(time.DateOnly),
+		EndDate:   end.Format(time.DateOnly),
+		PageSize:  reportPageSize,
+	}
+
+	var rows []ReportRow
+	for {
+		var page []ReportRow
+		header, err := c.postJSON(ctx, path, request, &page)
+		if err != nil {
+			return nil, err
+		}
+		rows = append(rows, page...)
+
+		next := header.Get(NextRowHeader)
+		if next == "" {
+			return rows, nil
+		}
+		request.FirstRowNumber, err = strconv.Atoi(next)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s header %q: %w", NextRowHeader, next, err)
+		}
+	}
+}
+
+func (c *Client) getJSON(ctx context.Context, path string, params url.Values, v any) error {
+	u := c.endpoint + path
+	if len(params) > 0 {
+		u += "?" + params.Encode()
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return err
+	}
+	_, err = c.do(req, v)
+	return err
+}
+
+func (c *Client) postJSON(ctx context.Context, path string, body any, v any) (http.Header, error) {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.endpoint+path, bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	return c.do(req, v)
+}
+
+func (c *Client) do(req *http.Request, v any) (http.Header, error) {
+	req.SetBasicAuth(c.apiToken, "api_token")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return nil, &StatusError{
+			StatusCode: resp.StatusCode,
+			Path:       req.URL.Path,
+			Body:       strings.TrimSpace(string(body)),
+		}
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", req.URL.Path, err)
+	}
+
+	return resp.Header, nil
+}
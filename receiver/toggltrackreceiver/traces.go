@@ -0,0 +1,193 @@
+package toggltrackreceiver
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"strconv"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/receiver"
+	"go.opentelemetry.io/collector/scraper/scrapererror"
+	"go.uber.org/zap"
+)
+
+// serviceName is the service the time entry spans belong to, so trace
+// UIs list them under a recognizable name.
+const serviceName = "toggltrack"
+
+// traces turns the time entry records into spans. Spans of the same day
+// (in the given time zone) or project share a trace ID derived from the
+// group, so the entries of later scrapes join the same trace.
+func (m *timeEntryMarshaler) traces(records []timeEntryRecord, location *time.Location) ptrace.Traces {
+	t := ptrace.NewTraces()
+
+	resourceSpans := t.ResourceSpans().AppendEmpty()
+	resourceSpans.Resource().Attributes().PutStr("service.name", serviceName)
+
+	scopeSpans := resourceSpans.ScopeSpans().AppendEmpty()
+	scopeSpans.Scope().SetName(scopeName)
+	scopeSpans.Scope().SetVersion(scopeVersion)
+
+	for _, r := range records {
+		span := scopeSpans.Spans().AppendEmpty()
+		span.SetTraceID(traceID(m.traceKey(r, location)))
+		span.SetSpanID(spanID(r.id))
+		span.SetName(spanName(r))
+		span.SetKind(ptrace.SpanKindInternal)
+		span.SetStartTimestamp(pcommon.NewTimestampFromTime(r.start))
+		span.SetEndTimestamp(pcommon.NewTimestampFromTime(r.stop))
+
+		m.naming.writeAttributes(span.Attributes(), r)
+	}
+
+	return t
+}
+
+// traceKey returns the key of the group the record belongs to.
+func (m *timeEntryMarshaler) traceKey(r timeEntryRecord, location *time.Location) string {
+	if m.groupBy == GroupByProject {
+		return "project/" + r.workspaceID + "/" + r.projectID
+	}
+
+	key := "day/" + r.start.In(location).Format(time.DateOnly)
+	if r.user != nil {
+		// Every member has their own workday.
+		key += "/" + strconv.Itoa(r.user.ID)
+	}
+	return key
+}
+
+// spanName returns the entry description, falling back to the project
+// name for entries without one.
+func spanName(r timeEntryRecord) string {
+	switch {
+	case r.description != "":
+		return r.description
+	case r.projectName != "":
+		return r.projectName
+	default:
+		return eventName
+	}
+}
+
+// traceID derives a stable trace ID from the group key.
+func traceID(key string) pcommon.TraceID {
+	var id pcommon.TraceID
+	sum := sha256.Sum256([]byte(serviceName + "/" + key))
+	copy(id[:], sum[:])
+	return id
+}
+
+// spanID derives a stable span ID from the record ID, so a re-sent entry
+// keeps its span ID.
+func spanID(id string) pcommon.SpanID {
+	var sid pcommon.SpanID
+	sum := sha256.Sum256([]byte(serviceName + "/" + id))
+	copy(sid[:], sum[:])
+	return sid
+}
+
+// tracesReceiver periodically scrapes the time entries and sends them to
+// the consumer as spans. The scraper controller only supports logs and
+// metrics, so the receiver runs its own collection loop.
+type tracesReceiver struct {
+	cfg      *Config
+	settings component.TelemetrySettings
+	consumer consumer.Traces
+	scraper  *togglTrackScraper
+
+	cancel     context.CancelFunc
+	shutdownWG sync.WaitGroup
+}
+
+// newTracesReceiver creates a new Toggl traces receiver.
+func newTracesReceiver(cfg *Config, settings receiver.Settings, consumer consumer.Traces) *tracesReceiver {
+	return &tracesReceiver{
+		cfg:      cfg,
+		settings: settings.TelemetrySettings,
+		consumer: consumer,
+		scraper:  newScraper(cfg, settings),
+	}
+}
+
+// Start starts the collection loop.
+func (r *tracesReceiver) Start(ctx context.Context, host component.Host) error {
+	if err := r.scraper.start(ctx, host); err != nil {
+		return err
+	}
+
+	// The loop outlives the Start context.
+	loopCtx, cancel := context.WithCancel(context.Background())
+	r.cancel = cancel
+
+	r.shutdownWG.Add(1)
+	go func() {
+		defer r.shutdownWG.Done()
+		r.run(loopCtx)
+	}()
+
+	return nil
+}
+
+// Shutdown stops the collection loop.
+func (r *tracesReceiver) Shutdown(ctx context.Context) error {
+	if r.cancel != nil {
+		r.cancel()
+	}
+	r.shutdownWG.Wait()
+	return r.scraper.shutdown(ctx)
+}
+
+func (r *tracesReceiver) run(ctx context.Context) {
+	select {
+	case <-ctx.Done():
+		return
+	case <-time.After(r.cfg.InitialDelay):
+	}
+
+	ticker := time.NewTicker(r.cfg.CollectionInterval)
+	defer ticker.Stop()
+
+	for {
+		r.collect(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// collect scrapes the time entries and forwards the spans to the
+// consumer. On partial scrape errors, the spans we got are forwarded
+// anyway.
+func (r *tracesReceiver) collect(ctx context.Context) {
+	if r.cfg.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, r.cfg.Timeout)
+		defer cancel()
+	}
+
+	traces, err := r.scraper.scrapeTraces(ctx)
+	if err != nil {
+		var partialErr scrapererror.PartialScrapeError
+		if !errors.As(err, &partialErr) {
+			return
+		}
+	}
+
+	if traces.SpanCount() == 0 {
+		return
+	}
+
+	if err := r.consumer.ConsumeTraces(ctx, traces); err != nil {
+		r.settings.Logger.Error("Error consuming toggltrack spans", zap.Error(err))
+	}
+}
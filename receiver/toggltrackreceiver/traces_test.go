@@ -0,0 +1,135 @@
+package toggltrackreceiver
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jason0x43/go-toggl"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+
+	"github.com/zmoog/collector/receiver/toggltrackreceiver/togglapi"
+)
+
+func TestTimeEntryMarshaler_UnmarshalTraces(t *testing.T) {
+	account := toggl.Account{
+		TimeEntries: []toggl.TimeEntry{
+			{
+				ID:       3,
+				Wid:      100,
+				Pid:      intPtr(200),
+				Start:    timePtr(time.Date(2024, 1, 16, 9, 0, 0, 0, time.UTC)),
+				Stop:     timePtr(time.Date(2024, 1, 16, 10, 0, 0, 0, time.UTC)),
+				Duration: 3600,
+			},
+			{
+				ID:          2,
+				Wid:         100,
+				Description: "Code review",
+				Start:       timePtr(time.Date(2024, 1, 15, 14, 0, 0, 0, time.UTC)),
+				Stop:        timePtr(time.Date(2024, 1, 15, 15, 0, 0, 0, time.UTC)),
+				Duration:    3600,
+			},
+			{
+				ID:          1,
+				Wid:         100,
+				Pid:         intPtr(200),
+				Description: "Testing feature X",
+				Start:       timePtr(time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC)),
+				Stop:        timePtr(time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC)),
+				Duration:    5400,
+				Billable:    true,
+			},
+		},
+		Workspaces: []toggl.Workspace{{ID: 100, Name: "My Workspace"}},
+		Projects:   []toggl.Project{{ID: 200, Name: "Project Alpha"}},
+	}
+
+	tests := []struct {
+		groupBy  string
+		validate func(t *testing.T, spans ptrace.SpanSlice)
+	}{
+		{
+			groupBy: GroupByDay,
+			validate: func(t *testing.T, spans ptrace.SpanSlice) {
+				assert.Equal(t, spans.At(0).TraceID(), spans.At(1).TraceID(), "entries of the same day share the trace")
+				assert.NotEqual(t, spans.At(0).TraceID(), spans.At(2).TraceID(), "entries of different days have different traces")
+			},
+		},
+		{
+			groupBy: GroupByProject,
+			validate: func(t *testing.T, spans ptrace.SpanSlice) {
+				assert.Equal(t, spans.At(0).TraceID(), spans.At(2).TraceID(), "entries of the same project share the trace")
+				assert.NotEqual(t, spans.At(0).TraceID(), spans.At(1).TraceID(), "entries of different projects have different traces")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.groupBy, func(t *testing.T) {
+			m := newTimeEntryMarshaler(Mappings{}).withTraces(TracesConfig{GroupBy: tt.groupBy})
+
+			traces, err := m.UnmarshalTraces(account)
+			require.NoError(t, err)
+			require.Equal(t, 3, traces.SpanCount())
+
+			resourceSpans := traces.ResourceSpans().At(0)
+			serviceName, ok := resourceSpans.Resource().Attributes().Get("service.name")
+			require.True(t, ok)
+			assert.Equal(t, "toggltrack", serviceName.Str())
+
+			scopeSpans := resourceSpans.ScopeSpans().At(0)
+			assert.Equal(t, scopeName, scopeSpans.Scope().Name())
+
+			spans := scopeSpans.Spans()
+			span := spans.At(0)
+			assert.Equal(t, "Testing feature X", span.Name())
+			assert.Equal(t, ptrace.SpanKindInternal, span.Kind())
+			assert.Equal(t, time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC), span.StartTimestamp().AsTime())
+			assert.Equal(t, time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC), span.EndTimestamp().AsTime())
+			projectName, ok := span.Attributes().Get("project.name")
+			require.True(t, ok)
+			assert.Equal(t, "Project Alpha", projectName.Str())
+
+			assert.Equal(t, "Project Alpha", spans.At(2).Name(), "entries without description are named after the project")
+			assert.NotEqual(t, spans.At(0).SpanID(), spans.At(1).SpanID())
+
+			tt.validate(t, spans)
+		})
+	}
+}
+
+func TestTimeEntryMarshaler_UnmarshalTeamTraces(t *testing.T) {
+	m := newTimeEntryMarshaler(Mappings{})
+
+	alice := togglapi.WorkspaceUser{ID: 1, FullName: "Alice"}
+	bob := togglapi.WorkspaceUser{ID: 2, FullName: "Bob"}
+	entry := toggl.TimeEntry{
+		Wid:      100,
+		Start:    timePtr(time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC)),
+		Stop:     timePtr(time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)),
+		Duration: 3600,
+	}
+	aliceEntry, bobEntry := entry, entry
+	aliceEntry.ID, bobEntry.ID = 1, 2
+
+	traces, err := m.UnmarshalTeamTraces(toggl.Account{}, []teamTimeEntry{
+		{TimeEntry: aliceEntry, User: alice},
+		{TimeEntry: bobEntry, User: bob},
+	})
+	require.NoError(t, err)
+	require.Equal(t, 2, traces.SpanCount())
+
+	spans := traces.ResourceSpans().At(0).ScopeSpans().At(0).Spans()
+	assert.NotEqual(t, spans.At(0).TraceID(), spans.At(1).TraceID(), "every member has their own workday trace")
+}
+
+func TestConfig_ValidateTraces(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.APIToken = "token"
+	assert.NoError(t, cfg.Validate())
+
+	cfg.Traces.GroupBy = "week"
+	assert.EqualError(t, cfg.Validate(), `traces.group_by must be one of "day" or "project"`)
+}
@@ -0,0 +1,274 @@
+package toggltrackreceiver
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jason0x43/go-toggl"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componentstatus"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/receiver"
+	"go.uber.org/zap"
+)
+
+const (
+	// signatureHeader carries the HMAC-SHA256 of the request body,
+	// signed with the subscription secret and formatted as
+	// "sha256=<hex digest>".
+	signatureHeader = "X-Webhook-Signature-256"
+	signaturePrefix = "sha256="
+
+	// pingPayload is the payload of the event Toggl sends to validate
+	// a new (or re-enabled) subscription.
+	pingPayload = `"ping"`
+)
+
+// webhookEvent is the envelope of the events Toggl posts to the
+// webhook subscriptions.
+type webhookEvent struct {
+	EventID        int64           `json:"event_id"`
+	CreatedAt      time.Time       `json:"created_at"`
+	Metadata       webhookMetadata `json:"metadata"`
+	Payload        json.RawMessage `json:"payload"`
+	SubscriptionID int64           `json:"subscription_id"`
+	ValidationCode string          `json:"validation_code"`
+}
+
+// webhookMetadata describes the entity and the action of the event.
+type webhookMetadata struct {
+	Action string `json:"action"`
+	Model  string `json:"model"`
+}
+
+// isPing returns true if the event is the subscription validation ping.
+func (e webhookEvent) isPing() bool {
+	return string(e.Payload) == pingPayload && e.ValidationCode != ""
+}
+
+// webhookReceiver receives the time entries pushed by a Toggl webhook
+// subscription and turns them into logs.
+type webhookReceiver struct {
+	cfg       *Config
+	settings  component.TelemetrySettings
+	consumer  consumer.Logs
+	marshaler *timeEntryMarshaler
+	// scraper fetches the account used to look up the workspace, project
+	// and task names. It is nil when no api_token is configured.
+	scraper *accountScraper
+
+	mu            sync.Mutex
+	account       toggl.Account
+	lastRefreshed time.Time
+
+	server     *http.Server
+	shutdownWG sync.WaitGroup
+}
+
+// newWebhookReceiver creates a new Toggl webhook receiver.
+func newWebhookReceiver(cfg *Config, settings receiver.Settings, consumer consumer.Logs) *webhookReceiver {
+	r := &webhookReceiver{
+		cfg:       cfg,
+		settings:  settings.TelemetrySettings,
+		consumer:  consumer,
+		marshaler: newTimeEntryMarshaler(cfg.Mappings).withSplitByDay(cfg.SplitByDay).withAttributeNaming(cfg.AttributeNaming),
+	}
+	if cfg.APIToken != "" {
+		r.scraper = NewScraper(cfg.APIToken, settings.Logger)
+	}
+	return r
+}
+
+// Start starts the HTTP server receiving the webhook events.
+func (r *webhookReceiver) Start(ctx context.Context, host component.Host) error {
+	r.settings.Logger.Info("Starting toggltrack webhook receiver",
+		zap.String("endpoint", r.cfg.Webhook.Endpoint),
+		zap.String("path", r.cfg.Webhook.Path))
+
+	if r.scraper != nil {
+		r.refreshAccount()
+	}
+
+	listener, err := r.cfg.Webhook.ToListener(ctx)
+	if err != nil {
+		return err
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(r.cfg.Webhook.Path, r.handleEvent)
+
+	r.server, err = r.cfg.Webhook.ToServer(ctx, host.GetExtensions(), r.settings, mux)
+	if err != nil {
+		return errors.Join(err, listener.Close())
+	}
+
+	r.shutdownWG.Add(1)
+	go func() {
+		defer r.shutdownWG.Done()
+		if errHTTP := r.server.Serve(listener); errHTTP != nil && !errors.Is(errHTTP, http.ErrServerClosed) {
+			componentstatus.ReportStatus(host, componentstatus.NewFatalErrorEvent(errHTTP))
+		}
+	}()
+
+	return nil
+}
+
+// Shutdown stops the HTTP server.
+func (r *webhookReceiver) Shutdown(ctx context.Context) error {
+	if r.server == nil {
+		return nil
+	}
+	err := r.server.Shutdown(ctx)
+	r.shutdownWG.Wait()
+	return err
+}
+
+// handleEvent validates the signature of a webhook event, answers the
+// validation ping and forwards the time entries to the consumer.
+func (r *webhookReceiver) handleEvent(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		http.Error(w, "failed to read body", http.StatusBadRequest)
+		return
+	}
+
+	if !validSignature(body, req.Header.Get(signatureHeader), string(r.cfg.Webhook.Secret)) {
+		r.settings.Logger.Warn("Rejecting toggltrack webhook event with invalid signature")
+		http.Error(w, "invalid signature", http.StatusUnauthorized)
+		return
+	}
+
+	var event webhookEvent
+	if err := json.Unmarshal(body, &event); err != nil {
+		http.Error(w, "invalid event", http.StatusBadRequest)
+		return
+	}
+
+	if event.isPing() {
+		r.settings.Logger.Info("Validating toggltrack webhook subscription",
+			zap.Int64("subscription_id", event.SubscriptionID))
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(struct {
+			ValidationCode string `json:"validation_code"`
+		}{ValidationCode: event.ValidationCode})
+		return
+	}
+
+	if event.Metadata.Model != "time_entry" || event.Metadata.Action == "deleted" {
+		r.settings.Logger.Debug("Skipping toggltrack webhook event",
+			zap.Int64("event_id", event.EventID),
+			zap.String("model", event.Metadata.Model),
+			zap.String("action", event.Metadata.Action))
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	var entry toggl.TimeEntry
+	if err := json.Unmarshal(event.Payload, &entry); err != nil {
+		http.Error(w, "invalid time entry", http.StatusBadRequest)
+		return
+	}
+
+	logs, err := r.marshaler.UnmarshalTimeEntry(entry, r.lookupAccount(entry))
+	if err != nil {
+		r.settings.Logger.Error("Error marshaling toggltrack webhook entry", zap.Error(err))
+		http.Error(w, "failed to marshal time entry", http.StatusInternalServerError)
+		return
+	}
+
+	if logs.LogRecordCount() > 0 {
+		if err := r.consumer.ConsumeLogs(req.Context(), logs); err != nil {
+			r.settings.Logger.Error("Error consuming toggltrack webhook entry", zap.Error(err))
+			http.Error(w, "failed to consume time entry", http.StatusServiceUnavailable)
+			return
+		}
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// lookupAccount returns the account used to look up the names of the
+// entry's workspace, project and task. The account is refreshed when the
+// entry refers to an entity we don't know yet, at most once per
+// MinCollectionInterval.
+func (r *webhookReceiver) lookupAccount(e toggl.TimeEntry) toggl.Account {
+	if r.scraper != nil && !knowsEntities(r.currentAccount(), e) {
+		r.mu.Lock()
+		stale := time.Since(r.lastRefreshed) >= MinCollectionInterval
+		r.mu.Unlock()
+		if stale {
+			r.refreshAccount()
+		}
+	}
+	return r.currentAccount()
+}
+
+func (r *webhookReceiver) currentAccount() toggl.Account {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.account
+}
+
+// refreshAccount fetches the account from the Toggl API. Failures are
+// not fatal: the names fall back to the "Unknown (id)" placeholder.
+func (r *webhookReceiver) refreshAccount() {
+	account, err := r.scraper.Scrape()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.lastRefreshed = time.Now()
+	if err != nil {
+		r.settings.Logger.Warn("Error fetching toggltrack account for name lookups", zap.Error(err))
+		return
+	}
+	r.account = account
+}
+
+// knowsEntities returns true if the account contains the workspace,
+// project and task the entry refers to.
+func knowsEntities(account toggl.Account, e toggl.TimeEntry) bool {
+	if _, ok := findName(account.Workspaces, e.Wid); !ok {
+		return false
+	}
+	if e.Pid != nil {
+		if _, ok := findName(account.Projects, *e.Pid); !ok {
+			return false
+		}
+	}
+	if e.Tid != nil {
+		if _, ok := findName(account.Tasks, *e.Tid); !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// validSignature checks the HMAC-SHA256 signature of the body.
+func validSignature(body []byte, signature, secret string) bool {
+	digest, found := strings.CutPrefix(signature, signaturePrefix)
+	if !found {
+		return false
+	}
+	expected, err := hex.DecodeString(digest)
+	if err != nil {
+		return false
+	}
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hmac.Equal(mac.Sum(nil), expected)
+}
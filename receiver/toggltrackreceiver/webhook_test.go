@@ -0,0 +1,226 @@
+package toggltrackreceiver
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/jason0x43/go-toggl"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+)
+
+const testSecret = "my-subscription-secret"
+
+func TestWebhookReceiver_HandleEvent(t *testing.T) {
+	timeEntryEvent, err := os.ReadFile("testdata/webhook_time_entry.json")
+	require.NoError(t, err)
+	pingEvent, err := os.ReadFile("testdata/webhook_ping.json")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name               string
+		method             string
+		body               []byte
+		signature          string
+		expectedStatus     int
+		expectedLogRecords int
+		validateResponse   func(t *testing.T, body []byte)
+	}{
+		{
+			name:               "time entry event with valid signature",
+			method:             http.MethodPost,
+			body:               timeEntryEvent,
+			signature:          sign(timeEntryEvent, testSecret),
+			expectedStatus:     http.StatusOK,
+			expectedLogRecords: 1,
+		},
+		{
+			name:           "time entry event with invalid signature",
+			method:         http.MethodPost,
+			body:           timeEntryEvent,
+			signature:      sign(timeEntryEvent, "wrong-secret"),
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "time entry event without signature",
+			method:         http.MethodPost,
+			body:           timeEntryEvent,
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "validation ping",
+			method:         http.MethodPost,
+			body:           pingEvent,
+			signature:      sign(pingEvent, testSecret),
+			expectedStatus: http.StatusOK,
+			validateResponse: func(t *testing.T, body []byte) {
+				var response struct {
+					ValidationCode string `json:"validation_code"`
+				}
+				require.NoError(t, json.Unmarshal(body, &response))
+				assert.Equal(t, "0a1b2c3d-4e5f-6789-abcd-ef0123456789", response.ValidationCode)
+			},
+		},
+		{
+			name:           "malformed event",
+			method:         http.MethodPost,
+			body:           []byte("{not json"),
+			signature:      sign([]byte("{not json"), testSecret),
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "wrong method",
+			method:         http.MethodGet,
+			expectedStatus: http.StatusMethodNotAllowed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sink := new(consumertest.LogsSink)
+			r := newTestWebhookReceiver(sink)
+
+			req := httptest.NewRequest(tt.method, DefaultWebhookPath, bytes.NewReader(tt.body))
+			if tt.signature != "" {
+				req.Header.Set(signatureHeader, tt.signature)
+			}
+			rec := httptest.NewRecorder()
+
+			r.handleEvent(rec, req)
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			assert.Equal(t, tt.expectedLogRecords, sink.LogRecordCount())
+			if tt.validateResponse != nil {
+				tt.validateResponse(t, rec.Body.Bytes())
+			}
+		})
+	}
+}
+
+func TestWebhookReceiver_TimeEntryLogRecord(t *testing.T) {
+	body, err := os.ReadFile("testdata/webhook_time_entry.json")
+	require.NoError(t, err)
+
+	sink := new(consumertest.LogsSink)
+	r := newTestWebhookReceiver(sink)
+	r.account = toggl.Account{
+		Workspaces: []toggl.Workspace{{ID: 100, Name: "My Workspace"}},
+		Projects:   []toggl.Project{{ID: 200, Name: "Project Alpha"}},
+	}
+
+	req := httptest.NewRequest(http.MethodPost, DefaultWebhookPath, bytes.NewReader(body))
+	req.Header.Set(signatureHeader, sign(body, testSecret))
+	rec := httptest.NewRecorder()
+
+	r.handleEvent(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Len(t, sink.AllLogs(), 1)
+
+	logs := sink.AllLogs()[0]
+	scopeLogs := logs.ResourceLogs().At(0).ScopeLogs().At(0)
+	assert.Equal(t, scopeName, scopeLogs.Scope().Name())
+	require.Equal(t, 1, scopeLogs.LogRecords().Len())
+
+	attrs := scopeLogs.LogRecords().At(0).Attributes()
+	expected := map[string]string{
+		"id":             "12345",
+		"workspace.id":   "100",
+		"workspace.name": "My Workspace",
+		"project.id":     "200",
+		"project.name":   "Project Alpha",
+		"description":    "Testing feature X",
+		"start":          "2024-01-15T09:00:00Z",
+		"end":            "2024-01-15T10:30:00Z",
+	}
+	for key, value := range expected {
+		v, ok := attrs.Get(key)
+		require.True(t, ok, "attribute %s should exist", key)
+		assert.Equal(t, value, v.Str(), "attribute %s", key)
+	}
+
+	_, ok := attrs.Get("task.id")
+	assert.False(t, ok, "Should not have task.id when task_id is null")
+
+	duration, ok := attrs.Get("duration")
+	require.True(t, ok)
+	assert.Equal(t, int64(5400), duration.Int())
+
+	billable, ok := attrs.Get("billable")
+	require.True(t, ok)
+	assert.True(t, billable.Bool())
+
+	tags, ok := attrs.Get("tags")
+	require.True(t, ok)
+	assert.Equal(t, 2, tags.Slice().Len())
+}
+
+func TestWebhookReceiver_SkipsRunningAndDeletedEntries(t *testing.T) {
+	tests := []struct {
+		name   string
+		action string
+		entry  string
+	}{
+		{
+			name:   "running entry",
+			action: "created",
+			entry:  `{"id": 1, "workspace_id": 100, "start": "2024-01-15T09:00:00Z", "duration": -1705309200}`,
+		},
+		{
+			name:   "deleted entry",
+			action: "deleted",
+			entry:  `{"id": 2, "workspace_id": 100, "start": "2024-01-15T09:00:00Z", "stop": "2024-01-15T10:00:00Z", "duration": 3600}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body := []byte(`{"event_id": 1, "metadata": {"action": "` + tt.action + `", "model": "time_entry"}, "payload": ` + tt.entry + `}`)
+
+			sink := new(consumertest.LogsSink)
+			r := newTestWebhookReceiver(sink)
+
+			req := httptest.NewRequest(http.MethodPost, DefaultWebhookPath, bytes.NewReader(body))
+			req.Header.Set(signatureHeader, sign(body, testSecret))
+			rec := httptest.NewRecorder()
+
+			r.handleEvent(rec, req)
+
+			assert.Equal(t, http.StatusOK, rec.Code)
+			assert.Equal(t, 0, sink.LogRecordCount())
+		})
+	}
+}
+
+func TestConfig_ValidateWebhook(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Mode = ModeWebhook
+	assert.EqualError(t, cfg.Validate(), "webhook.secret is required")
+
+	cfg.Webhook.Secret = testSecret
+	assert.NoError(t, cfg.Validate(), "api_token is optional in webhook mode")
+
+	cfg.Webhook.Path = "toggltrack"
+	assert.EqualError(t, cfg.Validate(), "webhook.path must start with /")
+}
+
+func newTestWebhookReceiver(sink *consumertest.LogsSink) *webhookReceiver {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Mode = ModeWebhook
+	cfg.Webhook.Secret = testSecret
+	return newWebhookReceiver(cfg, receivertest.NewNopSettings(typ), sink)
+}
+
+func sign(body []byte, secret string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,121 @@
+package wavinsentioreceiver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/extension/xextension/storage"
+)
+
+// activityStorageKey is the storage key of the rooms activity.
+const activityStorageKey = "room_activity"
+
+// roomActivity holds the heating and cooling activity of a room since
+// StartTime.
+type roomActivity struct {
+	StartTime       time.Time `json:"start_time"`
+	LastSeen        time.Time `json:"last_seen"`
+	LastState       string    `json:"last_state"`
+	HeatingDuration float64   `json:"heating_duration"` // seconds
+	CoolingDuration float64   `json:"cooling_duration"` // seconds
+	HeatingCycles   int64     `json:"heating_cycles"`
+	CoolingCycles   int64     `json:"cooling_cycles"`
+}
+
+// activityTracker tracks the temperature state transitions of the rooms
+// across scrapes.
+type activityTracker struct {
+	rooms map[string]roomActivity
+	// maxGap is the longest interval between two observations we
+	// attribute to the previous state. Longer gaps, like the collector
+	// being down, are not counted.
+	maxGap time.Duration
+}
+
+func newActivityTracker(maxGap time.Duration) *activityTracker {
+	return &activityTracker{
+		rooms:  make(map[string]roomActivity),
+		maxGap: maxGap,
+	}
+}
+
+// observe records the temperature state of the room at now and returns
+// the updated activity. The time since the previous observation is
+// attributed to the previous state, and a change of state to heating or
+// cooling starts a new cycle.
+func (t *activityTracker) observe(key, state string, now time.Time) roomActivity {
+	a, found := t.rooms[key]
+	if !found {
+		a = roomActivity{StartTime: now}
+	} else if gap := now.Sub(a.LastSeen); gap > 0 && gap <= t.maxGap {
+		switch a.LastState {
+		case temperatureStateHeating:
+			a.HeatingDuration += gap.Seconds()
+		case temperatureStateCooling:
+			a.CoolingDuration += gap.Seconds()
+		}
+	}
+
+	// A cycle starts on a transition from a known state: a room found
+	// heating, on the first scrape or after a restart without storage,
+	// may have started long before.
+	if a.LastState != "" && state != a.LastState {
+		switch state {
+		case temperatureStateHeating:
+			a.HeatingCycles++
+		case temperatureStateCooling:
+			a.CoolingCycles++
+		}
+	}
+
+	a.LastSeen = now
+	a.LastState = state
+	t.rooms[key] = a
+
+	return a
+}
+
+// load restores the rooms activity from the storage.
+func (t *activityTracker) load(ctx context.Context, client storage.Client) error {
+	data, err := client.Get(ctx, activityStorageKey)
+	if err != nil {
+		return err
+	}
+	if data == nil {
+		return nil
+	}
+	return json.Unmarshal(data, &t.rooms)
+}
+
+// save persists the rooms activity to the storage.
+func (t *activityTracker) save(ctx context.Context, client storage.Client) error {
+	data, err := json.Marshal(t.rooms)
+	if err != nil {
+		return err
+	}
+	return client.Set(ctx, activityStorageKey, data)
+}
+
+// getStorageClient returns the client name of the storage extension
+// storageID, or a client that does not persist anything when storageID
+// is nil.
+func getStorageClient(ctx context.Context, host component.Host, storageID *component.ID, id component.ID, name string) (storage.Client, error) {
+	if storageID == nil {
+		return storage.NewNopClient(), nil
+	}
+
+	ext, found := host.GetExtensions()[*storageID]
+	if !found {
+		return nil, fmt.Errorf("storage extension %s not found", storageID)
+	}
+
+	storageExt, ok := ext.(storage.Extension)
+	if !ok {
+		return nil, fmt.Errorf("extension %s is not a storage extension", storageID)
+	}
+
+	return storageExt.GetClient(ctx, component.KindReceiver, id, name)
+}
@@ -0,0 +1,159 @@
+package wavinsentioreceiver
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/extension/xextension/storage"
+)
+
+// memoryStorage is a storage extension keeping the data in memory.
+type memoryStorage struct {
+	component.StartFunc
+	component.ShutdownFunc
+	data map[string][]byte
+}
+
+func (m *memoryStorage) GetClient(context.Context, component.Kind, component.ID, string) (storage.Client, error) {
+	return m, nil
+}
+
+func (m *memoryStorage) Get(_ context.Context, key string) ([]byte, error) {
+	return m.data[key], nil
+}
+
+func (m *memoryStorage) Set(_ context.Context, key string, value []byte) error {
+	m.data[key] = value
+	return nil
+}
+
+func (m *memoryStorage) Delete(_ context.Context, key string) error {
+	delete(m.data, key)
+	return nil
+}
+
+func (m *memoryStorage) Batch(context.Context, ...*storage.Operation) error {
+	return nil
+}
+
+func (m *memoryStorage) Close(context.Context) error {
+	return nil
+}
+
+// storageHost is a host with a memory storage extension.
+type storageHost struct {
+	component.Host
+	extensions map[component.ID]component.Component
+}
+
+func (h storageHost) GetExtensions() map[component.ID]component.Component {
+	return h.extensions
+}
+
+func TestActivityTracker_Observe(t *testing.T) {
+	start := time.Date(2025, 1, 12, 10, 0, 0, 0, time.UTC)
+	tracker := newActivityTracker(2 * time.Minute)
+
+	a := tracker.observe("room", "TEMPERATURE_STATE_IDLE", start)
+	assert.Equal(t, roomActivity{StartTime: start, LastSeen: start, LastState: "TEMPERATURE_STATE_IDLE"}, a)
+
+	// The room starts heating: the idle minute is not counted.
+	a = tracker.observe("room", temperatureStateHeating, start.Add(time.Minute))
+	assert.Equal(t, 0.0, a.HeatingDuration)
+	assert.Equal(t, int64(1), a.HeatingCycles)
+
+	// Still heating: the minute is counted, but it's the same cycle.
+	a = tracker.observe("room", temperatureStateHeating, start.Add(2*time.Minute))
+	assert.Equal(t, 60.0, a.HeatingDuration)
+	assert.Equal(t, int64(1), a.HeatingCycles)
+
+	// The room stops heating.
+	a = tracker.observe("room", "TEMPERATURE_STATE_IDLE", start.Add(3*time.Minute))
+	assert.Equal(t, 120.0, a.HeatingDuration)
+
+	// A gap longer than maxGap is not attributed to the previous state.
+	tracker.observe("room", temperatureStateCooling, start.Add(4*time.Minute))
+	a = tracker.observe("room", temperatureStateCooling, start.Add(time.Hour))
+	assert.Equal(t, 0.0, a.CoolingDuration)
+	assert.Equal(t, int64(1), a.CoolingCycles)
+
+	a = tracker.observe("room", "TEMPERATURE_STATE_IDLE", start.Add(time.Hour+time.Minute))
+	assert.Equal(t, 60.0, a.CoolingDuration)
+	assert.Equal(t, 120.0, a.HeatingDuration)
+	assert.Equal(t, start, a.StartTime)
+}
+
+func TestActivityTracker_FirstObservation(t *testing.T) {
+	start := time.Date(2025, 1, 12, 10, 0, 0, 0, time.UTC)
+	tracker := newActivityTracker(2 * time.Minute)
+
+	// The room is already heating: we don't know when it started.
+	a := tracker.observe("room", temperatureStateHeating, start)
+	assert.Equal(t, int64(0), a.HeatingCycles)
+
+	a = tracker.observe("room", temperatureStateHeating, start.Add(time.Minute))
+	assert.Equal(t, 60.0, a.HeatingDuration)
+	assert.Equal(t, int64(0), a.HeatingCycles)
+
+	tracker.observe("room", "TEMPERATURE_STATE_IDLE", start.Add(2*time.Minute))
+	a = tracker.observe("room", temperatureStateHeating, start.Add(3*time.Minute))
+	assert.Equal(t, int64(1), a.HeatingCycles)
+}
+
+func TestActivityTracker_Persistence(t *testing.T) {
+	ctx := context.Background()
+	start := time.Date(2025, 1, 12, 10, 0, 0, 0, time.UTC)
+	storageID := component.MustNewID("memory")
+	ext := &memoryStorage{data: make(map[string][]byte)}
+	host := storageHost{
+		Host:       componenttest.NewNopHost(),
+		extensions: map[component.ID]component.Component{storageID: ext},
+	}
+
+	client, err := getStorageClient(ctx, host, &storageID, component.MustNewID("wavinsentio"), "")
+	require.NoError(t, err)
+
+	tracker := newActivityTracker(2 * time.Minute)
+	tracker.observe("room", "TEMPERATURE_STATE_IDLE", start)
+	tracker.observe("room", temperatureStateHeating, start.Add(time.Minute))
+	require.NoError(t, tracker.save(ctx, client))
+
+	// A new tracker, like after a restart, keeps counting.
+	restored := newActivityTracker(2 * time.Minute)
+	require.NoError(t, restored.load(ctx, client))
+	a := restored.observe("room", temperatureStateHeating, start.Add(2*time.Minute))
+	assert.Equal(t, 60.0, a.HeatingDuration)
+	assert.Equal(t, int64(1), a.HeatingCycles)
+	assert.Equal(t, start, a.StartTime)
+}
+
+func TestGetStorageClient(t *testing.T) {
+	ctx := context.Background()
+	id := component.MustNewID("wavinsentio")
+
+	client, err := getStorageClient(ctx, componenttest.NewNopHost(), nil, id, "")
+	require.NoError(t, err)
+	assert.Equal(t, storage.NewNopClient(), client)
+
+	missing := component.MustNewID("file_storage")
+	_, err = getStorageClient(ctx, componenttest.NewNopHost(), &missing, id, "")
+	assert.EqualError(t, err, "storage extension file_storage not found")
+
+	notStorage := component.MustNewID("nop")
+	host := storageHost{
+		Host: componenttest.NewNopHost(),
+		extensions: map[component.ID]component.Component{
+			notStorage: struct {
+				component.StartFunc
+				component.ShutdownFunc
+			}{},
+		},
+	}
+	_, err = getStorageClient(ctx, host, &notStorage, id, "")
+	assert.EqualError(t, err, "extension nop is not a storage extension")
+}
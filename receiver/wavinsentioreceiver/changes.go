@@ -0,0 +1,168 @@
+package wavinsentioreceiver
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+
+	ws2 "github.com/zmoog/ws/v2/ws"
+
+	"github.com/zmoog/collector/receiver/wavinsentioreceiver/internal/metadata"
+)
+
+// changeEventName identifies the setting change events.
+const changeEventName = "wavinsentio.change"
+
+// settingValues holds the values of the settings of a device or a room
+// we report changes for, by field name.
+type settingValues map[string]string
+
+// change is a setting of a device or a room that changed between two
+// scrapes.
+type change struct {
+	room     *ws2.Room // nil for the device settings
+	field    string
+	oldValue string
+	newValue string
+}
+
+// changeDetector detects the changes in the device and room settings by
+// diffing successive ListDevices results.
+type changeDetector struct {
+	// previous holds the settings seen in the last scrape, by device
+	// serial number and by device serial number and room ID.
+	previous map[string]settingValues
+}
+
+func newChangeDetector() *changeDetector {
+	return &changeDetector{}
+}
+
+// detect returns the changes of each device since the previous call. The
+// first call only records the settings.
+func (d *changeDetector) detect(devices []ws2.Device) map[string][]change {
+	current := make(map[string]settingValues)
+	changes := make(map[string][]change)
+
+	for _, device := range devices {
+		key := device.SerialNumber
+		current[key] = deviceSettings(device)
+		changes[key] = append(changes[key], diffSettings(d.previous, key, current[key])...)
+
+		for _, room := range device.LastConfig.Sentio.Rooms {
+			roomKey := roomKey(device, room)
+			current[roomKey] = roomSettings(room)
+			for _, c := range diffSettings(d.previous, roomKey, current[roomKey]) {
+				c.room = &room
+				changes[key] = append(changes[key], c)
+			}
+		}
+	}
+
+	d.previous = current
+	return changes
+}
+
+// diffSettings returns the settings that changed since the previous
+// scrape. New devices and rooms have no changes.
+func diffSettings(previous map[string]settingValues, key string, current settingValues) []change {
+	old, found := previous[key]
+	if !found {
+		return nil
+	}
+
+	fields := make([]string, 0, len(current))
+	for field := range current {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	var changes []change
+	for _, field := range fields {
+		if old[field] != current[field] {
+			changes = append(changes, change{field: field, oldValue: old[field], newValue: current[field]})
+		}
+	}
+	return changes
+}
+
+func deviceSettings(device ws2.Device) settingValues {
+	sentio := device.LastConfig.Sentio
+	return settingValues{
+		"hc_mode":            device.HcMode,
+		"standby_mode":       sentio.StandbyMode,
+		"vacation_mode":      sentio.VacationSettings.VacationMode,
+		"quiet_mode":         sentio.QuietSettings.Mode,
+		"firmware.installed": device.FirmwareInstalled,
+		"firmware.available": device.FirmwareAvailable,
+	}
+}
+
+func roomSettings(room ws2.Room) settingValues {
+	return settingValues{
+		"title":                room.Title,
+		"temperature.setpoint": strconv.FormatFloat(room.SetpointTemperature, 'f', -1, 64),
+		"vacation_mode":        room.VacationMode,
+		"lock_mode":            room.LockMode,
+	}
+}
+
+// changesMarshaler turns the setting changes into log records.
+type changesMarshaler struct {
+	buildInfo component.BuildInfo
+	// rooms holds the additional attributes of the rooms.
+	rooms RoomsConfig
+}
+
+// UnmarshalLogs returns a log record for each change, grouped by device.
+func (m *changesMarshaler) UnmarshalLogs(devices []ws2.Device, changes map[string][]change) plog.Logs {
+	logs := plog.NewLogs()
+	timestamp := pcommon.NewTimestampFromTime(time.Now())
+
+	for _, device := range devices {
+		deviceChanges := changes[device.SerialNumber]
+		if len(deviceChanges) == 0 {
+			continue
+		}
+
+		resourceLogs := logs.ResourceLogs().AppendEmpty()
+		resource := resourceLogs.Resource().Attributes()
+		resource.PutStr("wavinsentio.device.name", device.Name)
+		resource.PutStr("wavinsentio.device.serial_number", device.SerialNumber)
+		resource.PutStr("wavinsentio.device.type", device.Type)
+
+		scopeLogs := resourceLogs.ScopeLogs().AppendEmpty()
+		scopeLogs.Scope().SetName(metadata.ScopeName)
+		scopeLogs.Scope().SetVersion(m.buildInfo.Version)
+
+		for _, c := range deviceChanges {
+			lr := scopeLogs.LogRecords().AppendEmpty()
+			lr.SetTimestamp(timestamp)
+			lr.SetObservedTimestamp(timestamp)
+			lr.SetEventName(changeEventName)
+			lr.SetSeverityNumber(plog.SeverityNumberInfo)
+			lr.SetSeverityText("INFO")
+
+			attributes := lr.Attributes()
+			subject := device.Name
+			if c.room != nil {
+				attributes.PutStr("wavinsentio.room.id", c.room.ID)
+				attributes.PutStr("wavinsentio.room.title", c.room.Title)
+				m.rooms.putAttributes(attributes, *c.room)
+				subject = c.room.Title
+			}
+			attributes.PutStr("wavinsentio.change.field", c.field)
+			attributes.PutStr("wavinsentio.change.old_value", c.oldValue)
+			attributes.PutStr("wavinsentio.change.new_value", c.newValue)
+
+			lr.Body().SetStr(fmt.Sprintf("%s %s changed from %q to %q", subject, c.field, c.oldValue, c.newValue))
+		}
+	}
+
+	return logs
+}
@@ -0,0 +1,89 @@
+package wavinsentioreceiver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+func TestChangeDetector_Detect(t *testing.T) {
+	detector := newChangeDetector()
+
+	devices := loadDevices(t)
+	assert.Empty(t, detector.detect(devices)["my-serial-number"], "the first scrape only records the settings")
+	assert.Empty(t, detector.detect(devices)["my-serial-number"], "nothing changed")
+
+	devices = loadDevices(t)
+	devices[0].FirmwareInstalled = "1.2.0"
+	devices[0].LastConfig.Sentio.Rooms[0].SetpointTemperature = 22
+	devices[0].LastConfig.Sentio.Rooms[1].LockMode = "LOCK_MODE_UNLOCKED"
+	// The temperature state is reported by the metrics only.
+	devices[0].LastConfig.Sentio.Rooms[1].TemperatureState = temperatureStateHeating
+
+	changes := detector.detect(devices)["my-serial-number"]
+	require.Len(t, changes, 3)
+
+	assert.Nil(t, changes[0].room)
+	assert.Equal(t, "firmware.installed", changes[0].field)
+	assert.Equal(t, "1.1.0", changes[0].oldValue)
+	assert.Equal(t, "1.2.0", changes[0].newValue)
+
+	assert.Equal(t, "room-1", changes[1].room.ID)
+	assert.Equal(t, "temperature.setpoint", changes[1].field)
+	assert.Equal(t, "21.5", changes[1].oldValue)
+	assert.Equal(t, "22", changes[1].newValue)
+
+	assert.Equal(t, "room-2", changes[2].room.ID)
+	assert.Equal(t, "lock_mode", changes[2].field)
+	assert.Equal(t, "LOCK_MODE_LOCKED", changes[2].oldValue)
+	assert.Equal(t, "LOCK_MODE_UNLOCKED", changes[2].newValue)
+}
+
+func TestChangesMarshaler_UnmarshalLogs(t *testing.T) {
+	detector := newChangeDetector()
+	marshaler := &changesMarshaler{buildInfo: component.BuildInfo{Version: "v1.0.0"}}
+
+	devices := loadDevices(t)
+	logs := marshaler.UnmarshalLogs(devices, detector.detect(devices))
+	assert.Equal(t, 0, logs.LogRecordCount())
+
+	devices[0].LastConfig.Sentio.Rooms[0].VacationMode = "VACATION_MODE_ON"
+	logs = marshaler.UnmarshalLogs(devices, detector.detect(devices))
+	require.Equal(t, 1, logs.LogRecordCount())
+
+	resourceLogs := logs.ResourceLogs().At(0)
+	serialNumber, _ := resourceLogs.Resource().Attributes().Get("wavinsentio.device.serial_number")
+	assert.Equal(t, "my-serial-number", serialNumber.Str())
+	assert.Equal(t, "v1.0.0", resourceLogs.ScopeLogs().At(0).Scope().Version())
+
+	lr := resourceLogs.ScopeLogs().At(0).LogRecords().At(0)
+	assert.Equal(t, changeEventName, lr.EventName())
+	assert.Equal(t, plog.SeverityNumberInfo, lr.SeverityNumber())
+	assert.Equal(t, map[string]any{
+		"wavinsentio.room.id":          "room-1",
+		"wavinsentio.room.title":       "Living Room",
+		"wavinsentio.change.field":     "vacation_mode",
+		"wavinsentio.change.old_value": "VACATION_MODE_OFF",
+		"wavinsentio.change.new_value": "VACATION_MODE_ON",
+	}, lr.Attributes().AsRaw())
+	assert.Equal(t, `Living Room vacation_mode changed from "VACATION_MODE_OFF" to "VACATION_MODE_ON"`, lr.Body().Str())
+}
+
+func TestChangesMarshaler_RoomAttributes(t *testing.T) {
+	detector := newChangeDetector()
+	marshaler := &changesMarshaler{rooms: RoomsConfig{Attributes: map[string]map[string]any{"room-2": {"zone": "night"}}}}
+
+	devices := loadDevices(t)
+	detector.detect(devices)
+
+	devices[0].LastConfig.Sentio.Rooms[1].SetpointTemperature = 19
+	logs := marshaler.UnmarshalLogs(devices, detector.detect(devices))
+	require.Equal(t, 1, logs.LogRecordCount())
+
+	zone, ok := logs.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Attributes().Get("zone")
+	require.True(t, ok)
+	assert.Equal(t, "night", zone.Str())
+}
@@ -0,0 +1,83 @@
+package wavinsentioreceiver
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync"
+
+	"go.opentelemetry.io/collector/extension/xextension/storage"
+)
+
+// tokenCacheName is the name of the storage client of the token cache.
+const tokenCacheName = "token"
+
+// sharedClients holds the API client of each receiver configuration. The
+// collector creates a receiver for each signal of a configuration, so the
+// metrics and logs scrapers share the login to the identity provider and
+// the refresh token, or the connection to the CCU in the local mode.
+var sharedClients = &clientRegistry{clients: make(map[*Config]*sharedClient)}
+
+// sharedClient is an API client along with the storage client of its
+// token cache, if any.
+type sharedClient struct {
+	client        devicesClient
+	storageClient storage.Client
+	refs          int
+}
+
+// close closes the connection to the CCU and the storage client of the
+// token cache.
+func (c *sharedClient) close(ctx context.Context) error {
+	var errs error
+	if closer, ok := c.client.(io.Closer); ok {
+		errs = closer.Close()
+	}
+	if c.storageClient != nil {
+		errs = errors.Join(errs, c.storageClient.Close(ctx))
+	}
+	return errs
+}
+
+// clientRegistry counts the scrapers using each client, and closes the
+// client when the last one shuts down.
+type clientRegistry struct {
+	mu      sync.Mutex
+	clients map[*Config]*sharedClient
+}
+
+// acquire returns the client of cfg, creating it with create on the
+// first call.
+func (r *clientRegistry) acquire(cfg *Config, create func() (*sharedClient, error)) (*sharedClient, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	c, found := r.clients[cfg]
+	if !found {
+		var err error
+		if c, err = create(); err != nil {
+			return nil, err
+		}
+		r.clients[cfg] = c
+	}
+	c.refs++
+	return c, nil
+}
+
+// release drops a reference to the client of cfg, closing it with the
+// last one.
+func (r *clientRegistry) release(ctx context.Context, cfg *Config) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	c, found := r.clients[cfg]
+	if !found {
+		return nil
+	}
+	c.refs--
+	if c.refs > 0 {
+		return nil
+	}
+	delete(r.clients, cfg)
+	return c.close(ctx)
+}
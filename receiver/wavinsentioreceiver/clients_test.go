@@ -0,0 +1,47 @@
+package wavinsentioreceiver
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientRegistry(t *testing.T) {
+	ctx := context.Background()
+	cfg := createDefaultConfig().(*Config)
+	storage := &memoryStorage{data: make(map[string][]byte)}
+
+	created := 0
+	create := func() (*sharedClient, error) {
+		created++
+		return &sharedClient{storageClient: storage}, nil
+	}
+
+	metrics, err := sharedClients.acquire(cfg, create)
+	require.NoError(t, err)
+	logs, err := sharedClients.acquire(cfg, create)
+	require.NoError(t, err)
+	assert.Same(t, metrics, logs)
+	assert.Equal(t, 1, created, "the signals log in once")
+
+	otherCfg := createDefaultConfig().(*Config)
+	other, err := sharedClients.acquire(otherCfg, create)
+	require.NoError(t, err)
+	assert.NotSame(t, metrics, other)
+	require.NoError(t, sharedClients.release(ctx, otherCfg))
+
+	require.NoError(t, sharedClients.release(ctx, cfg))
+	assert.Contains(t, sharedClients.clients, cfg, "still used by the logs")
+	require.NoError(t, sharedClients.release(ctx, cfg))
+	assert.NotContains(t, sharedClients.clients, cfg)
+	require.NoError(t, sharedClients.release(ctx, cfg), "already released")
+
+	_, err = sharedClients.acquire(cfg, func() (*sharedClient, error) {
+		return nil, errors.New("storage extension missing not found")
+	})
+	require.EqualError(t, err, "storage extension missing not found")
+	assert.NotContains(t, sharedClients.clients, cfg)
+}
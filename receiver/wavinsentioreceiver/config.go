@@ -4,7 +4,12 @@ import (
 	"fmt"
 	"time"
 
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configopaque"
 	"go.opentelemetry.io/collector/scraper/scraperhelper"
+
+	"github.com/zmoog/collector/receiver/wavinsentioreceiver/internal/metadata"
+	"github.com/zmoog/collector/receiver/wavinsentioreceiver/wavinapi"
 )
 
 const (
@@ -13,16 +18,42 @@ const (
 
 type Config struct {
 	scraperhelper.ControllerConfig `mapstructure:",squash"`
-	Endpoint                       string `mapstructure:"endpoint"`
-	Username                       string `mapstructure:"username"`
-	Password                       string `mapstructure:"password"`
-	WebApiKey                      string `mapstructure:"web_api_key"`
+	wavinapi.ClientConfig          `mapstructure:",squash"`
+	// StorageID is the storage extension used to persist the rooms
+	// heating and cooling activity across restarts.
+	StorageID  *component.ID    `mapstructure:"storage"`
+	TokenCache TokenCacheConfig `mapstructure:"token_cache"`
+	// Devices selects the devices to scrape by serial number.
+	Devices FilterConfig `mapstructure:"devices"`
+	// Rooms selects the rooms to scrape by ID or title, and adds
+	// attributes to them.
+	Rooms RoomsConfig `mapstructure:"rooms"`
+	// Modbus reads the CCU on the local network instead of the cloud API
+	// when its endpoint is set.
+	Modbus                        ModbusConfig `mapstructure:"modbus"`
+	metadata.MetricsBuilderConfig `mapstructure:",squash"`
+}
+
+// TokenCacheConfig configures the cache keeping the refresh token across
+// restarts.
+type TokenCacheConfig struct {
+	// StorageID is the storage extension keeping the refresh token. The
+	// cache is disabled when nil.
+	StorageID *component.ID `mapstructure:"storage"`
+	// EncryptionKey encrypts the refresh token in the storage.
+	EncryptionKey configopaque.String `mapstructure:"encryption_key"`
 }
 
 func (cfg Config) Validate() error {
 	if cfg.CollectionInterval < MinCollectionInterval {
 		return fmt.Errorf("interval must be at least %s", MinCollectionInterval)
 	}
+	if cfg.Modbus.Endpoint != "" {
+		if err := cfg.Modbus.validate(); err != nil {
+			return err
+		}
+		return cfg.Rooms.validate()
+	}
 	if cfg.Endpoint == "" {
 		return fmt.Errorf("endpoint is required")
 	}
@@ -35,5 +66,8 @@ func (cfg Config) Validate() error {
 	if cfg.WebApiKey == "" {
 		return fmt.Errorf("web_api_key is required")
 	}
-	return nil
+	if cfg.TokenCache.StorageID != nil && cfg.TokenCache.EncryptionKey == "" {
+		return fmt.Errorf("token_cache.encryption_key is required")
+	}
+	return cfg.Rooms.validate()
 }
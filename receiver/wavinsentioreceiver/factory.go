@@ -27,6 +27,11 @@ func createDefaultConfig() component.Config {
 	cfg.CollectionInterval = DefaultCollectionInterval
 	return &Config{
 		ControllerConfig: cfg,
+		Modbus: ModbusConfig{
+			UnitID:  1,
+			Timeout: 10 * time.Second,
+		},
+		MetricsBuilderConfig: metadata.DefaultMetricsBuilderConfig(),
 	}
 }
 
@@ -40,7 +45,8 @@ func createMetricsReceiver(ctx context.Context, settings receiver.Settings, base
 
 	metrics, err := scraper.NewMetrics(
 		wavinsentioScraper.scrape,
-		scraper.WithStart(wavinsentioScraper.start),
+		scraper.WithStart(wavinsentioScraper.startMetrics),
+		scraper.WithShutdown(wavinsentioScraper.shutdown),
 	)
 	if err != nil {
 		return nil, err
@@ -54,10 +60,46 @@ func createMetricsReceiver(ctx context.Context, settings receiver.Settings, base
 	)
 }
 
+// createLogsScraperFactory creates a scraper.Factory for the setting
+// change logs.
+func createLogsScraperFactory(cfg *Config, settings receiver.Settings) scraper.Factory {
+	return scraper.NewFactory(
+		metadata.Type,
+		func() component.Config { return cfg },
+		scraper.WithLogs(func(ctx context.Context, scraperSettings scraper.Settings, scraperCfg component.Config) (scraper.Logs, error) {
+			cfg, ok := scraperCfg.(*Config)
+			if !ok {
+				return nil, fmt.Errorf("invalid config type")
+			}
+			wavinsentioScraper := newScraper(cfg, settings)
+			return scraper.NewLogs(
+				wavinsentioScraper.scrapeLogs,
+				scraper.WithStart(wavinsentioScraper.startLogs),
+				scraper.WithShutdown(wavinsentioScraper.shutdown),
+			)
+		}, component.StabilityLevelAlpha),
+	)
+}
+
+func createLogsReceiver(ctx context.Context, settings receiver.Settings, baseCfg component.Config, consumer consumer.Logs) (receiver.Logs, error) {
+	cfg, ok := baseCfg.(*Config)
+	if !ok {
+		return nil, fmt.Errorf("invalid config type")
+	}
+
+	return scraperhelper.NewLogsController(
+		&cfg.ControllerConfig,
+		settings,
+		consumer,
+		scraperhelper.AddFactoryWithConfig(createLogsScraperFactory(cfg, settings), cfg),
+	)
+}
+
 func NewFactory() receiver.Factory {
 	return receiver.NewFactory(
 		typeStr,
 		createDefaultConfig,
 		receiver.WithMetrics(createMetricsReceiver, component.StabilityLevelAlpha),
+		receiver.WithLogs(createLogsReceiver, component.StabilityLevelAlpha),
 	)
 }
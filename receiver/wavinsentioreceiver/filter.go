@@ -0,0 +1,115 @@
+package wavinsentioreceiver
+
+import (
+	"fmt"
+	"maps"
+	"strings"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	ws2 "github.com/zmoog/ws/v2/ws"
+)
+
+// FilterConfig selects the devices or rooms to scrape. The values are
+// compared case-insensitively.
+type FilterConfig struct {
+	// Include lists the items to scrape. All the items are scraped when
+	// empty.
+	Include []string `mapstructure:"include"`
+	// Exclude lists the items to skip, even when included.
+	Exclude []string `mapstructure:"exclude"`
+}
+
+// RoomsConfig selects the rooms to scrape, by ID or title, and adds
+// attributes to them.
+type RoomsConfig struct {
+	FilterConfig `mapstructure:",squash"`
+	// Attributes holds the additional resource attributes of the rooms,
+	// by room ID or title, like the floor or the area.
+	Attributes map[string]map[string]any `mapstructure:"attributes"`
+}
+
+// matches returns true if one of the values identifying an item is
+// included and none is excluded.
+func (f FilterConfig) matches(values ...string) bool {
+	if len(f.Include) > 0 && !containsAny(f.Include, values) {
+		return false
+	}
+	return !containsAny(f.Exclude, values)
+}
+
+func containsAny(list []string, values []string) bool {
+	for _, item := range list {
+		for _, value := range values {
+			if strings.EqualFold(item, value) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// validate checks the attributes can be set on a resource and don't
+// override the receiver ones.
+func (r RoomsConfig) validate() error {
+	for room, attributes := range r.Attributes {
+		for name, value := range attributes {
+			if strings.HasPrefix(name, "wavinsentio.") {
+				return fmt.Errorf("rooms.attributes: %s: attribute %q uses the reserved wavinsentio. prefix", room, name)
+			}
+			if err := pcommon.NewValueEmpty().FromRaw(value); err != nil {
+				return fmt.Errorf("rooms.attributes: %s: attribute %q: %w", room, name, err)
+			}
+		}
+	}
+	return nil
+}
+
+// attributes returns the additional attributes of the room. The keys
+// are compared case-insensitively, like the filters, and the attributes
+// configured by room ID win over the ones configured by title.
+func (r RoomsConfig) attributes(room ws2.Room) map[string]any {
+	merged := make(map[string]any)
+	for key, attributes := range r.Attributes {
+		if !strings.EqualFold(key, room.ID) && strings.EqualFold(key, room.Title) {
+			maps.Copy(merged, attributes)
+		}
+	}
+	for key, attributes := range r.Attributes {
+		if strings.EqualFold(key, room.ID) {
+			maps.Copy(merged, attributes)
+		}
+	}
+	return merged
+}
+
+// putAttributes sets the room attributes on m.
+func (r RoomsConfig) putAttributes(m pcommon.Map, room ws2.Room) {
+	for name, value := range r.attributes(room) {
+		// The values are checked when validating the config.
+		_ = m.PutEmpty(name).FromRaw(value)
+	}
+}
+
+// filterDevices returns the devices and rooms selected by the config.
+func filterDevices(devices []ws2.Device, devicesFilter FilterConfig, rooms RoomsConfig) []ws2.Device {
+	filtered := make([]ws2.Device, 0, len(devices))
+
+	for _, device := range devices {
+		if !devicesFilter.matches(device.SerialNumber) {
+			continue
+		}
+
+		selected := make([]ws2.Room, 0, len(device.LastConfig.Sentio.Rooms))
+		for _, room := range device.LastConfig.Sentio.Rooms {
+			if rooms.matches(room.ID, room.Title) {
+				selected = append(selected, room)
+			}
+		}
+		device.LastConfig.Sentio.Rooms = selected
+
+		filtered = append(filtered, device)
+	}
+
+	return filtered
+}
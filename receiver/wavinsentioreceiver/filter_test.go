@@ -0,0 +1,83 @@
+package wavinsentioreceiver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	ws2 "github.com/zmoog/ws/v2/ws"
+)
+
+// roomIDs returns the IDs of the rooms of the devices.
+func roomIDs(devices []ws2.Device) []string {
+	var ids []string
+	for _, device := range devices {
+		for _, room := range device.LastConfig.Sentio.Rooms {
+			ids = append(ids, room.ID)
+		}
+	}
+	return ids
+}
+
+func TestFilterDevices(t *testing.T) {
+	tests := []struct {
+		name     string
+		devices  FilterConfig
+		rooms    FilterConfig
+		expected []string
+	}{
+		{name: "no filters", expected: []string{"room-1", "room-2"}},
+		{name: "included device", devices: FilterConfig{Include: []string{"MY-SERIAL-NUMBER"}}, expected: []string{"room-1", "room-2"}},
+		{name: "excluded device", devices: FilterConfig{Exclude: []string{"my-serial-number"}}},
+		{name: "included room by ID", rooms: FilterConfig{Include: []string{"room-2"}}, expected: []string{"room-2"}},
+		{name: "included room by ID in upper case", rooms: FilterConfig{Include: []string{"ROOM-2"}}, expected: []string{"room-2"}},
+		{name: "included room by title", rooms: FilterConfig{Include: []string{"living room"}}, expected: []string{"room-1"}},
+		{name: "excluded room", rooms: FilterConfig{Exclude: []string{"Bedroom"}}, expected: []string{"room-1"}},
+		{
+			name:     "excluded wins over included",
+			rooms:    FilterConfig{Include: []string{"room-1", "room-2"}, Exclude: []string{"room-1"}},
+			expected: []string{"room-2"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			devices := loadDevices(t)
+			filtered := filterDevices(devices, tt.devices, RoomsConfig{FilterConfig: tt.rooms})
+			assert.Equal(t, tt.expected, roomIDs(filtered))
+			assert.Len(t, devices[0].LastConfig.Sentio.Rooms, 2, "the devices are not modified")
+		})
+	}
+}
+
+func TestRoomsConfig_Attributes(t *testing.T) {
+	rooms := RoomsConfig{
+		Attributes: map[string]map[string]any{
+			"living room": {"floor": "ground", "area": 32.5},
+			"room-1":      {"floor": "first"},
+		},
+	}
+
+	devices := loadDevices(t)
+	assert.Equal(t, map[string]any{"floor": "first", "area": 32.5}, rooms.attributes(devices[0].LastConfig.Sentio.Rooms[0]))
+	assert.Empty(t, rooms.attributes(devices[0].LastConfig.Sentio.Rooms[1]))
+
+	// The room IDs are compared case-insensitively too.
+	rooms.Attributes = map[string]map[string]any{
+		"Living Room": {"floor": "ground"},
+		"ROOM-1":      {"floor": "first"},
+	}
+	assert.Equal(t, map[string]any{"floor": "first"}, rooms.attributes(devices[0].LastConfig.Sentio.Rooms[0]))
+}
+
+func TestRoomsConfig_Validate(t *testing.T) {
+	rooms := RoomsConfig{Attributes: map[string]map[string]any{"room-1": {"floor": "ground", "area": 32}}}
+	require.NoError(t, rooms.validate())
+
+	rooms.Attributes["room-1"]["wavinsentio.room.title"] = "Kitchen"
+	assert.EqualError(t, rooms.validate(), `rooms.attributes: room-1: attribute "wavinsentio.room.title" uses the reserved wavinsentio. prefix`)
+
+	rooms.Attributes["room-1"] = map[string]any{"sensor": struct{}{}}
+	assert.ErrorContains(t, rooms.validate(), `rooms.attributes: room-1: attribute "sensor"`)
+}
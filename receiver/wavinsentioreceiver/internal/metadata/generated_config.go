@@ -0,0 +1,200 @@
+// Code generated by mdatagen. DO NOT EDIT.
+
+package metadata
+
+import (
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/filter"
+)
+
+// MetricConfig provides common config for a particular metric.
+type MetricConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+
+	enabledSetByUser bool
+}
+
+func (ms *MetricConfig) Unmarshal(parser *confmap.Conf) error {
+	if parser == nil {
+		return nil
+	}
+	err := parser.Unmarshal(ms)
+	if err != nil {
+		return err
+	}
+	ms.enabledSetByUser = parser.IsSet("enabled")
+	return nil
+}
+
+// MetricsConfig provides config for wavinsentio metrics.
+type MetricsConfig struct {
+	WavinsentioDeviceFirmware             MetricConfig `mapstructure:"wavinsentio.device.firmware"`
+	WavinsentioDeviceHcMode               MetricConfig `mapstructure:"wavinsentio.device.hc_mode"`
+	WavinsentioDeviceLastHeartbeat        MetricConfig `mapstructure:"wavinsentio.device.last_heartbeat"`
+	WavinsentioDeviceOutdoorTemperature   MetricConfig `mapstructure:"wavinsentio.device.outdoor_temperature"`
+	WavinsentioDeviceQuietMode            MetricConfig `mapstructure:"wavinsentio.device.quiet_mode"`
+	WavinsentioDeviceStandbyMode          MetricConfig `mapstructure:"wavinsentio.device.standby_mode"`
+	WavinsentioDeviceVacationMode         MetricConfig `mapstructure:"wavinsentio.device.vacation_mode"`
+	WavinsentioRoomCoolingCycles          MetricConfig `mapstructure:"wavinsentio.room.cooling.cycles"`
+	WavinsentioRoomCoolingDemand          MetricConfig `mapstructure:"wavinsentio.room.cooling.demand"`
+	WavinsentioRoomCoolingDuration        MetricConfig `mapstructure:"wavinsentio.room.cooling.duration"`
+	WavinsentioRoomDehumidifierState      MetricConfig `mapstructure:"wavinsentio.room.dehumidifier.state"`
+	WavinsentioRoomHeatingCycles          MetricConfig `mapstructure:"wavinsentio.room.heating.cycles"`
+	WavinsentioRoomHeatingDemand          MetricConfig `mapstructure:"wavinsentio.room.heating.demand"`
+	WavinsentioRoomHeatingDuration        MetricConfig `mapstructure:"wavinsentio.room.heating.duration"`
+	WavinsentioRoomHumidity               MetricConfig `mapstructure:"wavinsentio.room.humidity"`
+	WavinsentioRoomHumiditySetpoint       MetricConfig `mapstructure:"wavinsentio.room.humidity.setpoint"`
+	WavinsentioRoomInfo                   MetricConfig `mapstructure:"wavinsentio.room.info"`
+	WavinsentioRoomLockMode               MetricConfig `mapstructure:"wavinsentio.room.lock_mode"`
+	WavinsentioRoomTemperatureAir         MetricConfig `mapstructure:"wavinsentio.room.temperature.air"`
+	WavinsentioRoomTemperatureDewPoint    MetricConfig `mapstructure:"wavinsentio.room.temperature.dew_point"`
+	WavinsentioRoomTemperatureSetpoint    MetricConfig `mapstructure:"wavinsentio.room.temperature.setpoint"`
+	WavinsentioRoomTemperatureSetpointMax MetricConfig `mapstructure:"wavinsentio.room.temperature.setpoint.max"`
+	WavinsentioRoomTemperatureSetpointMin MetricConfig `mapstructure:"wavinsentio.room.temperature.setpoint.min"`
+	WavinsentioRoomTemperatureState       MetricConfig `mapstructure:"wavinsentio.room.temperature_state"`
+	WavinsentioRoomVacationMode           MetricConfig `mapstructure:"wavinsentio.room.vacation_mode"`
+}
+
+func DefaultMetricsConfig() MetricsConfig {
+	return MetricsConfig{
+		WavinsentioDeviceFirmware: MetricConfig{
+			Enabled: true,
+		},
+		WavinsentioDeviceHcMode: MetricConfig{
+			Enabled: true,
+		},
+		WavinsentioDeviceLastHeartbeat: MetricConfig{
+			Enabled: true,
+		},
+		WavinsentioDeviceOutdoorTemperature: MetricConfig{
+			Enabled: true,
+		},
+		WavinsentioDeviceQuietMode: MetricConfig{
+			Enabled: true,
+		},
+		WavinsentioDeviceStandbyMode: MetricConfig{
+			Enabled: true,
+		},
+		WavinsentioDeviceVacationMode: MetricConfig{
+			Enabled: true,
+		},
+		WavinsentioRoomCoolingCycles: MetricConfig{
+			Enabled: true,
+		},
+		WavinsentioRoomCoolingDemand: MetricConfig{
+			Enabled: true,
+		},
+		WavinsentioRoomCoolingDuration: MetricConfig{
+			Enabled: true,
+		},
+		WavinsentioRoomDehumidifierState: MetricConfig{
+			Enabled: true,
+		},
+		WavinsentioRoomHeatingCycles: MetricConfig{
+			Enabled: true,
+		},
+		WavinsentioRoomHeatingDemand: MetricConfig{
+			Enabled: true,
+		},
+		WavinsentioRoomHeatingDuration: MetricConfig{
+			Enabled: true,
+		},
+		WavinsentioRoomHumidity: MetricConfig{
+			Enabled: true,
+		},
+		WavinsentioRoomHumiditySetpoint: MetricConfig{
+			Enabled: true,
+		},
+		WavinsentioRoomInfo: MetricConfig{
+			Enabled: true,
+		},
+		WavinsentioRoomLockMode: MetricConfig{
+			Enabled: true,
+		},
+		WavinsentioRoomTemperatureAir: MetricConfig{
+			Enabled: true,
+		},
+		WavinsentioRoomTemperatureDewPoint: MetricConfig{
+			Enabled: true,
+		},
+		WavinsentioRoomTemperatureSetpoint: MetricConfig{
+			Enabled: true,
+		},
+		WavinsentioRoomTemperatureSetpointMax: MetricConfig{
+			Enabled: true,
+		},
+		WavinsentioRoomTemperatureSetpointMin: MetricConfig{
+			Enabled: true,
+		},
+		WavinsentioRoomTemperatureState: MetricConfig{
+			Enabled: true,
+		},
+		WavinsentioRoomVacationMode: MetricConfig{
+			Enabled: true,
+		},
+	}
+}
+
+// ResourceAttributeConfig provides common config for a particular resource attribute.
+type ResourceAttributeConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+	// Experimental: MetricsInclude defines a list of filters for attribute values.
+	// If the list is not empty, only metrics with matching resource attribute values will be emitted.
+	MetricsInclude []filter.Config `mapstructure:"metrics_include"`
+	// Experimental: MetricsExclude defines a list of filters for attribute values.
+	// If the list is not empty, metrics with matching resource attribute values will not be emitted.
+	// MetricsInclude has higher priority than MetricsExclude.
+	MetricsExclude []filter.Config `mapstructure:"metrics_exclude"`
+
+	enabledSetByUser bool
+}
+
+func (rac *ResourceAttributeConfig) Unmarshal(parser *confmap.Conf) error {
+	if parser == nil {
+		return nil
+	}
+	err := parser.Unmarshal(rac)
+	if err != nil {
+		return err
+	}
+	rac.enabledSetByUser = parser.IsSet("enabled")
+	return nil
+}
+
+// ResourceAttributesConfig provides config for wavinsentio resource attributes.
+type ResourceAttributesConfig struct {
+	WavinsentioDeviceName         ResourceAttributeConfig `mapstructure:"wavinsentio.device.name"`
+	WavinsentioDeviceSerialNumber ResourceAttributeConfig `mapstructure:"wavinsentio.device.serial_number"`
+	WavinsentioDeviceType         ResourceAttributeConfig `mapstructure:"wavinsentio.device.type"`
+	WavinsentioRoomID             ResourceAttributeConfig `mapstructure:"wavinsentio.room.id"`
+}
+
+func DefaultResourceAttributesConfig() ResourceAttributesConfig {
+	return ResourceAttributesConfig{
+		WavinsentioDeviceName: ResourceAttributeConfig{
+			Enabled: true,
+		},
+		WavinsentioDeviceSerialNumber: ResourceAttributeConfig{
+			Enabled: true,
+		},
+		WavinsentioDeviceType: ResourceAttributeConfig{
+			Enabled: true,
+		},
+		WavinsentioRoomID: ResourceAttributeConfig{
+			Enabled: true,
+		},
+	}
+}
+
+// MetricsBuilderConfig is a configuration for wavinsentio metrics builder.
+type MetricsBuilderConfig struct {
+	Metrics            MetricsConfig            `mapstructure:"metrics"`
+	ResourceAttributes ResourceAttributesConfig `mapstructure:"resource_attributes"`
+}
+
+func DefaultMetricsBuilderConfig() MetricsBuilderConfig {
+	return MetricsBuilderConfig{
+		Metrics:            DefaultMetricsConfig(),
+		ResourceAttributes: DefaultResourceAttributesConfig(),
+	}
+}
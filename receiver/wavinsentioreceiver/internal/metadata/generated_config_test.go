@@ -0,0 +1,169 @@
+// Code generated by mdatagen. DO NOT EDIT.
+
+package metadata
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+)
+
+func TestMetricsBuilderConfig(t *testing.T) {
+	tests := []struct {
+		name string
+		want MetricsBuilderConfig
+	}{
+		{
+			name: "default",
+			want: DefaultMetricsBuilderConfig(),
+		},
+		{
+			name: "all_set",
+			want: MetricsBuilderConfig{
+				Metrics: MetricsConfig{
+					WavinsentioDeviceFirmware:             MetricConfig{Enabled: true},
+					WavinsentioDeviceHcMode:               MetricConfig{Enabled: true},
+					WavinsentioDeviceLastHeartbeat:        MetricConfig{Enabled: true},
+					WavinsentioDeviceOutdoorTemperature:   MetricConfig{Enabled: true},
+					WavinsentioDeviceQuietMode:            MetricConfig{Enabled: true},
+					WavinsentioDeviceStandbyMode:          MetricConfig{Enabled: true},
+					WavinsentioDeviceVacationMode:         MetricConfig{Enabled: true},
+					WavinsentioRoomCoolingCycles:          MetricConfig{Enabled: true},
+					WavinsentioRoomCoolingDemand:          MetricConfig{Enabled: true},
+					WavinsentioRoomCoolingDuration:        MetricConfig{Enabled: true},
+					WavinsentioRoomDehumidifierState:      MetricConfig{Enabled: true},
+					WavinsentioRoomHeatingCycles:          MetricConfig{Enabled: true},
+					WavinsentioRoomHeatingDemand:          MetricConfig{Enabled: true},
+					WavinsentioRoomHeatingDuration:        MetricConfig{Enabled: true},
+					WavinsentioRoomHumidity:               MetricConfig{Enabled: true},
+					WavinsentioRoomHumiditySetpoint:       MetricConfig{Enabled: true},
+					WavinsentioRoomInfo:                   MetricConfig{Enabled: true},
+					WavinsentioRoomLockMode:               MetricConfig{Enabled: true},
+					WavinsentioRoomTemperatureAir:         MetricConfig{Enabled: true},
+					WavinsentioRoomTemperatureDewPoint:    MetricConfig{Enabled: true},
+					WavinsentioRoomTemperatureSetpoint:    MetricConfig{Enabled: true},
+					WavinsentioRoomTemperatureSetpointMax: MetricConfig{Enabled: true},
+					WavinsentioRoomTemperatureSetpointMin: MetricConfig{Enabled: true},
+					WavinsentioRoomTemperatureState:       MetricConfig{Enabled: true},
+					WavinsentioRoomVacationMode:           MetricConfig{Enabled: true},
+				},
+				ResourceAttributes: ResourceAttributesConfig{
+					WavinsentioDeviceName:         ResourceAttributeConfig{Enabled: true},
+					WavinsentioDeviceSerialNumber: ResourceAttributeConfig{Enabled: true},
+					WavinsentioDeviceType:         ResourceAttributeConfig{Enabled: true},
+					WavinsentioRoomID:             ResourceAttributeConfig{Enabled: true},
+				},
+			},
+		},
+		{
+			name: "none_set",
+			want: MetricsBuilderConfig{
+				Metrics: MetricsConfig{
+					WavinsentioDeviceFirmware:             MetricConfig{Enabled: false},
+					WavinsentioDeviceHcMode:               MetricConfig{Enabled: false},
+					WavinsentioDeviceLastHeartbeat:        MetricConfig{Enabled: false},
+					WavinsentioDeviceOutdoorTemperature:   MetricConfig{Enabled: false},
+					WavinsentioDeviceQuietMode:            MetricConfig{Enabled: false},
+					WavinsentioDeviceStandbyMode:          MetricConfig{Enabled: false},
+					WavinsentioDeviceVacationMode:         MetricConfig{Enabled: false},
+					WavinsentioRoomCoolingCycles:          MetricConfig{Enabled: false},
+					WavinsentioRoomCoolingDemand:          MetricConfig{Enabled: false},
+					WavinsentioRoomCoolingDuration:        MetricConfig{Enabled: false},
+					WavinsentioRoomDehumidifierState:      MetricConfig{Enabled: false},
+					WavinsentioRoomHeatingCycles:          MetricConfig{Enabled: false},
+					WavinsentioRoomHeatingDemand:          MetricConfig{Enabled: false},
+					WavinsentioRoomHeatingDuration:        MetricConfig{Enabled: false},
+					WavinsentioRoomHumidity:               MetricConfig{Enabled: false},
+					WavinsentioRoomHumiditySetpoint:       MetricConfig{Enabled: false},
+					WavinsentioRoomInfo:                   MetricConfig{Enabled: false},
+					WavinsentioRoomLockMode:               MetricConfig{Enabled: false},
+					WavinsentioRoomTemperatureAir:         MetricConfig{Enabled: false},
+					WavinsentioRoomTemperatureDewPoint:    MetricConfig{Enabled: false},
+					WavinsentioRoomTemperatureSetpoint:    MetricConfig{Enabled: false},
+					WavinsentioRoomTemperatureSetpointMax: MetricConfig{Enabled: false},
+					WavinsentioRoomTemperatureSetpointMin: MetricConfig{Enabled: false},
+					WavinsentioRoomTemperatureState:       MetricConfig{Enabled: false},
+					WavinsentioRoomVacationMode:           MetricConfig{Enabled: false},
+				},
+				ResourceAttributes: ResourceAttributesConfig{
+					WavinsentioDeviceName:         ResourceAttributeConfig{Enabled: false},
+					WavinsentioDeviceSerialNumber: ResourceAttributeConfig{Enabled: false},
+					WavinsentioDeviceType:         ResourceAttributeConfig{Enabled: false},
+					WavinsentioRoomID:             ResourceAttributeConfig{Enabled: false},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := loadMetricsBuilderConfig(t, tt.name)
+			diff := cmp.Diff(tt.want, cfg, cmpopts.IgnoreUnexported(MetricConfig{}, ResourceAttributeConfig{}))
+			require.Emptyf(t, diff, "Config mismatch (-expected +actual):\n%s", diff)
+		})
+	}
+}
+
+func loadMetricsBuilderConfig(t *testing.T, name string) MetricsBuilderConfig {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+	sub, err := cm.Sub(name)
+	require.NoError(t, err)
+	cfg := DefaultMetricsBuilderConfig()
+	require.NoError(t, sub.Unmarshal(&cfg, confmap.WithIgnoreUnused()))
+	return cfg
+}
+
+func TestResourceAttributesConfig(t *testing.T) {
+	tests := []struct {
+		name string
+		want ResourceAttributesConfig
+	}{
+		{
+			name: "default",
+			want: DefaultResourceAttributesConfig(),
+		},
+		{
+			name: "all_set",
+			want: ResourceAttributesConfig{
+				WavinsentioDeviceName:         ResourceAttributeConfig{Enabled: true},
+				WavinsentioDeviceSerialNumber: ResourceAttributeConfig{Enabled: true},
+				WavinsentioDeviceType:         ResourceAttributeConfig{Enabled: true},
+				WavinsentioRoomID:             ResourceAttributeConfig{Enabled: true},
+			},
+		},
+		{
+			name: "none_set",
+			want: ResourceAttributesConfig{
+				WavinsentioDeviceName:         ResourceAttributeConfig{Enabled: false},
+				WavinsentioDeviceSerialNumber: ResourceAttributeConfig{Enabled: false},
+				WavinsentioDeviceType:         ResourceAttributeConfig{Enabled: false},
+				WavinsentioRoomID:             ResourceAttributeConfig{Enabled: false},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := loadResourceAttributesConfig(t, tt.name)
+			diff := cmp.Diff(tt.want, cfg, cmpopts.IgnoreUnexported(ResourceAttributeConfig{}))
+			require.Emptyf(t, diff, "Config mismatch (-expected +actual):\n%s", diff)
+		})
+	}
+}
+
+func loadResourceAttributesConfig(t *testing.T, name string) ResourceAttributesConfig {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+	sub, err := cm.Sub(name)
+	require.NoError(t, err)
+	sub, err = sub.Sub("resource_attributes")
+	require.NoError(t, err)
+	cfg := DefaultResourceAttributesConfig()
+	require.NoError(t, sub.Unmarshal(&cfg))
+	return cfg
+}
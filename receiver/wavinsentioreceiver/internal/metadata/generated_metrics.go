@@ -0,0 +1,1752 @@
+// Code generated by mdatagen. DO NOT EDIT.
+
+package metadata
+
+import (
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/filter"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/receiver"
+)
+
+var MetricsInfo = metricsInfo{
+	WavinsentioDeviceFirmware: metricInfo{
+		Name: "wavinsentio.device.firmware",
+	},
+	WavinsentioDeviceHcMode: metricInfo{
+		Name: "wavinsentio.device.hc_mode",
+	},
+	WavinsentioDeviceLastHeartbeat: metricInfo{
+		Name: "wavinsentio.device.last_heartbeat",
+	},
+	WavinsentioDeviceOutdoorTemperature: metricInfo{
+		Name: "wavinsentio.device.outdoor_temperature",
+	},
+	WavinsentioDeviceQuietMode: metricInfo{
+		Name: "wavinsentio.device.quiet_mode",
+	},
+	WavinsentioDeviceStandbyMode: metricInfo{
+		Name: "wavinsentio.device.standby_mode",
+	},
+	WavinsentioDeviceVacationMode: metricInfo{
+		Name: "wavinsentio.device.vacation_mode",
+	},
+	WavinsentioRoomCoolingCycles: metricInfo{
+		Name: "wavinsentio.room.cooling.cycles",
+	},
+	WavinsentioRoomCoolingDemand: metricInfo{
+		Name: "wavinsentio.room.cooling.demand",
+	},
+	WavinsentioRoomCoolingDuration: metricInfo{
+		Name: "wavinsentio.room.cooling.duration",
+	},
+	WavinsentioRoomDehumidifierState: metricInfo{
+		Name: "wavinsentio.room.dehumidifier.state",
+	},
+	WavinsentioRoomHeatingCycles: metricInfo{
+		Name: "wavinsentio.room.heating.cycles",
+	},
+	WavinsentioRoomHeatingDemand: metricInfo{
+		Name: "wavinsentio.room.heating.demand",
+	},
+	WavinsentioRoomHeatingDuration: metricInfo{
+		Name: "wavinsentio.room.heating.duration",
+	},
+	WavinsentioRoomHumidity: metricInfo{
+		Name: "wavinsentio.room.humidity",
+	},
+	WavinsentioRoomHumiditySetpoint: metricInfo{
+		Name: "wavinsentio.room.humidity.setpoint",
+	},
+	WavinsentioRoomInfo: metricInfo{
+		Name: "wavinsentio.room.info",
+	},
+	WavinsentioRoomLockMode: metricInfo{
+		Name: "wavinsentio.room.lock_mode",
+	},
+	WavinsentioRoomTemperatureAir: metricInfo{
+		Name: "wavinsentio.room.temperature.air",
+	},
+	WavinsentioRoomTemperatureDewPoint: metricInfo{
+		Name: "wavinsentio.room.temperature.dew_point",
+	},
+	WavinsentioRoomTemperatureSetpoint: metricInfo{
+		Name: "wavinsentio.room.temperature.setpoint",
+	},
+	WavinsentioRoomTemperatureSetpointMax: metricInfo{
+		Name: "wavinsentio.room.temperature.setpoint.max",
+	},
+	WavinsentioRoomTemperatureSetpointMin: metricInfo{
+		Name: "wavinsentio.room.temperature.setpoint.min",
+	},
+	WavinsentioRoomTemperatureState: metricInfo{
+		Name: "wavinsentio.room.temperature_state",
+	},
+	WavinsentioRoomVacationMode: metricInfo{
+		Name: "wavinsentio.room.vacation_mode",
+	},
+}
+
+type metricsInfo struct {
+	WavinsentioDeviceFirmware             metricInfo
+	WavinsentioDeviceHcMode               metricInfo
+	WavinsentioDeviceLastHeartbeat        metricInfo
+	WavinsentioDeviceOutdoorTemperature   metricInfo
+	WavinsentioDeviceQuietMode            metricInfo
+	WavinsentioDeviceStandbyMode          metricInfo
+	WavinsentioDeviceVacationMode         metricInfo
+	WavinsentioRoomCoolingCycles          metricInfo
+	WavinsentioRoomCoolingDemand          metricInfo
+	WavinsentioRoomCoolingDuration        metricInfo
+	WavinsentioRoomDehumidifierState      metricInfo
+	WavinsentioRoomHeatingCycles          metricInfo
+	WavinsentioRoomHeatingDemand          metricInfo
+	WavinsentioRoomHeatingDuration        metricInfo
+	WavinsentioRoomHumidity               metricInfo
+	WavinsentioRoomHumiditySetpoint       metricInfo
+	WavinsentioRoomInfo                   metricInfo
+	WavinsentioRoomLockMode               metricInfo
+	WavinsentioRoomTemperatureAir         metricInfo
+	WavinsentioRoomTemperatureDewPoint    metricInfo
+	WavinsentioRoomTemperatureSetpoint    metricInfo
+	WavinsentioRoomTemperatureSetpointMax metricInfo
+	WavinsentioRoomTemperatureSetpointMin metricInfo
+	WavinsentioRoomTemperatureState       metricInfo
+	WavinsentioRoomVacationMode           metricInfo
+}
+
+type metricInfo struct {
+	Name string
+}
+
+type metricWavinsentioDeviceFirmware struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills wavinsentio.device.firmware metric with initial data.
+func (m *metricWavinsentioDeviceFirmware) init() {
+	m.data.SetName("wavinsentio.device.firmware")
+	m.data.SetDescription("Firmware versions of the device, as a gauge set to 1.")
+	m.data.SetUnit("1")
+	m.data.SetEmptyGauge()
+	m.data.Gauge().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricWavinsentioDeviceFirmware) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64, firmwareInstalledAttributeValue string, firmwareAvailableAttributeValue string) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Gauge().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(val)
+	dp.Attributes().PutStr("wavinsentio.firmware.installed", firmwareInstalledAttributeValue)
+	dp.Attributes().PutStr("wavinsentio.firmware.available", firmwareAvailableAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricWavinsentioDeviceFirmware) updateCapacity() {
+	if m.data.Gauge().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Gauge().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricWavinsentioDeviceFirmware) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Gauge().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricWavinsentioDeviceFirmware(cfg MetricConfig) metricWavinsentioDeviceFirmware {
+	m := metricWavinsentioDeviceFirmware{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricWavinsentioDeviceHcMode struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills wavinsentio.device.hc_mode metric with initial data.
+func (m *metricWavinsentioDeviceHcMode) init() {
+	m.data.SetName("wavinsentio.device.hc_mode")
+	m.data.SetDescription("Heating/cooling mode of the device, as a state-set gauge.")
+	m.data.SetUnit("1")
+	m.data.SetEmptyGauge()
+	m.data.Gauge().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricWavinsentioDeviceHcMode) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64, stateAttributeValue string) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Gauge().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(val)
+	dp.Attributes().PutStr("state", stateAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricWavinsentioDeviceHcMode) updateCapacity() {
+	if m.data.Gauge().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Gauge().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricWavinsentioDeviceHcMode) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Gauge().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricWavinsentioDeviceHcMode(cfg MetricConfig) metricWavinsentioDeviceHcMode {
+	m := metricWavinsentioDeviceHcMode{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricWavinsentioDeviceLastHeartbeat struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills wavinsentio.device.last_heartbeat metric with initial data.
+func (m *metricWavinsentioDeviceLastHeartbeat) init() {
+	m.data.SetName("wavinsentio.device.last_heartbeat")
+	m.data.SetDescription("Device last heartbeat timestamp.")
+	m.data.SetUnit("ns")
+	m.data.SetEmptyGauge()
+}
+
+func (m *metricWavinsentioDeviceLastHeartbeat) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Gauge().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(val)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricWavinsentioDeviceLastHeartbeat) updateCapacity() {
+	if m.data.Gauge().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Gauge().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricWavinsentioDeviceLastHeartbeat) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Gauge().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricWavinsentioDeviceLastHeartbeat(cfg MetricConfig) metricWavinsentioDeviceLastHeartbeat {
+	m := metricWavinsentioDeviceLastHeartbeat{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricWavinsentioDeviceOutdoorTemperature struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills wavinsentio.device.outdoor_temperature metric with initial data.
+func (m *metricWavinsentioDeviceOutdoorTemperature) init() {
+	m.data.SetName("wavinsentio.device.outdoor_temperature")
+	m.data.SetDescription("Outdoor temperature from sensor.")
+	m.data.SetUnit("°C")
+	m.data.SetEmptyGauge()
+	m.data.Gauge().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricWavinsentioDeviceOutdoorTemperature) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val float64, sensorIDAttributeValue string) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Gauge().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetDoubleValue(val)
+	dp.Attributes().PutStr("wavinsentio.sensor.id", sensorIDAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricWavinsentioDeviceOutdoorTemperature) updateCapacity() {
+	if m.data.Gauge().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Gauge().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricWavinsentioDeviceOutdoorTemperature) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Gauge().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricWavinsentioDeviceOutdoorTemperature(cfg MetricConfig) metricWavinsentioDeviceOutdoorTemperature {
+	m := metricWavinsentioDeviceOutdoorTemperature{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricWavinsentioDeviceQuietMode struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills wavinsentio.device.quiet_mode metric with initial data.
+func (m *metricWavinsentioDeviceQuietMode) init() {
+	m.data.SetName("wavinsentio.device.quiet_mode")
+	m.data.SetDescription("Quiet mode of the device, as a state-set gauge.")
+	m.data.SetUnit("1")
+	m.data.SetEmptyGauge()
+	m.data.Gauge().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricWavinsentioDeviceQuietMode) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64, stateAttributeValue string) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Gauge().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(val)
+	dp.Attributes().PutStr("state", stateAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricWavinsentioDeviceQuietMode) updateCapacity() {
+	if m.data.Gauge().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Gauge().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricWavinsentioDeviceQuietMode) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Gauge().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricWavinsentioDeviceQuietMode(cfg MetricConfig) metricWavinsentioDeviceQuietMode {
+	m := metricWavinsentioDeviceQuietMode{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricWavinsentioDeviceStandbyMode struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills wavinsentio.device.standby_mode metric with initial data.
+func (m *metricWavinsentioDeviceStandbyMode) init() {
+	m.data.SetName("wavinsentio.device.standby_mode")
+	m.data.SetDescription("Standby mode of the device, as a state-set gauge.")
+	m.data.SetUnit("1")
+	m.data.SetEmptyGauge()
+	m.data.Gauge().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricWavinsentioDeviceStandbyMode) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64, stateAttributeValue string) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Gauge().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(val)
+	dp.Attributes().PutStr("state", stateAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricWavinsentioDeviceStandbyMode) updateCapacity() {
+	if m.data.Gauge().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Gauge().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricWavinsentioDeviceStandbyMode) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Gauge().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricWavinsentioDeviceStandbyMode(cfg MetricConfig) metricWavinsentioDeviceStandbyMode {
+	m := metricWavinsentioDeviceStandbyMode{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricWavinsentioDeviceVacationMode struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills wavinsentio.device.vacation_mode metric with initial data.
+func (m *metricWavinsentioDeviceVacationMode) init() {
+	m.data.SetName("wavinsentio.device.vacation_mode")
+	m.data.SetDescription("Vacation mode of the device, as a state-set gauge.")
+	m.data.SetUnit("1")
+	m.data.SetEmptyGauge()
+	m.data.Gauge().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricWavinsentioDeviceVacationMode) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64, stateAttributeValue string) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Gauge().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(val)
+	dp.Attributes().PutStr("state", stateAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricWavinsentioDeviceVacationMode) updateCapacity() {
+	if m.data.Gauge().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Gauge().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricWavinsentioDeviceVacationMode) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Gauge().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricWavinsentioDeviceVacationMode(cfg MetricConfig) metricWavinsentioDeviceVacationMode {
+	m := metricWavinsentioDeviceVacationMode{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricWavinsentioRoomCoolingCycles struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills wavinsentio.room.cooling.cycles metric with initial data.
+func (m *metricWavinsentioRoomCoolingCycles) init() {
+	m.data.SetName("wavinsentio.room.cooling.cycles")
+	m.data.SetDescription("Number of times the room started cooling.")
+	m.data.SetUnit("{cycle}")
+	m.data.SetEmptySum()
+	m.data.Sum().SetIsMonotonic(true)
+	m.data.Sum().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+}
+
+func (m *metricWavinsentioRoomCoolingCycles) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Sum().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(val)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricWavinsentioRoomCoolingCycles) updateCapacity() {
+	if m.data.Sum().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Sum().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricWavinsentioRoomCoolingCycles) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Sum().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricWavinsentioRoomCoolingCycles(cfg MetricConfig) metricWavinsentioRoomCoolingCycles {
+	m := metricWavinsentioRoomCoolingCycles{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricWavinsentioRoomCoolingDemand struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills wavinsentio.room.cooling.demand metric with initial data.
+func (m *metricWavinsentioRoomCoolingDemand) init() {
+	m.data.SetName("wavinsentio.room.cooling.demand")
+	m.data.SetDescription("Whether the room is calling for cooling (1) or not (0).")
+	m.data.SetUnit("1")
+	m.data.SetEmptyGauge()
+}
+
+func (m *metricWavinsentioRoomCoolingDemand) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Gauge().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(val)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricWavinsentioRoomCoolingDemand) updateCapacity() {
+	if m.data.Gauge().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Gauge().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricWavinsentioRoomCoolingDemand) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Gauge().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricWavinsentioRoomCoolingDemand(cfg MetricConfig) metricWavinsentioRoomCoolingDemand {
+	m := metricWavinsentioRoomCoolingDemand{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricWavinsentioRoomCoolingDuration struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills wavinsentio.room.cooling.duration metric with initial data.
+func (m *metricWavinsentioRoomCoolingDuration) init() {
+	m.data.SetName("wavinsentio.room.cooling.duration")
+	m.data.SetDescription("Time the room has spent cooling.")
+	m.data.SetUnit("s")
+	m.data.SetEmptySum()
+	m.data.Sum().SetIsMonotonic(true)
+	m.data.Sum().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+}
+
+func (m *metricWavinsentioRoomCoolingDuration) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val float64) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Sum().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetDoubleValue(val)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricWavinsentioRoomCoolingDuration) updateCapacity() {
+	if m.data.Sum().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Sum().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricWavinsentioRoomCoolingDuration) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Sum().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricWavinsentioRoomCoolingDuration(cfg MetricConfig) metricWavinsentioRoomCoolingDuration {
+	m := metricWavinsentioRoomCoolingDuration{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricWavinsentioRoomDehumidifierState struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills wavinsentio.room.dehumidifier.state metric with initial data.
+func (m *metricWavinsentioRoomDehumidifierState) init() {
+	m.data.SetName("wavinsentio.room.dehumidifier.state")
+	m.data.SetDescription("Dehumidifier state, as a state-set gauge.")
+	m.data.SetUnit("1")
+	m.data.SetEmptyGauge()
+	m.data.Gauge().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricWavinsentioRoomDehumidifierState) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64, stateAttributeValue string) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Gauge().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(val)
+	dp.Attributes().PutStr("state", stateAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricWavinsentioRoomDehumidifierState) updateCapacity() {
+	if m.data.Gauge().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Gauge().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricWavinsentioRoomDehumidifierState) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Gauge().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricWavinsentioRoomDehumidifierState(cfg MetricConfig) metricWavinsentioRoomDehumidifierState {
+	m := metricWavinsentioRoomDehumidifierState{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricWavinsentioRoomHeatingCycles struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills wavinsentio.room.heating.cycles metric with initial data.
+func (m *metricWavinsentioRoomHeatingCycles) init() {
+	m.data.SetName("wavinsentio.room.heating.cycles")
+	m.data.SetDescription("Number of times the room started heating.")
+	m.data.SetUnit("{cycle}")
+	m.data.SetEmptySum()
+	m.data.Sum().SetIsMonotonic(true)
+	m.data.Sum().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+}
+
+func (m *metricWavinsentioRoomHeatingCycles) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Sum().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(val)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricWavinsentioRoomHeatingCycles) updateCapacity() {
+	if m.data.Sum().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Sum().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricWavinsentioRoomHeatingCycles) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Sum().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricWavinsentioRoomHeatingCycles(cfg MetricConfig) metricWavinsentioRoomHeatingCycles {
+	m := metricWavinsentioRoomHeatingCycles{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricWavinsentioRoomHeatingDemand struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills wavinsentio.room.heating.demand metric with initial data.
+func (m *metricWavinsentioRoomHeatingDemand) init() {
+	m.data.SetName("wavinsentio.room.heating.demand")
+	m.data.SetDescription("Whether the room is calling for heat (1) or not (0).")
+	m.data.SetUnit("1")
+	m.data.SetEmptyGauge()
+}
+
+func (m *metricWavinsentioRoomHeatingDemand) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Gauge().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(val)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricWavinsentioRoomHeatingDemand) updateCapacity() {
+	if m.data.Gauge().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Gauge().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricWavinsentioRoomHeatingDemand) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Gauge().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricWavinsentioRoomHeatingDemand(cfg MetricConfig) metricWavinsentioRoomHeatingDemand {
+	m := metricWavinsentioRoomHeatingDemand{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricWavinsentioRoomHeatingDuration struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills wavinsentio.room.heating.duration metric with initial data.
+func (m *metricWavinsentioRoomHeatingDuration) init() {
+	m.data.SetName("wavinsentio.room.heating.duration")
+	m.data.SetDescription("Time the room has spent heating.")
+	m.data.SetUnit("s")
+	m.data.SetEmptySum()
+	m.data.Sum().SetIsMonotonic(true)
+	m.data.Sum().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+}
+
+func (m *metricWavinsentioRoomHeatingDuration) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val float64) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Sum().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetDoubleValue(val)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricWavinsentioRoomHeatingDuration) updateCapacity() {
+	if m.data.Sum().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Sum().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricWavinsentioRoomHeatingDuration) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Sum().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricWavinsentioRoomHeatingDuration(cfg MetricConfig) metricWavinsentioRoomHeatingDuration {
+	m := metricWavinsentioRoomHeatingDuration{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricWavinsentioRoomHumidity struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills wavinsentio.room.humidity metric with initial data.
+func (m *metricWavinsentioRoomHumidity) init() {
+	m.data.SetName("wavinsentio.room.humidity")
+	m.data.SetDescription("Room humidity.")
+	m.data.SetUnit("%")
+	m.data.SetEmptyGauge()
+}
+
+func (m *metricWavinsentioRoomHumidity) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val float64) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Gauge().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetDoubleValue(val)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricWavinsentioRoomHumidity) updateCapacity() {
+	if m.data.Gauge().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Gauge().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricWavinsentioRoomHumidity) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Gauge().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricWavinsentioRoomHumidity(cfg MetricConfig) metricWavinsentioRoomHumidity {
+	m := metricWavinsentioRoomHumidity{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricWavinsentioRoomHumiditySetpoint struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills wavinsentio.room.humidity.setpoint metric with initial data.
+func (m *metricWavinsentioRoomHumiditySetpoint) init() {
+	m.data.SetName("wavinsentio.room.humidity.setpoint")
+	m.data.SetDescription("Room dehumidification setpoint for each heating/cooling mode.")
+	m.data.SetUnit("%")
+	m.data.SetEmptyGauge()
+	m.data.Gauge().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricWavinsentioRoomHumiditySetpoint) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val float64, hcModeAttributeValue string) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Gauge().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetDoubleValue(val)
+	dp.Attributes().PutStr("wavinsentio.hc_mode", hcModeAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricWavinsentioRoomHumiditySetpoint) updateCapacity() {
+	if m.data.Gauge().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Gauge().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricWavinsentioRoomHumiditySetpoint) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Gauge().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricWavinsentioRoomHumiditySetpoint(cfg MetricConfig) metricWavinsentioRoomHumiditySetpoint {
+	m := metricWavinsentioRoomHumiditySetpoint{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricWavinsentioRoomInfo struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills wavinsentio.room.info metric with initial data.
+func (m *metricWavinsentioRoomInfo) init() {
+	m.data.SetName("wavinsentio.room.info")
+	m.data.SetDescription("Title of the room, as a gauge set to 1. The title is a setting, so it is not a resource attribute.")
+	m.data.SetUnit("1")
+	m.data.SetEmptyGauge()
+	m.data.Gauge().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricWavinsentioRoomInfo) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64, roomTitleAttributeValue string) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Gauge().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(val)
+	dp.Attributes().PutStr("wavinsentio.room.title", roomTitleAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricWavinsentioRoomInfo) updateCapacity() {
+	if m.data.Gauge().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Gauge().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricWavinsentioRoomInfo) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Gauge().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricWavinsentioRoomInfo(cfg MetricConfig) metricWavinsentioRoomInfo {
+	m := metricWavinsentioRoomInfo{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricWavinsentioRoomLockMode struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills wavinsentio.room.lock_mode metric with initial data.
+func (m *metricWavinsentioRoomLockMode) init() {
+	m.data.SetName("wavinsentio.room.lock_mode")
+	m.data.SetDescription("Lock mode of the room, as a state-set gauge.")
+	m.data.SetUnit("1")
+	m.data.SetEmptyGauge()
+	m.data.Gauge().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricWavinsentioRoomLockMode) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64, stateAttributeValue string) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Gauge().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(val)
+	dp.Attributes().PutStr("state", stateAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricWavinsentioRoomLockMode) updateCapacity() {
+	if m.data.Gauge().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Gauge().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricWavinsentioRoomLockMode) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Gauge().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricWavinsentioRoomLockMode(cfg MetricConfig) metricWavinsentioRoomLockMode {
+	m := metricWavinsentioRoomLockMode{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricWavinsentioRoomTemperatureAir struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills wavinsentio.room.temperature.air metric with initial data.
+func (m *metricWavinsentioRoomTemperatureAir) init() {
+	m.data.SetName("wavinsentio.room.temperature.air")
+	m.data.SetDescription("Room air temperature.")
+	m.data.SetUnit("°C")
+	m.data.SetEmptyGauge()
+}
+
+func (m *metricWavinsentioRoomTemperatureAir) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val float64) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Gauge().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetDoubleValue(val)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricWavinsentioRoomTemperatureAir) updateCapacity() {
+	if m.data.Gauge().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Gauge().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricWavinsentioRoomTemperatureAir) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Gauge().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricWavinsentioRoomTemperatureAir(cfg MetricConfig) metricWavinsentioRoomTemperatureAir {
+	m := metricWavinsentioRoomTemperatureAir{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricWavinsentioRoomTemperatureDewPoint struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills wavinsentio.room.temperature.dew_point metric with initial data.
+func (m *metricWavinsentioRoomTemperatureDewPoint) init() {
+	m.data.SetName("wavinsentio.room.temperature.dew_point")
+	m.data.SetDescription("Room dew point, derived from the air temperature and humidity.")
+	m.data.SetUnit("°C")
+	m.data.SetEmptyGauge()
+}
+
+func (m *metricWavinsentioRoomTemperatureDewPoint) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val float64) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Gauge().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetDoubleValue(val)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricWavinsentioRoomTemperatureDewPoint) updateCapacity() {
+	if m.data.Gauge().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Gauge().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricWavinsentioRoomTemperatureDewPoint) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Gauge().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricWavinsentioRoomTemperatureDewPoint(cfg MetricConfig) metricWavinsentioRoomTemperatureDewPoint {
+	m := metricWavinsentioRoomTemperatureDewPoint{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricWavinsentioRoomTemperatureSetpoint struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills wavinsentio.room.temperature.setpoint metric with initial data.
+func (m *metricWavinsentioRoomTemperatureSetpoint) init() {
+	m.data.SetName("wavinsentio.room.temperature.setpoint")
+	m.data.SetDescription("Room setpoint temperature.")
+	m.data.SetUnit("°C")
+	m.data.SetEmptyGauge()
+}
+
+func (m *metricWavinsentioRoomTemperatureSetpoint) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val float64) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Gauge().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetDoubleValue(val)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricWavinsentioRoomTemperatureSetpoint) updateCapacity() {
+	if m.data.Gauge().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Gauge().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricWavinsentioRoomTemperatureSetpoint) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Gauge().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricWavinsentioRoomTemperatureSetpoint(cfg MetricConfig) metricWavinsentioRoomTemperatureSetpoint {
+	m := metricWavinsentioRoomTemperatureSetpoint{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricWavinsentioRoomTemperatureSetpointMax struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills wavinsentio.room.temperature.setpoint.max metric with initial data.
+func (m *metricWavinsentioRoomTemperatureSetpointMax) init() {
+	m.data.SetName("wavinsentio.room.temperature.setpoint.max")
+	m.data.SetDescription("Highest setpoint temperature allowed for the room.")
+	m.data.SetUnit("°C")
+	m.data.SetEmptyGauge()
+}
+
+func (m *metricWavinsentioRoomTemperatureSetpointMax) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val float64) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Gauge().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetDoubleValue(val)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricWavinsentioRoomTemperatureSetpointMax) updateCapacity() {
+	if m.data.Gauge().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Gauge().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricWavinsentioRoomTemperatureSetpointMax) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Gauge().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricWavinsentioRoomTemperatureSetpointMax(cfg MetricConfig) metricWavinsentioRoomTemperatureSetpointMax {
+	m := metricWavinsentioRoomTemperatureSetpointMax{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricWavinsentioRoomTemperatureSetpointMin struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills wavinsentio.room.temperature.setpoint.min metric with initial data.
+func (m *metricWavinsentioRoomTemperatureSetpointMin) init() {
+	m.data.SetName("wavinsentio.room.temperature.setpoint.min")
+	m.data.SetDescription("Lowest setpoint temperature allowed for the room.")
+	m.data.SetUnit("°C")
+	m.data.SetEmptyGauge()
+}
+
+func (m *metricWavinsentioRoomTemperatureSetpointMin) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val float64) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Gauge().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetDoubleValue(val)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricWavinsentioRoomTemperatureSetpointMin) updateCapacity() {
+	if m.data.Gauge().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Gauge().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricWavinsentioRoomTemperatureSetpointMin) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Gauge().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricWavinsentioRoomTemperatureSetpointMin(cfg MetricConfig) metricWavinsentioRoomTemperatureSetpointMin {
+	m := metricWavinsentioRoomTemperatureSetpointMin{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricWavinsentioRoomTemperatureState struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills wavinsentio.room.temperature_state metric with initial data.
+func (m *metricWavinsentioRoomTemperatureState) init() {
+	m.data.SetName("wavinsentio.room.temperature_state")
+	m.data.SetDescription("Temperature state of the room, as a state-set gauge.")
+	m.data.SetUnit("1")
+	m.data.SetEmptyGauge()
+	m.data.Gauge().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricWavinsentioRoomTemperatureState) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64, stateAttributeValue string) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Gauge().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(val)
+	dp.Attributes().PutStr("state", stateAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricWavinsentioRoomTemperatureState) updateCapacity() {
+	if m.data.Gauge().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Gauge().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricWavinsentioRoomTemperatureState) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Gauge().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricWavinsentioRoomTemperatureState(cfg MetricConfig) metricWavinsentioRoomTemperatureState {
+	m := metricWavinsentioRoomTemperatureState{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricWavinsentioRoomVacationMode struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills wavinsentio.room.vacation_mode metric with initial data.
+func (m *metricWavinsentioRoomVacationMode) init() {
+	m.data.SetName("wavinsentio.room.vacation_mode")
+	m.data.SetDescription("Vacation mode of the room, as a state-set gauge.")
+	m.data.SetUnit("1")
+	m.data.SetEmptyGauge()
+	m.data.Gauge().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricWavinsentioRoomVacationMode) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64, stateAttributeValue string) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Gauge().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(val)
+	dp.Attributes().PutStr("state", stateAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricWavinsentioRoomVacationMode) updateCapacity() {
+	if m.data.Gauge().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Gauge().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricWavinsentioRoomVacationMode) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Gauge().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricWavinsentioRoomVacationMode(cfg MetricConfig) metricWavinsentioRoomVacationMode {
+	m := metricWavinsentioRoomVacationMode{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+// MetricsBuilder provides an interface for scrapers to report metrics while taking care of all the transformations
+// required to produce metric representation defined in metadata and user config.
+type MetricsBuilder struct {
+	config                                      MetricsBuilderConfig // config of the metrics builder.
+	startTime                                   pcommon.Timestamp    // start time that will be applied to all recorded data points.
+	metricsCapacity                             int                  // maximum observed number of metrics per resource.
+	metricsBuffer                               pmetric.Metrics      // accumulates metrics data before emitting.
+	buildInfo                                   component.BuildInfo  // contains version information.
+	resourceAttributeIncludeFilter              map[string]filter.Filter
+	resourceAttributeExcludeFilter              map[string]filter.Filter
+	metricWavinsentioDeviceFirmware             metricWavinsentioDeviceFirmware
+	metricWavinsentioDeviceHcMode               metricWavinsentioDeviceHcMode
+	metricWavinsentioDeviceLastHeartbeat        metricWavinsentioDeviceLastHeartbeat
+	metricWavinsentioDeviceOutdoorTemperature   metricWavinsentioDeviceOutdoorTemperature
+	metricWavinsentioDeviceQuietMode            metricWavinsentioDeviceQuietMode
+	metricWavinsentioDeviceStandbyMode          metricWavinsentioDeviceStandbyMode
+	metricWavinsentioDeviceVacationMode         metricWavinsentioDeviceVacationMode
+	metricWavinsentioRoomCoolingCycles          metricWavinsentioRoomCoolingCycles
+	metricWavinsentioRoomCoolingDemand          metricWavinsentioRoomCoolingDemand
+	metricWavinsentioRoomCoolingDuration        metricWavinsentioRoomCoolingDuration
+	metricWavinsentioRoomDehumidifierState      metricWavinsentioRoomDehumidifierState
+	metricWavinsentioRoomHeatingCycles          metricWavinsentioRoomHeatingCycles
+	metricWavinsentioRoomHeatingDemand          metricWavinsentioRoomHeatingDemand
+	metricWavinsentioRoomHeatingDuration        metricWavinsentioRoomHeatingDuration
+	metricWavinsentioRoomHumidity               metricWavinsentioRoomHumidity
+	metricWavinsentioRoomHumiditySetpoint       metricWavinsentioRoomHumiditySetpoint
+	metricWavinsentioRoomInfo                   metricWavinsentioRoomInfo
+	metricWavinsentioRoomLockMode               metricWavinsentioRoomLockMode
+	metricWavinsentioRoomTemperatureAir         metricWavinsentioRoomTemperatureAir
+	metricWavinsentioRoomTemperatureDewPoint    metricWavinsentioRoomTemperatureDewPoint
+	metricWavinsentioRoomTemperatureSetpoint    metricWavinsentioRoomTemperatureSetpoint
+	metricWavinsentioRoomTemperatureSetpointMax metricWavinsentioRoomTemperatureSetpointMax
+	metricWavinsentioRoomTemperatureSetpointMin metricWavinsentioRoomTemperatureSetpointMin
+	metricWavinsentioRoomTemperatureState       metricWavinsentioRoomTemperatureState
+	metricWavinsentioRoomVacationMode           metricWavinsentioRoomVacationMode
+}
+
+// MetricBuilderOption applies changes to default metrics builder.
+type MetricBuilderOption interface {
+	apply(*MetricsBuilder)
+}
+
+type metricBuilderOptionFunc func(mb *MetricsBuilder)
+
+func (mbof metricBuilderOptionFunc) apply(mb *MetricsBuilder) {
+	mbof(mb)
+}
+
+// WithStartTime sets startTime on the metrics builder.
+func WithStartTime(startTime pcommon.Timestamp) MetricBuilderOption {
+	return metricBuilderOptionFunc(func(mb *MetricsBuilder) {
+		mb.startTime = startTime
+	})
+}
+func NewMetricsBuilder(mbc MetricsBuilderConfig, settings receiver.Settings, options ...MetricBuilderOption) *MetricsBuilder {
+	mb := &MetricsBuilder{
+		config:                               mbc,
+		startTime:                            pcommon.NewTimestampFromTime(time.Now()),
+		metricsBuffer:                        pmetric.NewMetrics(),
+		buildInfo:                            settings.BuildInfo,
+		metricWavinsentioDeviceFirmware:      newMetricWavinsentioDeviceFirmware(mbc.Metrics.WavinsentioDeviceFirmware),
+		metricWavinsentioDeviceHcMode:        newMetricWavinsentioDeviceHcMode(mbc.Metrics.WavinsentioDeviceHcMode),
+		metricWavinsentioDeviceLastHeartbeat: newMetricWavinsentioDeviceLastHeartbeat(mbc.Metrics.WavinsentioDeviceLastHeartbeat),
+		metricWavinsentioDeviceOutdoorTemperature:   newMetricWavinsentioDeviceOutdoorTemperature(mbc.Metrics.WavinsentioDeviceOutdoorTemperature),
+		metricWavinsentioDeviceQuietMode:            newMetricWavinsentioDeviceQuietMode(mbc.Metrics.WavinsentioDeviceQuietMode),
+		metricWavinsentioDeviceStandbyMode:          newMetricWavinsentioDeviceStandbyMode(mbc.Metrics.WavinsentioDeviceStandbyMode),
+		metricWavinsentioDeviceVacationMode:         newMetricWavinsentioDeviceVacationMode(mbc.Metrics.WavinsentioDeviceVacationMode),
+		metricWavinsentioRoomCoolingCycles:          newMetricWavinsentioRoomCoolingCycles(mbc.Metrics.WavinsentioRoomCoolingCycles),
+		metricWavinsentioRoomCoolingDemand:          newMetricWavinsentioRoomCoolingDemand(mbc.Metrics.WavinsentioRoomCoolingDemand),
+		metricWavinsentioRoomCoolingDuration:        newMetricWavinsentioRoomCoolingDuration(mbc.Metrics.WavinsentioRoomCoolingDuration),
+		metricWavinsentioRoomDehumidifierState:      newMetricWavinsentioRoomDehumidifierState(mbc.Metrics.WavinsentioRoomDehumidifierState),
+		metricWavinsentioRoomHeatingCycles:          newMetricWavinsentioRoomHeatingCycles(mbc.Metrics.WavinsentioRoomHeatingCycles),
+		metricWavinsentioRoomHeatingDemand:          newMetricWavinsentioRoomHeatingDemand(mbc.Metrics.WavinsentioRoomHeatingDemand),
+		metricWavinsentioRoomHeatingDuration:        newMetricWavinsentioRoomHeatingDuration(mbc.Metrics.WavinsentioRoomHeatingDuration),
+		metricWavinsentioRoomHumidity:               newMetricWavinsentioRoomHumidity(mbc.Metrics.WavinsentioRoomHumidity),
+		metricWavinsentioRoomHumiditySetpoint:       newMetricWavinsentioRoomHumiditySetpoint(mbc.Metrics.WavinsentioRoomHumiditySetpoint),
+		metricWavinsentioRoomInfo:                   newMetricWavinsentioRoomInfo(mbc.Metrics.WavinsentioRoomInfo),
+		metricWavinsentioRoomLockMode:               newMetricWavinsentioRoomLockMode(mbc.Metrics.WavinsentioRoomLockMode),
+		metricWavinsentioRoomTemperatureAir:         newMetricWavinsentioRoomTemperatureAir(mbc.Metrics.WavinsentioRoomTemperatureAir),
+		metricWavinsentioRoomTemperatureDewPoint:    newMetricWavinsentioRoomTemperatureDewPoint(mbc.Metrics.WavinsentioRoomTemperatureDewPoint),
+		metricWavinsentioRoomTemperatureSetpoint:    newMetricWavinsentioRoomTemperatureSetpoint(mbc.Metrics.WavinsentioRoomTemperatureSetpoint),
+		metricWavinsentioRoomTemperatureSetpointMax: newMetricWavinsentioRoomTemperatureSetpointMax(mbc.Metrics.WavinsentioRoomTemperatureSetpointMax),
+		metricWavinsentioRoomTemperatureSetpointMin: newMetricWavinsentioRoomTemperatureSetpointMin(mbc.Metrics.WavinsentioRoomTemperatureSetpointMin),
+		metricWavinsentioRoomTemperatureState:       newMetricWavinsentioRoomTemperatureState(mbc.Metrics.WavinsentioRoomTemperatureState),
+		metricWavinsentioRoomVacationMode:           newMetricWavinsentioRoomVacationMode(mbc.Metrics.WavinsentioRoomVacationMode),
+		resourceAttributeIncludeFilter:              make(map[string]filter.Filter),
+		resourceAttributeExcludeFilter:              make(map[string]filter.Filter),
+	}
+	if mbc.ResourceAttributes.WavinsentioDeviceName.MetricsInclude != nil {
+		mb.resourceAttributeIncludeFilter["wavinsentio.device.name"] = filter.CreateFilter(mbc.ResourceAttributes.WavinsentioDeviceName.MetricsInclude)
+	}
+	if mbc.ResourceAttributes.WavinsentioDeviceName.MetricsExclude != nil {
+		mb.resourceAttributeExcludeFilter["wavinsentio.device.name"] = filter.CreateFilter(mbc.ResourceAttributes.WavinsentioDeviceName.MetricsExclude)
+	}
+	if mbc.ResourceAttributes.WavinsentioDeviceSerialNumber.MetricsInclude != nil {
+		mb.resourceAttributeIncludeFilter["wavinsentio.device.serial_number"] = filter.CreateFilter(mbc.ResourceAttributes.WavinsentioDeviceSerialNumber.MetricsInclude)
+	}
+	if mbc.ResourceAttributes.WavinsentioDeviceSerialNumber.MetricsExclude != nil {
+		mb.resourceAttributeExcludeFilter["wavinsentio.device.serial_number"] = filter.CreateFilter(mbc.ResourceAttributes.WavinsentioDeviceSerialNumber.MetricsExclude)
+	}
+	if mbc.ResourceAttributes.WavinsentioDeviceType.MetricsInclude != nil {
+		mb.resourceAttributeIncludeFilter["wavinsentio.device.type"] = filter.CreateFilter(mbc.ResourceAttributes.WavinsentioDeviceType.MetricsInclude)
+	}
+	if mbc.ResourceAttributes.WavinsentioDeviceType.MetricsExclude != nil {
+		mb.resourceAttributeExcludeFilter["wavinsentio.device.type"] = filter.CreateFilter(mbc.ResourceAttributes.WavinsentioDeviceType.MetricsExclude)
+	}
+	if mbc.ResourceAttributes.WavinsentioRoomID.MetricsInclude != nil {
+		mb.resourceAttributeIncludeFilter["wavinsentio.room.id"] = filter.CreateFilter(mbc.ResourceAttributes.WavinsentioRoomID.MetricsInclude)
+	}
+	if mbc.ResourceAttributes.WavinsentioRoomID.MetricsExclude != nil {
+		mb.resourceAttributeExcludeFilter["wavinsentio.room.id"] = filter.CreateFilter(mbc.ResourceAttributes.WavinsentioRoomID.MetricsExclude)
+	}
+
+	for _, op := range options {
+		op.apply(mb)
+	}
+	return mb
+}
+
+// NewResourceBuilder returns a new resource builder that should be used to build a resource associated with for the emitted metrics.
+func (mb *MetricsBuilder) NewResourceBuilder() *ResourceBuilder {
+	return NewResourceBuilder(mb.config.ResourceAttributes)
+}
+
+// updateCapacity updates max length of metrics and resource attributes that will be used for the slice capacity.
+func (mb *MetricsBuilder) updateCapacity(rm pmetric.ResourceMetrics) {
+	if mb.metricsCapacity < rm.ScopeMetrics().At(0).Metrics().Len() {
+		mb.metricsCapacity = rm.ScopeMetrics().At(0).Metrics().Len()
+	}
+}
+
+// ResourceMetricsOption applies changes to provided resource metrics.
+type ResourceMetricsOption interface {
+	apply(pmetric.ResourceMetrics)
+}
+
+type resourceMetricsOptionFunc func(pmetric.ResourceMetrics)
+
+func (rmof resourceMetricsOptionFunc) apply(rm pmetric.ResourceMetrics) {
+	rmof(rm)
+}
+
+// WithResource sets the provided resource on the emitted ResourceMetrics.
+// It's recommended to use ResourceBuilder to create the resource.
+func WithResource(res pcommon.Resource) ResourceMetricsOption {
+	return resourceMetricsOptionFunc(func(rm pmetric.ResourceMetrics) {
+		res.CopyTo(rm.Resource())
+	})
+}
+
+// WithStartTimeOverride overrides start time for all the resource metrics data points.
+// This option should be only used if different start time has to be set on metrics coming from different resources.
+func WithStartTimeOverride(start pcommon.Timestamp) ResourceMetricsOption {
+	return resourceMetricsOptionFunc(func(rm pmetric.ResourceMetrics) {
+		var dps pmetric.NumberDataPointSlice
+		metrics := rm.ScopeMetrics().At(0).Metrics()
+		for i := 0; i < metrics.Len(); i++ {
+			switch metrics.At(i).Type() {
+			case pmetric.MetricTypeGauge:
+				dps = metrics.At(i).Gauge().DataPoints()
+			case pmetric.MetricTypeSum:
+				dps = metrics.At(i).Sum().DataPoints()
+			}
+			for j := 0; j < dps.Len(); j++ {
+				dps.At(j).SetStartTimestamp(start)
+			}
+		}
+	})
+}
+
+// EmitForResource saves all the generated metrics under a new resource and updates the internal state to be ready for
+// recording another set of data points as part of another resource. This function can be helpful when one scraper
+// needs to emit metrics from several resources. Otherwise calling this function is not required,
+// just `Emit` function can be called instead.
+// Resource attributes should be provided as ResourceMetricsOption arguments.
+func (mb *MetricsBuilder) EmitForResource(options ...ResourceMetricsOption) {
+	rm := pmetric.NewResourceMetrics()
+	ils := rm.ScopeMetrics().AppendEmpty()
+	ils.Scope().SetName(ScopeName)
+	ils.Scope().SetVersion(mb.buildInfo.Version)
+	ils.Metrics().EnsureCapacity(mb.metricsCapacity)
+	mb.metricWavinsentioDeviceFirmware.emit(ils.Metrics())
+	mb.metricWavinsentioDeviceHcMode.emit(ils.Metrics())
+	mb.metricWavinsentioDeviceLastHeartbeat.emit(ils.Metrics())
+	mb.metricWavinsentioDeviceOutdoorTemperature.emit(ils.Metrics())
+	mb.metricWavinsentioDeviceQuietMode.emit(ils.Metrics())
+	mb.metricWavinsentioDeviceStandbyMode.emit(ils.Metrics())
+	mb.metricWavinsentioDeviceVacationMode.emit(ils.Metrics())
+	mb.metricWavinsentioRoomCoolingCycles.emit(ils.Metrics())
+	mb.metricWavinsentioRoomCoolingDemand.emit(ils.Metrics())
+	mb.metricWavinsentioRoomCoolingDuration.emit(ils.Metrics())
+	mb.metricWavinsentioRoomDehumidifierState.emit(ils.Metrics())
+	mb.metricWavinsentioRoomHeatingCycles.emit(ils.Metrics())
+	mb.metricWavinsentioRoomHeatingDemand.emit(ils.Metrics())
+	mb.metricWavinsentioRoomHeatingDuration.emit(ils.Metrics())
+	mb.metricWavinsentioRoomHumidity.emit(ils.Metrics())
+	mb.metricWavinsentioRoomHumiditySetpoint.emit(ils.Metrics())
+	mb.metricWavinsentioRoomInfo.emit(ils.Metrics())
+	mb.metricWavinsentioRoomLockMode.emit(ils.Metrics())
+	mb.metricWavinsentioRoomTemperatureAir.emit(ils.Metrics())
+	mb.metricWavinsentioRoomTemperatureDewPoint.emit(ils.Metrics())
+	mb.metricWavinsentioRoomTemperatureSetpoint.emit(ils.Metrics())
+	mb.metricWavinsentioRoomTemperatureSetpointMax.emit(ils.Metrics())
+	mb.metricWavinsentioRoomTemperatureSetpointMin.emit(ils.Metrics())
+	mb.metricWavinsentioRoomTemperatureState.emit(ils.Metrics())
+	mb.metricWavinsentioRoomVacationMode.emit(ils.Metrics())
+
+	for _, op := range options {
+		op.apply(rm)
+	}
+	for attr, filter := range mb.resourceAttributeIncludeFilter {
+		if val, ok := rm.Resource().Attributes().Get(attr); ok && !filter.Matches(val.AsString()) {
+			return
+		}
+	}
+	for attr, filter := range mb.resourceAttributeExcludeFilter {
+		if val, ok := rm.Resource().Attributes().Get(attr); ok && filter.Matches(val.AsString()) {
+			return
+		}
+	}
+
+	if ils.Metrics().Len() > 0 {
+		mb.updateCapacity(rm)
+		rm.MoveTo(mb.metricsBuffer.ResourceMetrics().AppendEmpty())
+	}
+}
+
+// Emit returns all the metrics accumulated by the metrics builder and updates the internal state to be ready for
+// recording another set of metrics. This function will be responsible for applying all the transformations required to
+// produce metric representation defined in metadata and user config, e.g. delta or cumulative.
+func (mb *MetricsBuilder) Emit(options ...ResourceMetricsOption) pmetric.Metrics {
+	mb.EmitForResource(options...)
+	metrics := mb.metricsBuffer
+	mb.metricsBuffer = pmetric.NewMetrics()
+	return metrics
+}
+
+// RecordWavinsentioDeviceFirmwareDataPoint adds a data point to wavinsentio.device.firmware metric.
+func (mb *MetricsBuilder) RecordWavinsentioDeviceFirmwareDataPoint(ts pcommon.Timestamp, val int64, firmwareInstalledAttributeValue string, firmwareAvailableAttributeValue string) {
+	mb.metricWavinsentioDeviceFirmware.recordDataPoint(mb.startTime, ts, val, firmwareInstalledAttributeValue, firmwareAvailableAttributeValue)
+}
+
+// RecordWavinsentioDeviceHcModeDataPoint adds a data point to wavinsentio.device.hc_mode metric.
+func (mb *MetricsBuilder) RecordWavinsentioDeviceHcModeDataPoint(ts pcommon.Timestamp, val int64, stateAttributeValue string) {
+	mb.metricWavinsentioDeviceHcMode.recordDataPoint(mb.startTime, ts, val, stateAttributeValue)
+}
+
+// RecordWavinsentioDeviceLastHeartbeatDataPoint adds a data point to wavinsentio.device.last_heartbeat metric.
+func (mb *MetricsBuilder) RecordWavinsentioDeviceLastHeartbeatDataPoint(ts pcommon.Timestamp, val int64) {
+	mb.metricWavinsentioDeviceLastHeartbeat.recordDataPoint(mb.startTime, ts, val)
+}
+
+// RecordWavinsentioDeviceOutdoorTemperatureDataPoint adds a data point to wavinsentio.device.outdoor_temperature metric.
+func (mb *MetricsBuilder) RecordWavinsentioDeviceOutdoorTemperatureDataPoint(ts pcommon.Timestamp, val float64, sensorIDAttributeValue string) {
+	mb.metricWavinsentioDeviceOutdoorTemperature.recordDataPoint(mb.startTime, ts, val, sensorIDAttributeValue)
+}
+
+// RecordWavinsentioDeviceQuietModeDataPoint adds a data point to wavinsentio.device.quiet_mode metric.
+func (mb *MetricsBuilder) RecordWavinsentioDeviceQuietModeDataPoint(ts pcommon.Timestamp, val int64, stateAttributeValue string) {
+	mb.metricWavinsentioDeviceQuietMode.recordDataPoint(mb.startTime, ts, val, stateAttributeValue)
+}
+
+// RecordWavinsentioDeviceStandbyModeDataPoint adds a data point to wavinsentio.device.standby_mode metric.
+func (mb *MetricsBuilder) RecordWavinsentioDeviceStandbyModeDataPoint(ts pcommon.Timestamp, val int64, stateAttributeValue string) {
+	mb.metricWavinsentioDeviceStandbyMode.recordDataPoint(mb.startTime, ts, val, stateAttributeValue)
+}
+
+// RecordWavinsentioDeviceVacationModeDataPoint adds a data point to wavinsentio.device.vacation_mode metric.
+func (mb *MetricsBuilder) RecordWavinsentioDeviceVacationModeDataPoint(ts pcommon.Timestamp, val int64, stateAttributeValue string) {
+	mb.metricWavinsentioDeviceVacationMode.recordDataPoint(mb.startTime, ts, val, stateAttributeValue)
+}
+
+// RecordWavinsentioRoomCoolingCyclesDataPoint adds a data point to wavinsentio.room.cooling.cycles metric.
+func (mb *MetricsBuilder) RecordWavinsentioRoomCoolingCyclesDataPoint(ts pcommon.Timestamp, val int64) {
+	mb.metricWavinsentioRoomCoolingCycles.recordDataPoint(mb.startTime, ts, val)
+}
+
+// RecordWavinsentioRoomCoolingDemandDataPoint adds a data point to wavinsentio.room.cooling.demand metric.
+func (mb *MetricsBuilder) RecordWavinsentioRoomCoolingDemandDataPoint(ts pcommon.Timestamp, val int64) {
+	mb.metricWavinsentioRoomCoolingDemand.recordDataPoint(mb.startTime, ts, val)
+}
+
+// RecordWavinsentioRoomCoolingDurationDataPoint adds a data point to wavinsentio.room.cooling.duration metric.
+func (mb *MetricsBuilder) RecordWavinsentioRoomCoolingDurationDataPoint(ts pcommon.Timestamp, val float64) {
+	mb.metricWavinsentioRoomCoolingDuration.recordDataPoint(mb.startTime, ts, val)
+}
+
+// RecordWavinsentioRoomDehumidifierStateDataPoint adds a data point to wavinsentio.room.dehumidifier.state metric.
+func (mb *MetricsBuilder) RecordWavinsentioRoomDehumidifierStateDataPoint(ts pcommon.Timestamp, val int64, stateAttributeValue string) {
+	mb.metricWavinsentioRoomDehumidifierState.recordDataPoint(mb.startTime, ts, val, stateAttributeValue)
+}
+
+// RecordWavinsentioRoomHeatingCyclesDataPoint adds a data point to wavinsentio.room.heating.cycles metric.
+func (mb *MetricsBuilder) RecordWavinsentioRoomHeatingCyclesDataPoint(ts pcommon.Timestamp, val int64) {
+	mb.metricWavinsentioRoomHeatingCycles.recordDataPoint(mb.startTime, ts, val)
+}
+
+// RecordWavinsentioRoomHeatingDemandDataPoint adds a data point to wavinsentio.room.heating.demand metric.
+func (mb *MetricsBuilder) RecordWavinsentioRoomHeatingDemandDataPoint(ts pcommon.Timestamp, val int64) {
+	mb.metricWavinsentioRoomHeatingDemand.recordDataPoint(mb.startTime, ts, val)
+}
+
+// RecordWavinsentioRoomHeatingDurationDataPoint adds a data point to wavinsentio.room.heating.duration metric.
+func (mb *MetricsBuilder) RecordWavinsentioRoomHeatingDurationDataPoint(ts pcommon.Timestamp, val float64) {
+	mb.metricWavinsentioRoomHeatingDuration.recordDataPoint(mb.startTime, ts, val)
+}
+
+// RecordWavinsentioRoomHumidityDataPoint adds a data point to wavinsentio.room.humidity metric.
+func (mb *MetricsBuilder) RecordWavinsentioRoomHumidityDataPoint(ts pcommon.Timestamp, val float64) {
+	mb.metricWavinsentioRoomHumidity.recordDataPoint(mb.startTime, ts, val)
+}
+
+// RecordWavinsentioRoomHumiditySetpointDataPoint adds a data point to wavinsentio.room.humidity.setpoint metric.
+func (mb *MetricsBuilder) RecordWavinsentioRoomHumiditySetpointDataPoint(ts pcommon.Timestamp, val float64, hcModeAttributeValue string) {
+	mb.metricWavinsentioRoomHumiditySetpoint.recordDataPoint(mb.startTime, ts, val, hcModeAttributeValue)
+}
+
+// RecordWavinsentioRoomInfoDataPoint adds a data point to wavinsentio.room.info metric.
+func (mb *MetricsBuilder) RecordWavinsentioRoomInfoDataPoint(ts pcommon.Timestamp, val int64, roomTitleAttributeValue string) {
+	mb.metricWavinsentioRoomInfo.recordDataPoint(mb.startTime, ts, val, roomTitleAttributeValue)
+}
+
+// RecordWavinsentioRoomLockModeDataPoint adds a data point to wavinsentio.room.lock_mode metric.
+func (mb *MetricsBuilder) RecordWavinsentioRoomLockModeDataPoint(ts pcommon.Timestamp, val int64, stateAttributeValue string) {
+	mb.metricWavinsentioRoomLockMode.recordDataPoint(mb.startTime, ts, val, stateAttributeValue)
+}
+
+// RecordWavinsentioRoomTemperatureAirDataPoint adds a data point to wavinsentio.room.temperature.air metric.
+func (mb *MetricsBuilder) RecordWavinsentioRoomTemperatureAirDataPoint(ts pcommon.Timestamp, val float64) {
+	mb.metricWavinsentioRoomTemperatureAir.recordDataPoint(mb.startTime, ts, val)
+}
+
+// RecordWavinsentioRoomTemperatureDewPointDataPoint adds a data point to wavinsentio.room.temperature.dew_point metric.
+func (mb *MetricsBuilder) RecordWavinsentioRoomTemperatureDewPointDataPoint(ts pcommon.Timestamp, val float64) {
+	mb.metricWavinsentioRoomTemperatureDewPoint.recordDataPoint(mb.startTime, ts, val)
+}
+
+// RecordWavinsentioRoomTemperatureSetpointDataPoint adds a data point to wavinsentio.room.temperature.setpoint metric.
+func (mb *MetricsBuilder) RecordWavinsentioRoomTemperatureSetpointDataPoint(ts pcommon.Timestamp, val float64) {
+	mb.metricWavinsentioRoomTemperatureSetpoint.recordDataPoint(mb.startTime, ts, val)
+}
+
+// RecordWavinsentioRoomTemperatureSetpointMaxDataPoint adds a data point to wavinsentio.room.temperature.setpoint.max metric.
+func (mb *MetricsBuilder) RecordWavinsentioRoomTemperatureSetpointMaxDataPoint(ts pcommon.Timestamp, val float64) {
+	mb.metricWavinsentioRoomTemperatureSetpointMax.recordDataPoint(mb.startTime, ts, val)
+}
+
+// RecordWavinsentioRoomTemperatureSetpointMinDataPoint adds a data point to wavinsentio.room.temperature.setpoint.min metric.
+func (mb *MetricsBuilder) RecordWavinsentioRoomTemperatureSetpointMinDataPoint(ts pcommon.Timestamp, val float64) {
+	mb.metricWavinsentioRoomTemperatureSetpointMin.recordDataPoint(mb.startTime, ts, val)
+}
+
+// RecordWavinsentioRoomTemperatureStateDataPoint adds a data point to wavinsentio.room.temperature_state metric.
+func (mb *MetricsBuilder) RecordWavinsentioRoomTemperatureStateDataPoint(ts pcommon.Timestamp, val int64, stateAttributeValue string) {
+	mb.metricWavinsentioRoomTemperatureState.recordDataPoint(mb.startTime, ts, val, stateAttributeValue)
+}
+
+// RecordWavinsentioRoomVacationModeDataPoint adds a data point to wavinsentio.room.vacation_mode metric.
+func (mb *MetricsBuilder) RecordWavinsentioRoomVacationModeDataPoint(ts pcommon.Timestamp, val int64, stateAttributeValue string) {
+	mb.metricWavinsentioRoomVacationMode.recordDataPoint(mb.startTime, ts, val, stateAttributeValue)
+}
+
+// Reset resets metrics builder to its initial state. It should be used when external metrics source is restarted,
+// and metrics builder should update its startTime and reset it's internal state accordingly.
+func (mb *MetricsBuilder) Reset(options ...MetricBuilderOption) {
+	mb.startTime = pcommon.NewTimestampFromTime(time.Now())
+	for _, op := range options {
+		op.apply(mb)
+	}
+}
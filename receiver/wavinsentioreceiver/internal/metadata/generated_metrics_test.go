@@ -0,0 +1,549 @@
+// Code generated by mdatagen. DO NOT EDIT.
+
+package metadata
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+type testDataSet int
+
+const (
+	testDataSetDefault testDataSet = iota
+	testDataSetAll
+	testDataSetNone
+)
+
+func TestMetricsBuilder(t *testing.T) {
+	tests := []struct {
+		name        string
+		metricsSet  testDataSet
+		resAttrsSet testDataSet
+		expectEmpty bool
+	}{
+		{
+			name: "default",
+		},
+		{
+			name:        "all_set",
+			metricsSet:  testDataSetAll,
+			resAttrsSet: testDataSetAll,
+		},
+		{
+			name:        "none_set",
+			metricsSet:  testDataSetNone,
+			resAttrsSet: testDataSetNone,
+			expectEmpty: true,
+		},
+		{
+			name:        "filter_set_include",
+			resAttrsSet: testDataSetAll,
+		},
+		{
+			name:        "filter_set_exclude",
+			resAttrsSet: testDataSetAll,
+			expectEmpty: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start := pcommon.Timestamp(1_000_000_000)
+			ts := pcommon.Timestamp(1_000_001_000)
+			observedZapCore, observedLogs := observer.New(zap.WarnLevel)
+			settings := receivertest.NewNopSettings(receivertest.NopType)
+			settings.Logger = zap.New(observedZapCore)
+			mb := NewMetricsBuilder(loadMetricsBuilderConfig(t, tt.name), settings, WithStartTime(start))
+
+			expectedWarnings := 0
+
+			assert.Equal(t, expectedWarnings, observedLogs.Len())
+
+			defaultMetricsCount := 0
+			allMetricsCount := 0
+
+			defaultMetricsCount++
+			allMetricsCount++
+			mb.RecordWavinsentioDeviceFirmwareDataPoint(ts, 1, "firmware.installed-val", "firmware.available-val")
+
+			defaultMetricsCount++
+			allMetricsCount++
+			mb.RecordWavinsentioDeviceHcModeDataPoint(ts, 1, "state-val")
+
+			defaultMetricsCount++
+			allMetricsCount++
+			mb.RecordWavinsentioDeviceLastHeartbeatDataPoint(ts, 1)
+
+			defaultMetricsCount++
+			allMetricsCount++
+			mb.RecordWavinsentioDeviceOutdoorTemperatureDataPoint(ts, 1, "sensor.id-val")
+
+			defaultMetricsCount++
+			allMetricsCount++
+			mb.RecordWavinsentioDeviceQuietModeDataPoint(ts, 1, "state-val")
+
+			defaultMetricsCount++
+			allMetricsCount++
+			mb.RecordWavinsentioDeviceStandbyModeDataPoint(ts, 1, "state-val")
+
+			defaultMetricsCount++
+			allMetricsCount++
+			mb.RecordWavinsentioDeviceVacationModeDataPoint(ts, 1, "state-val")
+
+			defaultMetricsCount++
+			allMetricsCount++
+			mb.RecordWavinsentioRoomCoolingCyclesDataPoint(ts, 1)
+
+			defaultMetricsCount++
+			allMetricsCount++
+			mb.RecordWavinsentioRoomCoolingDemandDataPoint(ts, 1)
+
+			defaultMetricsCount++
+			allMetricsCount++
+			mb.RecordWavinsentioRoomCoolingDurationDataPoint(ts, 1)
+
+			defaultMetricsCount++
+			allMetricsCount++
+			mb.RecordWavinsentioRoomDehumidifierStateDataPoint(ts, 1, "state-val")
+
+			defaultMetricsCount++
+			allMetricsCount++
+			mb.RecordWavinsentioRoomHeatingCyclesDataPoint(ts, 1)
+
+			defaultMetricsCount++
+			allMetricsCount++
+			mb.RecordWavinsentioRoomHeatingDemandDataPoint(ts, 1)
+
+			defaultMetricsCount++
+			allMetricsCount++
+			mb.RecordWavinsentioRoomHeatingDurationDataPoint(ts, 1)
+
+			defaultMetricsCount++
+			allMetricsCount++
+			mb.RecordWavinsentioRoomHumidityDataPoint(ts, 1)
+
+			defaultMetricsCount++
+			allMetricsCount++
+			mb.RecordWavinsentioRoomHumiditySetpointDataPoint(ts, 1, "hc_mode-val")
+
+			defaultMetricsCount++
+			allMetricsCount++
+			mb.RecordWavinsentioRoomInfoDataPoint(ts, 1, "room.title-val")
+
+			defaultMetricsCount++
+			allMetricsCount++
+			mb.RecordWavinsentioRoomLockModeDataPoint(ts, 1, "state-val")
+
+			defaultMetricsCount++
+			allMetricsCount++
+			mb.RecordWavinsentioRoomTemperatureAirDataPoint(ts, 1)
+
+			defaultMetricsCount++
+			allMetricsCount++
+			mb.RecordWavinsentioRoomTemperatureDewPointDataPoint(ts, 1)
+
+			defaultMetricsCount++
+			allMetricsCount++
+			mb.RecordWavinsentioRoomTemperatureSetpointDataPoint(ts, 1)
+
+			defaultMetricsCount++
+			allMetricsCount++
+			mb.RecordWavinsentioRoomTemperatureSetpointMaxDataPoint(ts, 1)
+
+			defaultMetricsCount++
+			allMetricsCount++
+			mb.RecordWavinsentioRoomTemperatureSetpointMinDataPoint(ts, 1)
+
+			defaultMetricsCount++
+			allMetricsCount++
+			mb.RecordWavinsentioRoomTemperatureStateDataPoint(ts, 1, "state-val")
+
+			defaultMetricsCount++
+			allMetricsCount++
+			mb.RecordWavinsentioRoomVacationModeDataPoint(ts, 1, "state-val")
+
+			rb := mb.NewResourceBuilder()
+			rb.SetWavinsentioDeviceName("wavinsentio.device.name-val")
+			rb.SetWavinsentioDeviceSerialNumber("wavinsentio.device.serial_number-val")
+			rb.SetWavinsentioDeviceType("wavinsentio.device.type-val")
+			rb.SetWavinsentioRoomID("wavinsentio.room.id-val")
+			res := rb.Emit()
+			metrics := mb.Emit(WithResource(res))
+
+			if tt.expectEmpty {
+				assert.Equal(t, 0, metrics.ResourceMetrics().Len())
+				return
+			}
+
+			assert.Equal(t, 1, metrics.ResourceMetrics().Len())
+			rm := metrics.ResourceMetrics().At(0)
+			assert.Equal(t, res, rm.Resource())
+			assert.Equal(t, 1, rm.ScopeMetrics().Len())
+			ms := rm.ScopeMetrics().At(0).Metrics()
+			if tt.metricsSet == testDataSetDefault {
+				assert.Equal(t, defaultMetricsCount, ms.Len())
+			}
+			if tt.metricsSet == testDataSetAll {
+				assert.Equal(t, allMetricsCount, ms.Len())
+			}
+			validatedMetrics := make(map[string]bool)
+			for i := 0; i < ms.Len(); i++ {
+				switch ms.At(i).Name() {
+				case "wavinsentio.device.firmware":
+					assert.False(t, validatedMetrics["wavinsentio.device.firmware"], "Found a duplicate in the metrics slice: wavinsentio.device.firmware")
+					validatedMetrics["wavinsentio.device.firmware"] = true
+					assert.Equal(t, pmetric.MetricTypeGauge, ms.At(i).Type())
+					assert.Equal(t, 1, ms.At(i).Gauge().DataPoints().Len())
+					assert.Equal(t, "Firmware versions of the device, as a gauge set to 1.", ms.At(i).Description())
+					assert.Equal(t, "1", ms.At(i).Unit())
+					dp := ms.At(i).Gauge().DataPoints().At(0)
+					assert.Equal(t, start, dp.StartTimestamp())
+					assert.Equal(t, ts, dp.Timestamp())
+					assert.Equal(t, pmetric.NumberDataPointValueTypeInt, dp.ValueType())
+					assert.Equal(t, int64(1), dp.IntValue())
+					attrVal, ok := dp.Attributes().Get("wavinsentio.firmware.installed")
+					assert.True(t, ok)
+					assert.Equal(t, "firmware.installed-val", attrVal.Str())
+					attrVal, ok = dp.Attributes().Get("wavinsentio.firmware.available")
+					assert.True(t, ok)
+					assert.Equal(t, "firmware.available-val", attrVal.Str())
+				case "wavinsentio.device.hc_mode":
+					assert.False(t, validatedMetrics["wavinsentio.device.hc_mode"], "Found a duplicate in the metrics slice: wavinsentio.device.hc_mode")
+					validatedMetrics["wavinsentio.device.hc_mode"] = true
+					assert.Equal(t, pmetric.MetricTypeGauge, ms.At(i).Type())
+					assert.Equal(t, 1, ms.At(i).Gauge().DataPoints().Len())
+					assert.Equal(t, "Heating/cooling mode of the device, as a state-set gauge.", ms.At(i).Description())
+					assert.Equal(t, "1", ms.At(i).Unit())
+					dp := ms.At(i).Gauge().DataPoints().At(0)
+					assert.Equal(t, start, dp.StartTimestamp())
+					assert.Equal(t, ts, dp.Timestamp())
+					assert.Equal(t, pmetric.NumberDataPointValueTypeInt, dp.ValueType())
+					assert.Equal(t, int64(1), dp.IntValue())
+					attrVal, ok := dp.Attributes().Get("state")
+					assert.True(t, ok)
+					assert.Equal(t, "state-val", attrVal.Str())
+				case "wavinsentio.device.last_heartbeat":
+					assert.False(t, validatedMetrics["wavinsentio.device.last_heartbeat"], "Found a duplicate in the metrics slice: wavinsentio.device.last_heartbeat")
+					validatedMetrics["wavinsentio.device.last_heartbeat"] = true
+					assert.Equal(t, pmetric.MetricTypeGauge, ms.At(i).Type())
+					assert.Equal(t, 1, ms.At(i).Gauge().DataPoints().Len())
+					assert.Equal(t, "Device last heartbeat timestamp.", ms.At(i).Description())
+					assert.Equal(t, "ns", ms.At(i).Unit())
+					dp := ms.At(i).Gauge().DataPoints().At(0)
+					assert.Equal(t, start, dp.StartTimestamp())
+					assert.Equal(t, ts, dp.Timestamp())
+					assert.Equal(t, pmetric.NumberDataPointValueTypeInt, dp.ValueType())
+					assert.Equal(t, int64(1), dp.IntValue())
+				case "wavinsentio.device.outdoor_temperature":
+					assert.False(t, validatedMetrics["wavinsentio.device.outdoor_temperature"], "Found a duplicate in the metrics slice: wavinsentio.device.outdoor_temperature")
+					validatedMetrics["wavinsentio.device.outdoor_temperature"] = true
+					assert.Equal(t, pmetric.MetricTypeGauge, ms.At(i).Type())
+					assert.Equal(t, 1, ms.At(i).Gauge().DataPoints().Len())
+					assert.Equal(t, "Outdoor temperature from sensor.", ms.At(i).Description())
+					assert.Equal(t, "°C", ms.At(i).Unit())
+					dp := ms.At(i).Gauge().DataPoints().At(0)
+					assert.Equal(t, start, dp.StartTimestamp())
+					assert.Equal(t, ts, dp.Timestamp())
+					assert.Equal(t, pmetric.NumberDataPointValueTypeDouble, dp.ValueType())
+					assert.InDelta(t, float64(1), dp.DoubleValue(), 0.01)
+					attrVal, ok := dp.Attributes().Get("wavinsentio.sensor.id")
+					assert.True(t, ok)
+					assert.Equal(t, "sensor.id-val", attrVal.Str())
+				case "wavinsentio.device.quiet_mode":
+					assert.False(t, validatedMetrics["wavinsentio.device.quiet_mode"], "Found a duplicate in the metrics slice: wavinsentio.device.quiet_mode")
+					validatedMetrics["wavinsentio.device.quiet_mode"] = true
+					assert.Equal(t, pmetric.MetricTypeGauge, ms.At(i).Type())
+					assert.Equal(t, 1, ms.At(i).Gauge().DataPoints().Len())
+					assert.Equal(t, "Quiet mode of the device, as a state-set gauge.", ms.At(i).Description())
+					assert.Equal(t, "1", ms.At(i).Unit())
+					dp := ms.At(i).Gauge().DataPoints().At(0)
+					assert.Equal(t, start, dp.StartTimestamp())
+					assert.Equal(t, ts, dp.Timestamp())
+					assert.Equal(t, pmetric.NumberDataPointValueTypeInt, dp.ValueType())
+					assert.Equal(t, int64(1), dp.IntValue())
+					attrVal, ok := dp.Attributes().Get("state")
+					assert.True(t, ok)
+					assert.Equal(t, "state-val", attrVal.Str())
+				case "wavinsentio.device.standby_mode":
+					assert.False(t, validatedMetrics["wavinsentio.device.standby_mode"], "Found a duplicate in the metrics slice: wavinsentio.device.standby_mode")
+					validatedMetrics["wavinsentio.device.standby_mode"] = true
+					assert.Equal(t, pmetric.MetricTypeGauge, ms.At(i).Type())
+					assert.Equal(t, 1, ms.At(i).Gauge().DataPoints().Len())
+					assert.Equal(t, "Standby mode of the device, as a state-set gauge.", ms.At(i).Description())
+					assert.Equal(t, "1", ms.At(i).Unit())
+					dp := ms.At(i).Gauge().DataPoints().At(0)
+					assert.Equal(t, start, dp.StartTimestamp())
+					assert.Equal(t, ts, dp.Timestamp())
+					assert.Equal(t, pmetric.NumberDataPointValueTypeInt, dp.ValueType())
+					assert.Equal(t, int64(1), dp.IntValue())
+					attrVal, ok := dp.Attributes().Get("state")
+					assert.True(t, ok)
+					assert.Equal(t, "state-val", attrVal.Str())
+				case "wavinsentio.device.vacation_mode":
+					assert.False(t, validatedMetrics["wavinsentio.device.vacation_mode"], "Found a duplicate in the metrics slice: wavinsentio.device.vacation_mode")
+					validatedMetrics["wavinsentio.device.vacation_mode"] = true
+					assert.Equal(t, pmetric.MetricTypeGauge, ms.At(i).Type())
+					assert.Equal(t, 1, ms.At(i).Gauge().DataPoints().Len())
+					assert.Equal(t, "Vacation mode of the device, as a state-set gauge.", ms.At(i).Description())
+					assert.Equal(t, "1", ms.At(i).Unit())
+					dp := ms.At(i).Gauge().DataPoints().At(0)
+					assert.Equal(t, start, dp.StartTimestamp())
+					assert.Equal(t, ts, dp.Timestamp())
+					assert.Equal(t, pmetric.NumberDataPointValueTypeInt, dp.ValueType())
+					assert.Equal(t, int64(1), dp.IntValue())
+					attrVal, ok := dp.Attributes().Get("state")
+					assert.True(t, ok)
+					assert.Equal(t, "state-val", attrVal.Str())
+				case "wavinsentio.room.cooling.cycles":
+					assert.False(t, validatedMetrics["wavinsentio.room.cooling.cycles"], "Found a duplicate in the metrics slice: wavinsentio.room.cooling.cycles")
+					validatedMetrics["wavinsentio.room.cooling.cycles"] = true
+					assert.Equal(t, pmetric.MetricTypeSum, ms.At(i).Type())
+					assert.Equal(t, 1, ms.At(i).Sum().DataPoints().Len())
+					assert.Equal(t, "Number of times the room started cooling.", ms.At(i).Description())
+					assert.Equal(t, "{cycle}", ms.At(i).Unit())
+					assert.True(t, ms.At(i).Sum().IsMonotonic())
+					assert.Equal(t, pmetric.AggregationTemporalityCumulative, ms.At(i).Sum().AggregationTemporality())
+					dp := ms.At(i).Sum().DataPoints().At(0)
+					assert.Equal(t, start, dp.StartTimestamp())
+					assert.Equal(t, ts, dp.Timestamp())
+					assert.Equal(t, pmetric.NumberDataPointValueTypeInt, dp.ValueType())
+					assert.Equal(t, int64(1), dp.IntValue())
+				case "wavinsentio.room.cooling.demand":
+					assert.False(t, validatedMetrics["wavinsentio.room.cooling.demand"], "Found a duplicate in the metrics slice: wavinsentio.room.cooling.demand")
+					validatedMetrics["wavinsentio.room.cooling.demand"] = true
+					assert.Equal(t, pmetric.MetricTypeGauge, ms.At(i).Type())
+					assert.Equal(t, 1, ms.At(i).Gauge().DataPoints().Len())
+					assert.Equal(t, "Whether the room is calling for cooling (1) or not (0).", ms.At(i).Description())
+					assert.Equal(t, "1", ms.At(i).Unit())
+					dp := ms.At(i).Gauge().DataPoints().At(0)
+					assert.Equal(t, start, dp.StartTimestamp())
+					assert.Equal(t, ts, dp.Timestamp())
+					assert.Equal(t, pmetric.NumberDataPointValueTypeInt, dp.ValueType())
+					assert.Equal(t, int64(1), dp.IntValue())
+				case "wavinsentio.room.cooling.duration":
+					assert.False(t, validatedMetrics["wavinsentio.room.cooling.duration"], "Found a duplicate in the metrics slice: wavinsentio.room.cooling.duration")
+					validatedMetrics["wavinsentio.room.cooling.duration"] = true
+					assert.Equal(t, pmetric.MetricTypeSum, ms.At(i).Type())
+					assert.Equal(t, 1, ms.At(i).Sum().DataPoints().Len())
+					assert.Equal(t, "Time the room has spent cooling.", ms.At(i).Description())
+					assert.Equal(t, "s", ms.At(i).Unit())
+					assert.True(t, ms.At(i).Sum().IsMonotonic())
+					assert.Equal(t, pmetric.AggregationTemporalityCumulative, ms.At(i).Sum().AggregationTemporality())
+					dp := ms.At(i).Sum().DataPoints().At(0)
+					assert.Equal(t, start, dp.StartTimestamp())
+					assert.Equal(t, ts, dp.Timestamp())
+					assert.Equal(t, pmetric.NumberDataPointValueTypeDouble, dp.ValueType())
+					assert.InDelta(t, float64(1), dp.DoubleValue(), 0.01)
+				case "wavinsentio.room.dehumidifier.state":
+					assert.False(t, validatedMetrics["wavinsentio.room.dehumidifier.state"], "Found a duplicate in the metrics slice: wavinsentio.room.dehumidifier.state")
+					validatedMetrics["wavinsentio.room.dehumidifier.state"] = true
+					assert.Equal(t, pmetric.MetricTypeGauge, ms.At(i).Type())
+					assert.Equal(t, 1, ms.At(i).Gauge().DataPoints().Len())
+					assert.Equal(t, "Dehumidifier state, as a state-set gauge.", ms.At(i).Description())
+					assert.Equal(t, "1", ms.At(i).Unit())
+					dp := ms.At(i).Gauge().DataPoints().At(0)
+					assert.Equal(t, start, dp.StartTimestamp())
+					assert.Equal(t, ts, dp.Timestamp())
+					assert.Equal(t, pmetric.NumberDataPointValueTypeInt, dp.ValueType())
+					assert.Equal(t, int64(1), dp.IntValue())
+					attrVal, ok := dp.Attributes().Get("state")
+					assert.True(t, ok)
+					assert.Equal(t, "state-val", attrVal.Str())
+				case "wavinsentio.room.heating.cycles":
+					assert.False(t, validatedMetrics["wavinsentio.room.heating.cycles"], "Found a duplicate in the metrics slice: wavinsentio.room.heating.cycles")
+					validatedMetrics["wavinsentio.room.heating.cycles"] = true
+					assert.Equal(t, pmetric.MetricTypeSum, ms.At(i).Type())
+					assert.Equal(t, 1, ms.At(i).Sum().DataPoints().Len())
+					assert.Equal(t, "Number of times the room started heating.", ms.At(i).Description())
+					assert.Equal(t, "{cycle}", ms.At(i).Unit())
+					assert.True(t, ms.At(i).Sum().IsMonotonic())
+					assert.Equal(t, pmetric.AggregationTemporalityCumulative, ms.At(i).Sum().AggregationTemporality())
+					dp := ms.At(i).Sum().DataPoints().At(0)
+					assert.Equal(t, start, dp.StartTimestamp())
+					assert.Equal(t, ts, dp.Timestamp())
+					assert.Equal(t, pmetric.NumberDataPointValueTypeInt, dp.ValueType())
+					assert.Equal(t, int64(1), dp.IntValue())
+				case "wavinsentio.room.heating.demand":
+					assert.False(t, validatedMetrics["wavinsentio.room.heating.demand"], "Found a duplicate in the metrics slice: wavinsentio.room.heating.demand")
+					validatedMetrics["wavinsentio.room.heating.demand"] = true
+					assert.Equal(t, pmetric.MetricTypeGauge, ms.At(i).Type())
+					assert.Equal(t, 1, ms.At(i).Gauge().DataPoints().Len())
+					assert.Equal(t, "Whether the room is calling for heat (1) or not (0).", ms.At(i).Description())
+					assert.Equal(t, "1", ms.At(i).Unit())
+					dp := ms.At(i).Gauge().DataPoints().At(0)
+					assert.Equal(t, start, dp.StartTimestamp())
+					assert.Equal(t, ts, dp.Timestamp())
+					assert.Equal(t, pmetric.NumberDataPointValueTypeInt, dp.ValueType())
+					assert.Equal(t, int64(1), dp.IntValue())
+				case "wavinsentio.room.heating.duration":
+					assert.False(t, validatedMetrics["wavinsentio.room.heating.duration"], "Found a duplicate in the metrics slice: wavinsentio.room.heating.duration")
+					validatedMetrics["wavinsentio.room.heating.duration"] = true
+					assert.Equal(t, pmetric.MetricTypeSum, ms.At(i).Type())
+					assert.Equal(t, 1, ms.At(i).Sum().DataPoints().Len())
+					assert.Equal(t, "Time the room has spent heating.", ms.At(i).Description())
+					assert.Equal(t, "s", ms.At(i).Unit())
+					assert.True(t, ms.At(i).Sum().IsMonotonic())
+					assert.Equal(t, pmetric.AggregationTemporalityCumulative, ms.At(i).Sum().AggregationTemporality())
+					dp := ms.At(i).Sum().DataPoints().At(0)
+					assert.Equal(t, start, dp.StartTimestamp())
+					assert.Equal(t, ts, dp.Timestamp())
+					assert.Equal(t, pmetric.NumberDataPointValueTypeDouble, dp.ValueType())
+					assert.InDelta(t, float64(1), dp.DoubleValue(), 0.01)
+				case "wavinsentio.room.humidity":
+					assert.False(t, validatedMetrics["wavinsentio.room.humidity"], "Found a duplicate in the metrics slice: wavinsentio.room.humidity")
+					validatedMetrics["wavinsentio.room.humidity"] = true
+					assert.Equal(t, pmetric.MetricTypeGauge, ms.At(i).Type())
+					assert.Equal(t, 1, ms.At(i).Gauge().DataPoints().Len())
+					assert.Equal(t, "Room humidity.", ms.At(i).Description())
+					assert.Equal(t, "%", ms.At(i).Unit())
+					dp := ms.At(i).Gauge().DataPoints().At(0)
+					assert.Equal(t, start, dp.StartTimestamp())
+					assert.Equal(t, ts, dp.Timestamp())
+					assert.Equal(t, pmetric.NumberDataPointValueTypeDouble, dp.ValueType())
+					assert.InDelta(t, float64(1), dp.DoubleValue(), 0.01)
+				case "wavinsentio.room.humidity.setpoint":
+					assert.False(t, validatedMetrics["wavinsentio.room.humidity.setpoint"], "Found a duplicate in the metrics slice: wavinsentio.room.humidity.setpoint")
+					validatedMetrics["wavinsentio.room.humidity.setpoint"] = true
+					assert.Equal(t, pmetric.MetricTypeGauge, ms.At(i).Type())
+					assert.Equal(t, 1, ms.At(i).Gauge().DataPoints().Len())
+					assert.Equal(t, "Room dehumidification setpoint for each heating/cooling mode.", ms.At(i).Description())
+					assert.Equal(t, "%", ms.At(i).Unit())
+					dp := ms.At(i).Gauge().DataPoints().At(0)
+					assert.Equal(t, start, dp.StartTimestamp())
+					assert.Equal(t, ts, dp.Timestamp())
+					assert.Equal(t, pmetric.NumberDataPointValueTypeDouble, dp.ValueType())
+					assert.InDelta(t, float64(1), dp.DoubleValue(), 0.01)
+					attrVal, ok := dp.Attributes().Get("wavinsentio.hc_mode")
+					assert.True(t, ok)
+					assert.Equal(t, "hc_mode-val", attrVal.Str())
+				case "wavinsentio.room.info":
+					assert.False(t, validatedMetrics["wavinsentio.room.info"], "Found a duplicate in the metrics slice: wavinsentio.room.info")
+					validatedMetrics["wavinsentio.room.info"] = true
+					assert.Equal(t, pmetric.MetricTypeGauge, ms.At(i).Type())
+					assert.Equal(t, 1, ms.At(i).Gauge().DataPoints().Len())
+					assert.Equal(t, "Title of the room, as a gauge set to 1. The title is a setting, so it is not a resource attribute.", ms.At(i).Description())
+					assert.Equal(t, "1", ms.At(i).Unit())
+					dp := ms.At(i).Gauge().DataPoints().At(0)
+					assert.Equal(t, start, dp.StartTimestamp())
+					assert.Equal(t, ts, dp.Timestamp())
+					assert.Equal(t, pmetric.NumberDataPointValueTypeInt, dp.ValueType())
+					assert.Equal(t, int64(1), dp.IntValue())
+					attrVal, ok := dp.Attributes().Get("wavinsentio.room.title")
+					assert.True(t, ok)
+					assert.Equal(t, "room.title-val", attrVal.Str())
+				case "wavinsentio.room.lock_mode":
+					assert.False(t, validatedMetrics["wavinsentio.room.lock_mode"], "Found a duplicate in the metrics slice: wavinsentio.room.lock_mode")
+					validatedMetrics["wavinsentio.room.lock_mode"] = true
+					assert.Equal(t, pmetric.MetricTypeGauge, ms.At(i).Type())
+					assert.Equal(t, 1, ms.At(i).Gauge().DataPoints().Len())
+					assert.Equal(t, "Lock mode of the room, as a state-set gauge.", ms.At(i).Description())
+					assert.Equal(t, "1", ms.At(i).Unit())
+					dp := ms.At(i).Gauge().DataPoints().At(0)
+					assert.Equal(t, start, dp.StartTimestamp())
+					assert.Equal(t, ts, dp.Timestamp())
+					assert.Equal(t, pmetric.NumberDataPointValueTypeInt, dp.ValueType())
+					assert.Equal(t, int64(1), dp.IntValue())
+					attrVal, ok := dp.Attributes().Get("state")
+					assert.True(t, ok)
+					assert.Equal(t, "state-val", attrVal.Str())
+				case "wavinsentio.room.temperature.air":
+					assert.False(t, validatedMetrics["wavinsentio.room.temperature.air"], "Found a duplicate in the metrics slice: wavinsentio.room.temperature.air")
+					validatedMetrics["wavinsentio.room.temperature.air"] = true
+					assert.Equal(t, pmetric.MetricTypeGauge, ms.At(i).Type())
+					assert.Equal(t, 1, ms.At(i).Gauge().DataPoints().Len())
+					assert.Equal(t, "Room air temperature.", ms.At(i).Description())
+					assert.Equal(t, "°C", ms.At(i).Unit())
+					dp := ms.At(i).Gauge().DataPoints().At(0)
+					assert.Equal(t, start, dp.StartTimestamp())
+					assert.Equal(t, ts, dp.Timestamp())
+					assert.Equal(t, pmetric.NumberDataPointValueTypeDouble, dp.ValueType())
+					assert.InDelta(t, float64(1), dp.DoubleValue(), 0.01)
+				case "wavinsentio.room.temperature.dew_point":
+					assert.False(t, validatedMetrics["wavinsentio.room.temperature.dew_point"], "Found a duplicate in the metrics slice: wavinsentio.room.temperature.dew_point")
+					validatedMetrics["wavinsentio.room.temperature.dew_point"] = true
+					assert.Equal(t, pmetric.MetricTypeGauge, ms.At(i).Type())
+					assert.Equal(t, 1, ms.At(i).Gauge().DataPoints().Len())
+					assert.Equal(t, "Room dew point, derived from the air temperature and humidity.", ms.At(i).Description())
+					assert.Equal(t, "°C", ms.At(i).Unit())
+					dp := ms.At(i).Gauge().DataPoints().At(0)
+					assert.Equal(t, start, dp.StartTimestamp())
+					assert.Equal(t, ts, dp.Timestamp())
+					assert.Equal(t, pmetric.NumberDataPointValueTypeDouble, dp.ValueType())
+					assert.InDelta(t, float64(1), dp.DoubleValue(), 0.01)
+				case "wavinsentio.room.temperature.setpoint":
+					assert.False(t, validatedMetrics["wavinsentio.room.temperature.setpoint"], "Found a duplicate in the metrics slice: wavinsentio.room.temperature.setpoint")
+					validatedMetrics["wavinsentio.room.temperature.setpoint"] = true
+					assert.Equal(t, pmetric.MetricTypeGauge, ms.At(i).Type())
+					assert.Equal(t, 1, ms.At(i).Gauge().DataPoints().Len())
+					assert.Equal(t, "Room setpoint temperature.", ms.At(i).Description())
+					assert.Equal(t, "°C", ms.At(i).Unit())
+					dp := ms.At(i).Gauge().DataPoints().At(0)
+					assert.Equal(t, start, dp.StartTimestamp())
+					assert.Equal(t, ts, dp.Timestamp())
+					assert.Equal(t, pmetric.NumberDataPointValueTypeDouble, dp.ValueType())
+					assert.InDelta(t, float64(1), dp.DoubleValue(), 0.01)
+				case "wavinsentio.room.temperature.setpoint.max":
+					assert.False(t, validatedMetrics["wavinsentio.room.temperature.setpoint.max"], "Found a duplicate in the metrics slice: wavinsentio.room.temperature.setpoint.max")
+					validatedMetrics["wavinsentio.room.temperature.setpoint.max"] = true
+					assert.Equal(t, pmetric.MetricTypeGauge, ms.At(i).Type())
+					assert.Equal(t, 1, ms.At(i).Gauge().DataPoints().Len())
+					assert.Equal(t, "Highest setpoint temperature allowed for the room.", ms.At(i).Description())
+					assert.Equal(t, "°C", ms.At(i).Unit())
+					dp := ms.At(i).Gauge().DataPoints().At(0)
+					assert.Equal(t, start, dp.StartTimestamp())
+					assert.Equal(t, ts, dp.Timestamp())
+					assert.Equal(t, pmetric.NumberDataPointValueTypeDouble, dp.ValueType())
+					assert.InDelta(t, float64(1), dp.DoubleValue(), 0.01)
+				case "wavinsentio.room.temperature.setpoint.min":
+					assert.False(t, validatedMetrics["wavinsentio.room.temperature.setpoint.min"], "Found a duplicate in the metrics slice: wavinsentio.room.temperature.setpoint.min")
+					validatedMetrics["wavinsentio.room.temperature.setpoint.min"] = true
+					assert.Equal(t, pmetric.MetricTypeGauge, ms.At(i).Type())
+					assert.Equal(t, 1, ms.At(i).Gauge().DataPoints().Len())
+					assert.Equal(t, "Lowest setpoint temperature allowed for the room.", ms.At(i).Description())
+					assert.Equal(t, "°C", ms.At(i).Unit())
+					dp := ms.At(i).Gauge().DataPoints().At(0)
+					assert.Equal(t, start, dp.StartTimestamp())
+					assert.Equal(t, ts, dp.Timestamp())
+					assert.Equal(t, pmetric.NumberDataPointValueTypeDouble, dp.ValueType())
+					assert.InDelta(t, float64(1), dp.DoubleValue(), 0.01)
+				case "wavinsentio.room.temperature_state":
+					assert.False(t, validatedMetrics["wavinsentio.room.temperature_state"], "Found a duplicate in the metrics slice: wavinsentio.room.temperature_state")
+					validatedMetrics["wavinsentio.room.temperature_state"] = true
+					assert.Equal(t, pmetric.MetricTypeGauge, ms.At(i).Type())
+					assert.Equal(t, 1, ms.At(i).Gauge().DataPoints().Len())
+					assert.Equal(t, "Temperature state of the room, as a state-set gauge.", ms.At(i).Description())
+					assert.Equal(t, "1", ms.At(i).Unit())
+					dp := ms.At(i).Gauge().DataPoints().At(0)
+					assert.Equal(t, start, dp.StartTimestamp())
+					assert.Equal(t, ts, dp.Timestamp())
+					assert.Equal(t, pmetric.NumberDataPointValueTypeInt, dp.ValueType())
+					assert.Equal(t, int64(1), dp.IntValue())
+					attrVal, ok := dp.Attributes().Get("state")
+					assert.True(t, ok)
+					assert.Equal(t, "state-val", attrVal.Str())
+				case "wavinsentio.room.vacation_mode":
+					assert.False(t, validatedMetrics["wavinsentio.room.vacation_mode"], "Found a duplicate in the metrics slice: wavinsentio.room.vacation_mode")
+					validatedMetrics["wavinsentio.room.vacation_mode"] = true
+					assert.Equal(t, pmetric.MetricTypeGauge, ms.At(i).Type())
+					assert.Equal(t, 1, ms.At(i).Gauge().DataPoints().Len())
+					assert.Equal(t, "Vacation mode of the room, as a state-set gauge.", ms.At(i).Description())
+					assert.Equal(t, "1", ms.At(i).Unit())
+					dp := ms.At(i).Gauge().DataPoints().At(0)
+					assert.Equal(t, start, dp.StartTimestamp())
+					assert.Equal(t, ts, dp.Timestamp())
+					assert.Equal(t, pmetric.NumberDataPointValueTypeInt, dp.ValueType())
+					assert.Equal(t, int64(1), dp.IntValue())
+					attrVal, ok := dp.Attributes().Get("state")
+					assert.True(t, ok)
+					assert.Equal(t, "state-val", attrVal.Str())
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,57 @@
+// Code generated by mdatagen. DO NOT EDIT.
+
+package metadata
+
+import (
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+// ResourceBuilder is a helper struct to build resources predefined in metadata.yaml.
+// The ResourceBuilder is not thread-safe and must not to be used in multiple goroutines.
+type ResourceBuilder struct {
+	config ResourceAttributesConfig
+	res    pcommon.Resource
+}
+
+// NewResourceBuilder creates a new ResourceBuilder. This method should be called on the start of the application.
+func NewResourceBuilder(rac ResourceAttributesConfig) *ResourceBuilder {
+	return &ResourceBuilder{
+		config: rac,
+		res:    pcommon.NewResource(),
+	}
+}
+
+// SetWavinsentioDeviceName sets provided value as "wavinsentio.device.name" attribute.
+func (rb *ResourceBuilder) SetWavinsentioDeviceName(val string) {
+	if rb.config.WavinsentioDeviceName.Enabled {
+		rb.res.Attributes().PutStr("wavinsentio.device.name", val)
+	}
+}
+
+// SetWavinsentioDeviceSerialNumber sets provided value as "wavinsentio.device.serial_number" attribute.
+func (rb *ResourceBuilder) SetWavinsentioDeviceSerialNumber(val string) {
+	if rb.config.WavinsentioDeviceSerialNumber.Enabled {
+		rb.res.Attributes().PutStr("wavinsentio.device.serial_number", val)
+	}
+}
+
+// SetWavinsentioDeviceType sets provided value as "wavinsentio.device.type" attribute.
+func (rb *ResourceBuilder) SetWavinsentioDeviceType(val string) {
+	if rb.config.WavinsentioDeviceType.Enabled {
+		rb.res.Attributes().PutStr("wavinsentio.device.type", val)
+	}
+}
+
+// SetWavinsentioRoomID sets provided value as "wavinsentio.room.id" attribute.
+func (rb *ResourceBuilder) SetWavinsentioRoomID(val string) {
+	if rb.config.WavinsentioRoomID.Enabled {
+		rb.res.Attributes().PutStr("wavinsentio.room.id", val)
+	}
+}
+
+// Emit returns the built resource and resets the internal builder state.
+func (rb *ResourceBuilder) Emit() pcommon.Resource {
+	r := rb.res
+	rb.res = pcommon.NewResource()
+	return r
+}
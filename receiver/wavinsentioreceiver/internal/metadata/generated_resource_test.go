@@ -0,0 +1,58 @@
+// Code generated by mdatagen. DO NOT EDIT.
+
+package metadata
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResourceBuilder(t *testing.T) {
+	for _, tt := range []string{"default", "all_set", "none_set"} {
+		t.Run(tt, func(t *testing.T) {
+			cfg := loadResourceAttributesConfig(t, tt)
+			rb := NewResourceBuilder(cfg)
+			rb.SetWavinsentioDeviceName("wavinsentio.device.name-val")
+			rb.SetWavinsentioDeviceSerialNumber("wavinsentio.device.serial_number-val")
+			rb.SetWavinsentioDeviceType("wavinsentio.device.type-val")
+			rb.SetWavinsentioRoomID("wavinsentio.room.id-val")
+
+			res := rb.Emit()
+			assert.Equal(t, 0, rb.Emit().Attributes().Len()) // Second call should return empty Resource
+
+			switch tt {
+			case "default":
+				assert.Equal(t, 4, res.Attributes().Len())
+			case "all_set":
+				assert.Equal(t, 4, res.Attributes().Len())
+			case "none_set":
+				assert.Equal(t, 0, res.Attributes().Len())
+				return
+			default:
+				assert.Failf(t, "unexpected test case: %s", tt)
+			}
+
+			val, ok := res.Attributes().Get("wavinsentio.device.name")
+			assert.True(t, ok)
+			if ok {
+				assert.Equal(t, "wavinsentio.device.name-val", val.Str())
+			}
+			val, ok = res.Attributes().Get("wavinsentio.device.serial_number")
+			assert.True(t, ok)
+			if ok {
+				assert.Equal(t, "wavinsentio.device.serial_number-val", val.Str())
+			}
+			val, ok = res.Attributes().Get("wavinsentio.device.type")
+			assert.True(t, ok)
+			if ok {
+				assert.Equal(t, "wavinsentio.device.type-val", val.Str())
+			}
+			val, ok = res.Attributes().Get("wavinsentio.room.id")
+			assert.True(t, ok)
+			if ok {
+				assert.Equal(t, "wavinsentio.room.id-val", val.Str())
+			}
+		})
+	}
+}
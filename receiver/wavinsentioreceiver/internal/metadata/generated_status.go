@@ -8,9 +8,10 @@ import (
 
 var (
 	Type      = component.MustNewType("wavinsentio")
-	ScopeName = "github.com/zmoog/collector/wavinsentio"
+	ScopeName = "github.com/zmoog/collector/receiver/wavinsentioreceiver"
 )
 
 const (
 	MetricsStability = component.StabilityLevelDevelopment
+	LogsStability    = component.StabilityLevelDevelopment
 )
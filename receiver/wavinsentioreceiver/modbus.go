@@ -0,0 +1,199 @@
+package wavinsentioreceiver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	ws2 "github.com/zmoog/ws/v2/ws"
+
+	"github.com/zmoog/collector/internal/modbus"
+)
+
+const defaultRegisterScale = 0.1
+
+// ModbusConfig configures the local mode, reading the CCU over its Modbus
+// TCP interface instead of the cloud API.
+type ModbusConfig struct {
+	// Endpoint is the host:port of the CCU Modbus TCP interface. The
+	// local mode is enabled when set.
+	Endpoint string `mapstructure:"endpoint"`
+	// UnitID is the Modbus unit identifier of the CCU.
+	UnitID byte `mapstructure:"unit_id"`
+	// Timeout is the connect and read timeout.
+	Timeout time.Duration `mapstructure:"timeout"`
+	// SerialNumber is the serial number of the CCU, used as the device
+	// resource attributes.
+	SerialNumber string `mapstructure:"serial_number"`
+	// OutdoorTemperature is the register of the outdoor temperature.
+	OutdoorTemperature *RegisterConfig `mapstructure:"outdoor_temperature"`
+	// Rooms lists the rooms to read and their registers.
+	Rooms []ModbusRoomConfig `mapstructure:"rooms"`
+}
+
+// ModbusRoomConfig maps a room to its registers.
+type ModbusRoomConfig struct {
+	ID string `mapstructure:"id"`
+	// Title is the room title. Defaults to the ID.
+	Title          string          `mapstructure:"title"`
+	AirTemperature *RegisterConfig `mapstructure:"air_temperature"`
+	Setpoint       *RegisterConfig `mapstructure:"setpoint"`
+	Humidity       *RegisterConfig `mapstructure:"humidity"`
+}
+
+// RegisterConfig locates a value in the Modbus registers. The register
+// holds a signed 16-bit integer, multiplied by the scale.
+type RegisterConfig struct {
+	Address uint16 `mapstructure:"address"`
+	// Type is the register type: input (default) or holding.
+	Type string `mapstructure:"type"`
+	// Scale converts the raw value to the unit of the metric. Defaults
+	// to 0.1, for temperatures in tenths of °C.
+	Scale float64 `mapstructure:"scale"`
+}
+
+func (cfg ModbusConfig) validate() error {
+	if cfg.SerialNumber == "" {
+		return errors.New("modbus.serial_number is required")
+	}
+	if len(cfg.Rooms) == 0 {
+		return errors.New("modbus.rooms is required")
+	}
+	if err := cfg.OutdoorTemperature.validate(); err != nil {
+		return fmt.Errorf("modbus.outdoor_temperature: %w", err)
+	}
+
+	ids := make(map[string]bool, len(cfg.Rooms))
+	for i, room := range cfg.Rooms {
+		if room.ID == "" {
+			return fmt.Errorf("modbus.rooms[%d]: id is required", i)
+		}
+		if ids[room.ID] {
+			return fmt.Errorf("modbus.rooms[%d]: duplicate id %q", i, room.ID)
+		}
+		ids[room.ID] = true
+
+		if room.AirTemperature == nil {
+			return fmt.Errorf("modbus.rooms[%d]: air_temperature is required", i)
+		}
+		if room.Setpoint == nil {
+			return fmt.Errorf("modbus.rooms[%d]: setpoint is required", i)
+		}
+		if err := room.AirTemperature.validate(); err != nil {
+			return fmt.Errorf("modbus.rooms[%d].air_temperature: %w", i, err)
+		}
+		if err := room.Setpoint.validate(); err != nil {
+			return fmt.Errorf("modbus.rooms[%d].setpoint: %w", i, err)
+		}
+		if err := room.Humidity.validate(); err != nil {
+			return fmt.Errorf("modbus.rooms[%d].humidity: %w", i, err)
+		}
+	}
+	return nil
+}
+
+func (r *RegisterConfig) validate() error {
+	if r == nil {
+		return nil
+	}
+	return r.register().Validate()
+}
+
+// register returns the register with the defaults: a signed 16-bit input
+// register, in tenths.
+func (r *RegisterConfig) register() modbus.Register {
+	register := modbus.Register{
+		Address: r.Address,
+		Type:    r.Type,
+		Words:   1,
+		Signed:  true,
+		Scale:   r.Scale,
+	}
+	if register.Type == "" {
+		register.Type = modbus.RegisterTypeInput
+	}
+	if register.Scale == 0 {
+		register.Scale = defaultRegisterScale
+	}
+	return register
+}
+
+// modbusClient reads the CCU over Modbus TCP and returns it as a device
+// of the cloud API, so the filters, metrics and setting changes work the
+// same in both modes.
+type modbusClient struct {
+	cfg    ModbusConfig
+	client *modbus.Client
+	now    func() time.Time
+}
+
+func newModbusClient(cfg ModbusConfig) *modbusClient {
+	return &modbusClient{
+		cfg:    cfg,
+		client: modbus.NewClient(cfg.Endpoint, cfg.UnitID, cfg.Timeout),
+		now:    time.Now,
+	}
+}
+
+// Login connects to the CCU, to check it can be reached.
+func (c *modbusClient) Login(_ context.Context) error {
+	return c.client.Connect()
+}
+
+// ListDevices reads the registers and returns the CCU as the only
+// device. The values the Modbus interface doesn't expose, like the
+// firmware or the modes, are left empty.
+func (c *modbusClient) ListDevices(_ context.Context) ([]ws2.Device, error) {
+	device := ws2.Device{
+		Name:          "local/" + c.cfg.SerialNumber,
+		SerialNumber:  c.cfg.SerialNumber,
+		Type:          "TYPE_SENTIO",
+		LastHeartbeat: c.now(),
+	}
+	sentio := &device.LastConfig.Sentio
+
+	if c.cfg.OutdoorTemperature != nil {
+		temperature, err := c.read(c.cfg.OutdoorTemperature)
+		if err != nil {
+			return nil, fmt.Errorf("read outdoor temperature: %w", err)
+		}
+		sentio.OutdoorTemperatureSensors = []ws2.OutdoorTemperatureSensor{
+			{ID: "modbus", OutdoorTemperature: temperature},
+		}
+	}
+
+	for _, cfg := range c.cfg.Rooms {
+		room := ws2.Room{ID: cfg.ID, Title: cfg.Title}
+		if room.Title == "" {
+			room.Title = cfg.ID
+		}
+
+		var err error
+		if room.AirTemperature, err = c.read(cfg.AirTemperature); err != nil {
+			return nil, fmt.Errorf("read room %s air temperature: %w", cfg.ID, err)
+		}
+		if room.SetpointTemperature, err = c.read(cfg.Setpoint); err != nil {
+			return nil, fmt.Errorf("read room %s setpoint: %w", cfg.ID, err)
+		}
+		if cfg.Humidity != nil {
+			if room.Humidity, err = c.read(cfg.Humidity); err != nil {
+				return nil, fmt.Errorf("read room %s humidity: %w", cfg.ID, err)
+			}
+		}
+
+		sentio.Rooms = append(sentio.Rooms, room)
+	}
+
+	return []ws2.Device{device}, nil
+}
+
+// read returns the scaled value of the register.
+func (c *modbusClient) read(register *RegisterConfig) (float64, error) {
+	return c.client.Read(register.register())
+}
+
+// Close closes the connection to the CCU.
+func (c *modbusClient) Close() error {
+	return c.client.Close()
+}
@@ -0,0 +1,191 @@
+package wavinsentioreceiver
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componentstatus"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+
+	"github.com/zmoog/collector/internal/modbus"
+	"github.com/zmoog/collector/internal/modbus/modbustest"
+	"github.com/zmoog/collector/receiver/wavinsentioreceiver/internal/metadata"
+)
+
+func newTestModbusConfig(endpoint string) ModbusConfig {
+	return ModbusConfig{
+		Endpoint:           endpoint,
+		UnitID:             1,
+		Timeout:            time.Second,
+		SerialNumber:       "my-serial-number",
+		OutdoorTemperature: &RegisterConfig{Address: 10},
+		Rooms: []ModbusRoomConfig{
+			{
+				ID:             "room-1",
+				Title:          "Living Room",
+				AirTemperature: &RegisterConfig{Address: 100},
+				Setpoint:       &RegisterConfig{Address: 200, Type: modbus.RegisterTypeHolding},
+				Humidity:       &RegisterConfig{Address: 101, Scale: 1},
+			},
+			{
+				ID:             "room-2",
+				AirTemperature: &RegisterConfig{Address: 110},
+				Setpoint:       &RegisterConfig{Address: 210, Type: modbus.RegisterTypeHolding},
+			},
+		},
+	}
+}
+
+func newTestModbusServer(t *testing.T) *modbustest.Server {
+	t.Helper()
+
+	return modbustest.NewServer(t, modbustest.Registers{
+		Holding: map[uint16]uint16{200: 220, 210: 190},
+		Input: map[uint16]uint16{
+			10:  0xffe2, // -3.0 °C
+			100: 215,
+			101: 48,
+			110: 198,
+		},
+	})
+}
+
+func TestModbusClient_ListDevices(t *testing.T) {
+	server := newTestModbusServer(t)
+	heartbeat := time.Date(2025, 1, 15, 10, 0, 0, 0, time.UTC)
+
+	client := newModbusClient(newTestModbusConfig(server.Addr()))
+	client.now = func() time.Time { return heartbeat }
+	defer client.Close()
+
+	require.NoError(t, client.Login(context.Background()))
+
+	devices, err := client.ListDevices(context.Background())
+	require.NoError(t, err)
+	require.Len(t, devices, 1)
+
+	device := devices[0]
+	assert.Equal(t, "local/my-serial-number", device.Name)
+	assert.Equal(t, "my-serial-number", device.SerialNumber)
+	assert.Equal(t, heartbeat, device.LastHeartbeat)
+
+	sentio := device.LastConfig.Sentio
+	require.Len(t, sentio.OutdoorTemperatureSensors, 1)
+	assert.Equal(t, -3.0, sentio.OutdoorTemperatureSensors[0].OutdoorTemperature)
+
+	require.Len(t, sentio.Rooms, 2)
+	assert.Equal(t, "Living Room", sentio.Rooms[0].Title)
+	assert.Equal(t, 21.5, sentio.Rooms[0].AirTemperature)
+	assert.Equal(t, 22.0, sentio.Rooms[0].SetpointTemperature)
+	assert.Equal(t, 48.0, sentio.Rooms[0].Humidity)
+	assert.Equal(t, "room-2", sentio.Rooms[1].Title, "the title defaults to the ID")
+	assert.Equal(t, 19.8, sentio.Rooms[1].AirTemperature)
+	assert.Equal(t, 19.0, sentio.Rooms[1].SetpointTemperature)
+	assert.Zero(t, sentio.Rooms[1].Humidity)
+}
+
+func TestModbusClient_ListDevicesMissingRegister(t *testing.T) {
+	server := newTestModbusServer(t)
+
+	cfg := newTestModbusConfig(server.Addr())
+	cfg.Rooms[1].AirTemperature.Address = 999
+	client := newModbusClient(cfg)
+	defer client.Close()
+
+	_, err := client.ListDevices(context.Background())
+	assert.ErrorContains(t, err, "read room room-2 air temperature")
+}
+
+func TestScraper_Modbus(t *testing.T) {
+	server := newTestModbusServer(t)
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.Modbus = newTestModbusConfig(server.Addr())
+	require.NoError(t, cfg.Validate(), "no cloud credentials needed")
+
+	s := newScraper(cfg, receivertest.NewNopSettings(metadata.Type))
+	host := newStatusHost(t)
+	require.NoError(t, s.startMetrics(context.Background(), host))
+	defer func() { require.NoError(t, s.shutdown(context.Background())) }()
+	assert.Empty(t, host.events)
+
+	metrics, err := s.scrape(context.Background())
+	require.NoError(t, err)
+
+	// One resource for the device, one for each room.
+	require.Equal(t, 3, metrics.ResourceMetrics().Len())
+
+	device := metrics.ResourceMetrics().At(0)
+	assert.Equal(t, -3.0, findMetric(t, device, "wavinsentio.device.outdoor_temperature").Gauge().DataPoints().At(0).DoubleValue())
+
+	room := metrics.ResourceMetrics().At(1)
+	assert.Equal(t, 21.5, findMetric(t, room, "wavinsentio.room.temperature.air").Gauge().DataPoints().At(0).DoubleValue())
+	assert.Equal(t, 22.0, findMetric(t, room, "wavinsentio.room.temperature.setpoint").Gauge().DataPoints().At(0).DoubleValue())
+	assert.Equal(t, 48.0, findMetric(t, room, "wavinsentio.room.humidity").Gauge().DataPoints().At(0).DoubleValue())
+
+	// The logs share the connection to the CCU.
+	logs := newScraper(cfg, receivertest.NewNopSettings(metadata.Type))
+	require.NoError(t, logs.startLogs(context.Background(), host))
+	assert.Same(t, s.client, logs.client)
+	require.NoError(t, logs.shutdown(context.Background()))
+
+	_, err = s.scrape(context.Background())
+	require.NoError(t, err, "still connected for the metrics")
+}
+
+func TestScraper_ModbusUnreachable(t *testing.T) {
+	// Grab a free port with nothing listening on it.
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	endpoint := listener.Addr().String()
+	require.NoError(t, listener.Close())
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.Modbus = newTestModbusConfig(endpoint)
+
+	s := newScraper(cfg, receivertest.NewNopSettings(metadata.Type))
+	host := newStatusHost(t)
+	require.NoError(t, s.startMetrics(context.Background(), host))
+	assert.Equal(t, []componentstatus.Status{componentstatus.StatusRecoverableError}, host.statuses())
+	require.NoError(t, s.shutdown(context.Background()))
+}
+
+func TestConfig_ValidateModbus(t *testing.T) {
+	tests := []struct {
+		name     string
+		modify   func(cfg *ModbusConfig)
+		expected string
+	}{
+		{name: "valid", modify: func(*ModbusConfig) {}},
+		{name: "no serial number", modify: func(cfg *ModbusConfig) { cfg.SerialNumber = "" }, expected: "modbus.serial_number is required"},
+		{name: "no rooms", modify: func(cfg *ModbusConfig) { cfg.Rooms = nil }, expected: "modbus.rooms is required"},
+		{name: "no room ID", modify: func(cfg *ModbusConfig) { cfg.Rooms[1].ID = "" }, expected: "modbus.rooms[1]: id is required"},
+		{name: "duplicate room ID", modify: func(cfg *ModbusConfig) { cfg.Rooms[1].ID = "room-1" }, expected: `modbus.rooms[1]: duplicate id "room-1"`},
+		{name: "no air temperature", modify: func(cfg *ModbusConfig) { cfg.Rooms[0].AirTemperature = nil }, expected: "modbus.rooms[0]: air_temperature is required"},
+		{name: "no setpoint", modify: func(cfg *ModbusConfig) { cfg.Rooms[0].Setpoint = nil }, expected: "modbus.rooms[0]: setpoint is required"},
+		{
+			name:     "unknown register type",
+			modify:   func(cfg *ModbusConfig) { cfg.Rooms[0].Humidity.Type = "coil" },
+			expected: `modbus.rooms[0].humidity: unknown register type "coil"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			cfg.Modbus = newTestModbusConfig("192.168.1.10:502")
+			tt.modify(&cfg.Modbus)
+
+			err := cfg.Validate()
+			if tt.expected == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.expected)
+			}
+		})
+	}
+}
@@ -2,53 +2,231 @@ package wavinsentioreceiver
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"io"
 
-	"github.com/zmoog/ws/v2/ws"
-	"github.com/zmoog/ws/v2/ws/identity"
+	"go.uber.org/zap"
 
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componentstatus"
+	"go.opentelemetry.io/collector/extension/xextension/storage"
+	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/receiver"
+
+	ws2 "github.com/zmoog/ws/v2/ws"
+
+	"github.com/zmoog/collector/receiver/wavinsentioreceiver/internal/metadata"
+	"github.com/zmoog/collector/receiver/wavinsentioreceiver/wavinapi"
 )
 
 // wavinsentioScraper is the struct that contains the Wavin Sentio scraper.
 type wavinsentioScraper struct {
 	cfg                *Config
+	id                 component.ID
 	settings           component.TelemetrySettings
-	client             *ws.Client
+	client             devicesClient
+	storageClient      storage.Client
 	devicesUnmarshaler *devicesUnmarshaler
+	changes            *changeDetector
+	changesMarshaler   *changesMarshaler
+	host               component.Host
+	// shared is true when the client is shared with the other signal
+	// and must be released on shutdown.
+	shared bool
+	// degraded is true when the last API call failed and the status
+	// reported to the host is an error.
+	degraded bool
+}
+
+// devicesClient lists the devices, from the cloud API or from the CCU
+// Modbus interface in the local mode.
+type devicesClient interface {
+	Login(ctx context.Context) error
+	ListDevices(ctx context.Context) ([]ws2.Device, error)
 }
 
 // newScraper is the function that creates a new Wavin Sentio scraper.
 func newScraper(cfg *Config, settings receiver.Settings) *wavinsentioScraper {
 	return &wavinsentioScraper{
 		cfg:      cfg,
+		id:       settings.ID,
 		settings: settings.TelemetrySettings,
 		devicesUnmarshaler: &devicesUnmarshaler{
 			logger: settings.Logger,
+			mb:     metadata.NewMetricsBuilder(cfg.MetricsBuilderConfig, settings),
+			// Allow for a late scrape before considering the
+			// interval a gap in the observations.
+			activity: newActivityTracker(2 * cfg.CollectionInterval),
+			rooms:    cfg.Rooms,
+		},
+		changes: newChangeDetector(),
+		changesMarshaler: &changesMarshaler{
+			buildInfo: settings.BuildInfo,
+			rooms:     cfg.Rooms,
 		},
 	}
 }
 
 // scrape is the main function that scrapes the data from the Wavin Sentio API.
-func (s *wavinsentioScraper) scrape(_ context.Context) (pmetric.Metrics, error) {
-	devices, err := s.client.ListDevices()
+func (s *wavinsentioScraper) scrape(ctx context.Context) (pmetric.Metrics, error) {
+	devices, err := s.client.ListDevices(ctx)
+	s.reportStatus(err)
 	if err != nil {
 		return pmetric.NewMetrics(), err
 	}
+	devices = filterDevices(devices, s.cfg.Devices, s.cfg.Rooms)
 
-	return s.devicesUnmarshaler.UnmarshalMetrics(devices)
+	metrics, err := s.devicesUnmarshaler.UnmarshalMetrics(devices)
+	if err != nil {
+		return metrics, err
+	}
+
+	if err := s.devicesUnmarshaler.activity.save(ctx, s.storageClient); err != nil {
+		s.settings.Logger.Warn("Error saving the rooms activity", zap.Error(err))
+	}
+
+	return metrics, nil
 }
 
-// start is the function that starts the Wavin Sentio scraper.
-func (s *wavinsentioScraper) start(_ context.Context, host component.Host) (err error) {
-	identityManager := identity.NewInMemoryManager(
-		identity.Config{
-			Username:  s.cfg.Username,
-			Password:  s.cfg.Password,
-			WebApiKey: s.cfg.WebApiKey,
-		},
-	)
-	s.client = ws.NewClient(identityManager, s.cfg.Endpoint)
+// scrapeLogs scrapes the devices and returns the setting changes since
+// the previous scrape as logs.
+func (s *wavinsentioScraper) scrapeLogs(ctx context.Context) (plog.Logs, error) {
+	devices, err := s.client.ListDevices(ctx)
+	s.reportStatus(err)
+	if err != nil {
+		return plog.NewLogs(), err
+	}
+	devices = filterDevices(devices, s.cfg.Devices, s.cfg.Rooms)
+
+	return s.changesMarshaler.UnmarshalLogs(devices, s.changes.detect(devices)), nil
+}
+
+// startMetrics starts the scraper and restores the rooms activity from
+// the storage.
+func (s *wavinsentioScraper) startMetrics(ctx context.Context, host component.Host) (err error) {
+	s.storageClient, err = getStorageClient(ctx, host, s.cfg.StorageID, s.id, "")
+	if err != nil {
+		return err
+	}
+
+	if err := s.devicesUnmarshaler.activity.load(ctx, s.storageClient); err != nil {
+		s.settings.Logger.Warn("Error loading the rooms activity, starting from scratch", zap.Error(err))
+	}
+
+	return s.start(ctx, host)
+}
+
+// startLogs starts the setting changes scraper.
+func (s *wavinsentioScraper) startLogs(ctx context.Context, host component.Host) error {
+	return s.start(ctx, host)
+}
+
+// start is the function that starts the Wavin Sentio scraper. The
+// metrics and logs scrapers share the API client, so they log in once
+// and keep a single refresh token, or share the connection to the CCU.
+//
+// It logs in to check the credentials: when the identity provider
+// rejects them, the receiver fails to start. Other failures, like the
+// identity provider being unreachable, are reported as a recoverable
+// error and the scrapes try again. In the local mode, it connects to the
+// CCU instead.
+func (s *wavinsentioScraper) start(ctx context.Context, host component.Host) error {
+	s.host = host
+	if s.client == nil {
+		shared, err := sharedClients.acquire(s.cfg, func() (*sharedClient, error) {
+			if s.cfg.Modbus.Endpoint != "" {
+				return &sharedClient{client: newModbusClient(s.cfg.Modbus)}, nil
+			}
+			return s.newCloudClient(ctx, host)
+		})
+		if err != nil {
+			return err
+		}
+		s.client = shared.client
+		s.shared = true
+	}
+
+	if err := s.client.Login(ctx); err != nil {
+		if isPermanentAuthError(err) {
+			return fmt.Errorf("login to Wavin Sentio: %w", err)
+		}
+		s.settings.Logger.Warn("Error logging in to Wavin Sentio, retrying at the next scrape", zap.Error(err))
+		s.reportStatus(err)
+	}
+
 	return nil
 }
+
+// newCloudClient creates the API client, keeping the refresh token in
+// the token cache when enabled.
+func (s *wavinsentioScraper) newCloudClient(ctx context.Context, host component.Host) (*sharedClient, error) {
+	if s.cfg.TokenCache.StorageID == nil {
+		manager := wavinapi.NewIdentityManager(s.cfg.ClientConfig)
+		return &sharedClient{client: wavinapi.NewClientWithIdentity(manager, s.cfg.Endpoint)}, nil
+	}
+
+	storageClient, err := getStorageClient(ctx, host, s.cfg.TokenCache.StorageID, s.id, tokenCacheName)
+	if err != nil {
+		return nil, err
+	}
+
+	cache, err := newTokenCache(storageClient, string(s.cfg.TokenCache.EncryptionKey), s.cfg.Username, s.settings.Logger)
+	if err != nil {
+		return nil, errors.Join(err, storageClient.Close(ctx))
+	}
+
+	manager := wavinapi.NewIdentityManagerWithStore(s.cfg.ClientConfig, cache)
+	return &sharedClient{
+		client:        wavinapi.NewClientWithIdentity(manager, s.cfg.Endpoint),
+		storageClient: storageClient,
+	}, nil
+}
+
+// reportStatus reports the outcome of an API call to the host, so login
+// and transport failures show up in the component status.
+//
+// The failures are recoverable, even when the identity provider rejects
+// the credentials: the collector doesn't accept any status after a
+// permanent error, so the status couldn't go back to OK once the
+// credentials work again. Only the rejection in start is permanent.
+func (s *wavinsentioScraper) reportStatus(err error) {
+	if s.host == nil {
+		return
+	}
+
+	if err == nil {
+		if s.degraded {
+			s.degraded = false
+			componentstatus.ReportStatus(s.host, componentstatus.NewEvent(componentstatus.StatusOK))
+		}
+		return
+	}
+
+	s.degraded = true
+	componentstatus.ReportStatus(s.host, componentstatus.NewRecoverableErrorEvent(err))
+}
+
+// isPermanentAuthError returns true if the identity provider rejected
+// the credentials.
+func isPermanentAuthError(err error) bool {
+	var authErr *wavinapi.AuthError
+	return errors.As(err, &authErr) && authErr.Permanent()
+}
+
+// shutdown closes the storage client and the connection to the CCU, and
+// releases the shared API client.
+func (s *wavinsentioScraper) shutdown(ctx context.Context) error {
+	var errs error
+	if s.shared {
+		s.shared = false
+		errs = sharedClients.release(ctx, s.cfg)
+	} else if closer, ok := s.client.(io.Closer); ok {
+		errs = closer.Close()
+	}
+	if s.storageClient != nil {
+		errs = errors.Join(errs, s.storageClient.Close(ctx))
+	}
+	return errs
+}
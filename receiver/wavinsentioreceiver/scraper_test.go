@@ -0,0 +1,186 @@
+package wavinsentioreceiver
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componentstatus"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+
+	"github.com/zmoog/ws/v2/ws/identity"
+
+	"github.com/zmoog/collector/receiver/wavinsentioreceiver/internal/metadata"
+	"github.com/zmoog/collector/receiver/wavinsentioreceiver/wavinapi"
+)
+
+// fakeIdentity is an identity manager returning a fixed token, or err
+// when set.
+type fakeIdentity struct {
+	mu  sync.Mutex
+	err error
+}
+
+func (f *fakeIdentity) GetToken() (identity.Token, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return identity.Token{ID: "my-token"}, f.err
+}
+
+func (f *fakeIdentity) setErr(err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.err = err
+}
+
+// statusTransitions are the status transitions accepted by the
+// collector, the other ones are rejected.
+var statusTransitions = map[componentstatus.Status][]componentstatus.Status{
+	componentstatus.StatusStarting: {
+		componentstatus.StatusOK, componentstatus.StatusRecoverableError, componentstatus.StatusPermanentError,
+		componentstatus.StatusFatalError, componentstatus.StatusStopping,
+	},
+	componentstatus.StatusOK: {
+		componentstatus.StatusRecoverableError, componentstatus.StatusPermanentError,
+		componentstatus.StatusFatalError, componentstatus.StatusStopping,
+	},
+	componentstatus.StatusRecoverableError: {
+		componentstatus.StatusOK, componentstatus.StatusRecoverableError, componentstatus.StatusPermanentError,
+		componentstatus.StatusFatalError, componentstatus.StatusStopping,
+	},
+	componentstatus.StatusPermanentError: {componentstatus.StatusStopping},
+	componentstatus.StatusStopping: {
+		componentstatus.StatusRecoverableError, componentstatus.StatusPermanentError,
+		componentstatus.StatusFatalError, componentstatus.StatusStopped,
+	},
+}
+
+// statusHost is a host recording the reported status events. Like the
+// collector, it rejects the invalid transitions, failing the test.
+type statusHost struct {
+	component.Host
+	t      *testing.T
+	events []*componentstatus.Event
+}
+
+func newStatusHost(t *testing.T) *statusHost {
+	return &statusHost{Host: componenttest.NewNopHost(), t: t}
+}
+
+func (h *statusHost) Report(event *componentstatus.Event) {
+	current := componentstatus.StatusStarting
+	if len(h.events) > 0 {
+		current = h.events[len(h.events)-1].Status()
+	}
+	if !slices.Contains(statusTransitions[current], event.Status()) {
+		h.t.Errorf("invalid status transition from %v to %v", current, event.Status())
+		return
+	}
+	h.events = append(h.events, event)
+}
+
+// statuses returns the reported statuses.
+func (h *statusHost) statuses() []componentstatus.Status {
+	var statuses []componentstatus.Status
+	for _, event := range h.events {
+		statuses = append(statuses, event.Status())
+	}
+	return statuses
+}
+
+func newTestScraper(t *testing.T, manager identity.Manager) *wavinsentioScraper {
+	t.Helper()
+
+	devices, err := os.ReadFile("testdata/devices.json")
+	require.NoError(t, err)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer my-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write(devices)
+	}))
+	t.Cleanup(server.Close)
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.Username = "my-username"
+	cfg.Password = "my-password"
+	cfg.WebApiKey = "my-web-api-key"
+	s := newScraper(cfg, receivertest.NewNopSettings(metadata.Type))
+	s.client = wavinapi.NewClientWithIdentity(manager, server.URL)
+	return s
+}
+
+func TestScraper_StartInvalidCredentials(t *testing.T) {
+	s := newTestScraper(t, &fakeIdentity{err: &wavinapi.AuthError{StatusCode: http.StatusBadRequest, Reason: "INVALID_PASSWORD"}})
+
+	err := s.startMetrics(context.Background(), componenttest.NewNopHost())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "INVALID_PASSWORD")
+}
+
+func TestScraper_StartUnreachableIdentityProvider(t *testing.T) {
+	manager := &fakeIdentity{err: &wavinapi.TransportError{URL: "https://example.com", Err: errors.New("connection refused")}}
+	s := newTestScraper(t, manager)
+	host := newStatusHost(t)
+
+	require.NoError(t, s.startMetrics(context.Background(), host))
+	assert.Equal(t, []componentstatus.Status{componentstatus.StatusRecoverableError}, host.statuses())
+
+	_, err := s.scrape(context.Background())
+	var transportErr *wavinapi.TransportError
+	require.ErrorAs(t, err, &transportErr)
+
+	// The identity provider is back.
+	manager.setErr(nil)
+	metrics, err := s.scrape(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 3, metrics.ResourceMetrics().Len())
+
+	_, err = s.scrape(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, []componentstatus.Status{
+		componentstatus.StatusRecoverableError,
+		componentstatus.StatusRecoverableError,
+		componentstatus.StatusOK,
+	}, host.statuses())
+}
+
+func TestScraper_ScrapeRejectedCredentials(t *testing.T) {
+	manager := &fakeIdentity{}
+	s := newTestScraper(t, manager)
+	host := newStatusHost(t)
+
+	require.NoError(t, s.startMetrics(context.Background(), host))
+	assert.Empty(t, host.events)
+
+	// The password changed while running.
+	manager.setErr(&wavinapi.AuthError{StatusCode: http.StatusBadRequest, Reason: "INVALID_PASSWORD"})
+	_, err := s.scrapeLogs(context.Background())
+	require.Error(t, err)
+
+	require.Len(t, host.events, 1)
+	assert.Equal(t, componentstatus.StatusRecoverableError, host.events[0].Status())
+	assert.ErrorContains(t, host.events[0].Err(), "INVALID_PASSWORD")
+
+	// The password is fixed.
+	manager.setErr(nil)
+	_, err = s.scrapeLogs(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, []componentstatus.Status{
+		componentstatus.StatusRecoverableError,
+		componentstatus.StatusOK,
+	}, host.statuses())
+}
@@ -0,0 +1,71 @@
+package wavinsentioreceiver
+
+import (
+	"slices"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+const (
+	temperatureStateHeating = "TEMPERATURE_STATE_HEATING"
+	temperatureStateCooling = "TEMPERATURE_STATE_COOLING"
+)
+
+// The known values of the enum settings reported by the Wavin Sentio API.
+// A value missing from these lists is still reported, as an additional
+// state.
+var (
+	hcModes = []string{
+		"HC_MODE_HEATING",
+		"HC_MODE_COOLING",
+	}
+	standbyModes = []string{
+		"STANDBY_MODE_OFF",
+		"STANDBY_MODE_ON",
+	}
+	vacationModes = []string{
+		"VACATION_MODE_OFF",
+		"VACATION_MODE_ON",
+	}
+	quietModes = []string{
+		"QUIET_MODE_OFF",
+		"QUIET_MODE_ON",
+	}
+	temperatureStates = []string{
+		"TEMPERATURE_STATE_IDLE",
+		temperatureStateHeating,
+		temperatureStateCooling,
+		"TEMPERATURE_STATE_BLOCKED_HEATING",
+		"TEMPERATURE_STATE_BLOCKED_COOLING",
+	}
+	lockModes = []string{
+		"LOCK_MODE_UNLOCKED",
+		"LOCK_MODE_LOCKED",
+	}
+	dehumidifierStates = []string{
+		"DEHUMIDIFIER_STATE_IDLE",
+		"DEHUMIDIFIER_STATE_RUNNING",
+	}
+)
+
+// recordStateSet records one data point per state, set to 1 for the
+// current state and 0 for the others. Nothing is recorded when the
+// current state is unknown (empty).
+func recordStateSet(ts pcommon.Timestamp, states []string, current string, record func(ts pcommon.Timestamp, val int64, state string)) {
+	if current == "" {
+		return
+	}
+	for _, state := range states {
+		record(ts, boolToInt(state == current), state)
+	}
+	if !slices.Contains(states, current) {
+		record(ts, 1, current)
+	}
+}
+
+func boolToInt(b bool) int64 {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,129 @@
+package wavinsentioreceiver
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/collector/extension/xextension/storage"
+	"go.uber.org/zap"
+)
+
+// tokenCacheStorageKey is the storage key of the refresh token.
+const tokenCacheStorageKey = "refresh_token"
+
+// cachedToken is the refresh token of an account, as stored encrypted in
+// the token cache.
+type cachedToken struct {
+	Username     string `json:"username"`
+	RefreshToken string `json:"refresh_token"`
+}
+
+// tokenCache keeps the refresh token in a storage extension, encrypted
+// with AES-GCM, so restarts don't log in with the password again. It
+// implements wavinapi.TokenStore.
+type tokenCache struct {
+	client   storage.Client
+	aead     cipher.AEAD
+	username string
+	logger   *zap.Logger
+}
+
+// newTokenCache creates a token cache for the username's refresh token.
+// The AES-256 key is derived from the encryption key with SHA-256, so
+// any passphrase works.
+func newTokenCache(client storage.Client, encryptionKey, username string, logger *zap.Logger) (*tokenCache, error) {
+	key := sha256.Sum256([]byte(encryptionKey))
+	block, err := aes.NewCipher(key[:])
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	return &tokenCache{
+		client:   client,
+		aead:     aead,
+		username: username,
+		logger:   logger,
+	}, nil
+}
+
+// LoadRefreshToken returns the cached refresh token, or an empty string
+// when there is none, it can't be decrypted, or it belongs to another
+// account.
+func (c *tokenCache) LoadRefreshToken(ctx context.Context) string {
+	data, err := c.client.Get(ctx, tokenCacheStorageKey)
+	if err != nil {
+		c.logger.Warn("Error loading the cached refresh token", zap.Error(err))
+		return ""
+	}
+	if data == nil {
+		return ""
+	}
+
+	token, err := c.decrypt(data)
+	if err != nil {
+		c.logger.Warn("Ignoring the cached refresh token", zap.Error(err))
+		return ""
+	}
+	if token.Username != c.username {
+		c.logger.Info("Ignoring the cached refresh token of another account")
+		return ""
+	}
+
+	return token.RefreshToken
+}
+
+// SaveRefreshToken encrypts and stores the refresh token.
+func (c *tokenCache) SaveRefreshToken(ctx context.Context, refreshToken string) {
+	data, err := c.encrypt(cachedToken{Username: c.username, RefreshToken: refreshToken})
+	if err == nil {
+		err = c.client.Set(ctx, tokenCacheStorageKey, data)
+	}
+	if err != nil {
+		c.logger.Warn("Error caching the refresh token", zap.Error(err))
+	}
+}
+
+// encrypt returns the nonce followed by the encrypted token.
+func (c *tokenCache) encrypt(token cachedToken) ([]byte, error) {
+	plaintext, err := json.Marshal(token)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, c.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+
+	return c.aead.Seal(nonce, nonce, plaintext, nil), nil
+}
+
+func (c *tokenCache) decrypt(data []byte) (cachedToken, error) {
+	var token cachedToken
+
+	if len(data) < c.aead.NonceSize() {
+		return token, errors.New("cached token too short")
+	}
+
+	nonce, ciphertext := data[:c.aead.NonceSize()], data[c.aead.NonceSize():]
+	plaintext, err := c.aead.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		// Most likely the encryption key changed.
+		return token, fmt.Errorf("decrypt cached token: %w", err)
+	}
+
+	if err := json.Unmarshal(plaintext, &token); err != nil {
+		return token, fmt.Errorf("parse cached token: %w", err)
+	}
+	return token, nil
+}
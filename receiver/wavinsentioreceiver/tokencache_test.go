@@ -0,0 +1,86 @@
+package wavinsentioreceiver
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+	"go.uber.org/zap"
+
+	"github.com/zmoog/collector/receiver/wavinsentioreceiver/internal/metadata"
+)
+
+func TestTokenCache(t *testing.T) {
+	ctx := context.Background()
+	storage := &memoryStorage{data: make(map[string][]byte)}
+
+	cache, err := newTokenCache(storage, "my-key", "my-username", zap.NewNop())
+	require.NoError(t, err)
+	assert.Empty(t, cache.LoadRefreshToken(ctx), "nothing cached yet")
+
+	cache.SaveRefreshToken(ctx, "my-refresh-token")
+	assert.NotContains(t, string(storage.data[tokenCacheStorageKey]), "my-refresh-token", "the token is encrypted")
+	assert.Equal(t, "my-refresh-token", cache.LoadRefreshToken(ctx))
+
+	t.Run("other key", func(t *testing.T) {
+		cache, err := newTokenCache(storage, "other-key", "my-username", zap.NewNop())
+		require.NoError(t, err)
+		assert.Empty(t, cache.LoadRefreshToken(ctx))
+	})
+
+	t.Run("other account", func(t *testing.T) {
+		cache, err := newTokenCache(storage, "my-key", "other-username", zap.NewNop())
+		require.NoError(t, err)
+		assert.Empty(t, cache.LoadRefreshToken(ctx))
+	})
+
+	t.Run("corrupted", func(t *testing.T) {
+		storage.data[tokenCacheStorageKey] = []byte("garbage")
+		assert.Empty(t, cache.LoadRefreshToken(ctx))
+	})
+}
+
+func TestScraper_StartTokenCache(t *testing.T) {
+	storageID := component.MustNewID("file_storage")
+	storage := &memoryStorage{data: make(map[string][]byte)}
+	host := storageHost{
+		Host:       componenttest.NewNopHost(),
+		extensions: map[component.ID]component.Component{storageID: storage},
+	}
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.Username = "my-username"
+	cfg.TokenCache.StorageID = &storageID
+	cfg.TokenCache.EncryptionKey = "my-key"
+
+	s := newScraper(cfg, receivertest.NewNopSettings(metadata.Type))
+	shared, err := s.newCloudClient(context.Background(), host)
+	require.NoError(t, err)
+	assert.NotNil(t, shared.client)
+	assert.Equal(t, storage, shared.storageClient)
+	require.NoError(t, shared.close(context.Background()))
+
+	missing := component.MustNewID("missing")
+	cfg.TokenCache.StorageID = &missing
+	_, err = newScraper(cfg, receivertest.NewNopSettings(metadata.Type)).newCloudClient(context.Background(), host)
+	assert.EqualError(t, err, "storage extension missing not found")
+}
+
+func TestConfig_ValidateTokenCache(t *testing.T) {
+	storageID := component.MustNewID("file_storage")
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = "https://example.com"
+	cfg.Username = "my-username"
+	cfg.Password = "my-password"
+	cfg.WebApiKey = "my-web-api-key"
+	cfg.TokenCache.StorageID = &storageID
+	assert.EqualError(t, cfg.Validate(), "token_cache.encryption_key is required")
+
+	cfg.TokenCache.EncryptionKey = "my-key"
+	assert.NoError(t, cfg.Validate())
+}
@@ -1,6 +1,7 @@
 package wavinsentioreceiver
 
 import (
+	"math"
 	"time"
 
 	"go.opentelemetry.io/collector/pdata/pcommon"
@@ -8,119 +9,122 @@ import (
 	"go.uber.org/zap"
 
 	ws2 "github.com/zmoog/ws/v2/ws"
-)
 
-const (
-	scopeName    = "github.com/zmoog/collector/receiver/wavinsentioreceiver"
-	scopeVersion = "v0.2.0"
+	"github.com/zmoog/collector/receiver/wavinsentioreceiver/internal/metadata"
 )
 
 type devicesUnmarshaler struct {
 	logger *zap.Logger
+	mb     *metadata.MetricsBuilder
+	// activity tracks the heating and cooling activity of the rooms.
+	activity *activityTracker
+	// rooms holds the additional attributes of the rooms.
+	rooms RoomsConfig
 }
 
 func (u *devicesUnmarshaler) UnmarshalMetrics(devices []ws2.Device) (pmetric.Metrics, error) {
 	u.logger.Info("Unmarshalling devices", zap.Int("device_count", len(devices)))
 
-	timestamp := pcommon.Timestamp(time.Now().UnixNano())
-	m := pmetric.NewMetrics()
+	timestamp := pcommon.NewTimestampFromTime(time.Now())
 
 	for _, device := range devices {
-		resourceMetrics := m.ResourceMetrics().AppendEmpty()
-		resource := resourceMetrics.Resource()
-
-		resource.Attributes().PutStr("wavinsentio.device.name", device.Name)
-		resource.Attributes().PutStr("wavinsentio.device.serial_number", device.SerialNumber)
-		resource.Attributes().PutStr("wavinsentio.device.type", device.Type)
-		resource.Attributes().PutStr("wavinsentio.device.hc_mode", device.HcMode)
-		resource.Attributes().PutStr("wavinsentio.device.firmware.available", device.FirmwareAvailable)
-		resource.Attributes().PutStr("wavinsentio.device.firmware.installed", device.FirmwareInstalled)
-
-		scopeMetrics := resourceMetrics.ScopeMetrics().AppendEmpty()
-		scopeMetrics.Scope().SetName(scopeName)
-		scopeMetrics.Scope().SetVersion(scopeVersion)
-
-		metrics := scopeMetrics.Metrics()
-
-		deviceLastHeartbeat := metrics.AppendEmpty()
-		deviceLastHeartbeat.SetName("wavinsentio.device.last_heartbeat")
-		deviceLastHeartbeat.SetDescription("Device last heartbeat timestamp")
-		deviceLastHeartbeat.SetUnit("ns")
-		deviceLastHeartbeatDataPoint := deviceLastHeartbeat.SetEmptyGauge().DataPoints().AppendEmpty()
-		deviceLastHeartbeatDataPoint.SetIntValue(device.LastHeartbeat.UnixNano())
-		deviceLastHeartbeatDataPoint.SetTimestamp(timestamp)
-
-		if device.LastConfig.Sentio.OutdoorTemperatureSensors != nil {
-			for _, sensor := range device.LastConfig.Sentio.OutdoorTemperatureSensors {
-				outdoorTemp := metrics.AppendEmpty()
-				outdoorTemp.SetName("wavinsentio.device.outdoor_temperature")
-				outdoorTemp.SetDescription("Outdoor temperature from sensor")
-				outdoorTemp.SetUnit("°C")
-				outdoorTempDataPoint := outdoorTemp.SetEmptyGauge().DataPoints().AppendEmpty()
-				outdoorTempDataPoint.SetDoubleValue(sensor.OutdoorTemperature)
-				outdoorTempDataPoint.SetTimestamp(timestamp)
-				outdoorTempDataPoint.Attributes().PutStr("wavinsentio.sensor.id", sensor.ID)
-			}
-		}
+		u.recordDevice(timestamp, device)
 
 		for _, room := range device.LastConfig.Sentio.Rooms {
-			roomResourceMetrics := m.ResourceMetrics().AppendEmpty()
-			roomResource := roomResourceMetrics.Resource()
-
-			roomResource.Attributes().PutStr("wavinsentio.device.name", device.Name)
-			roomResource.Attributes().PutStr("wavinsentio.device.serial_number", device.SerialNumber)
-			roomResource.Attributes().PutStr("wavinsentio.room.id", room.ID)
-			roomResource.Attributes().PutStr("wavinsentio.room.title", room.Title)
-			roomResource.Attributes().PutStr("wavinsentio.room.temperature_state", room.TemperatureState)
-			roomResource.Attributes().PutStr("wavinsentio.room.vacation_mode", room.VacationMode)
-			roomResource.Attributes().PutStr("wavinsentio.room.lock_mode", room.LockMode)
-
-			roomScopeMetrics := roomResourceMetrics.ScopeMetrics().AppendEmpty()
-			roomScopeMetrics.Scope().SetName(scopeName)
-			roomScopeMetrics.Scope().SetVersion(scopeVersion)
-
-			roomMetrics := roomScopeMetrics.Metrics()
-
-			airTemp := roomMetrics.AppendEmpty()
-			airTemp.SetName("wavinsentio.room.temperature.air")
-			airTemp.SetDescription("Room air temperature")
-			airTemp.SetUnit("°C")
-			airTempDataPoint := airTemp.SetEmptyGauge().DataPoints().AppendEmpty()
-			airTempDataPoint.SetDoubleValue(room.AirTemperature)
-			airTempDataPoint.SetTimestamp(timestamp)
-
-			setpointTemp := roomMetrics.AppendEmpty()
-			setpointTemp.SetName("wavinsentio.room.temperature.setpoint")
-			setpointTemp.SetDescription("Room setpoint temperature")
-			setpointTemp.SetUnit("°C")
-			setpointTempDataPoint := setpointTemp.SetEmptyGauge().DataPoints().AppendEmpty()
-			setpointTempDataPoint.SetDoubleValue(room.SetpointTemperature)
-			setpointTempDataPoint.SetTimestamp(timestamp)
-
-			humidity := roomMetrics.AppendEmpty()
-			humidity.SetName("wavinsentio.room.humidity")
-			humidity.SetDescription("Room humidity")
-			humidity.SetUnit("%")
-			humidityDataPoint := humidity.SetEmptyGauge().DataPoints().AppendEmpty()
-			humidityDataPoint.SetDoubleValue(room.Humidity)
-			humidityDataPoint.SetTimestamp(timestamp)
-
-			if room.DehumidifierState != "" {
-				dehumidifierState := roomMetrics.AppendEmpty()
-				dehumidifierState.SetName("wavinsentio.room.dehumidifier.state")
-				dehumidifierState.SetDescription("Dehumidifier state")
-				dehumidifierState.SetUnit("")
-				dehumidifierStateDataPoint := dehumidifierState.SetEmptyGauge().DataPoints().AppendEmpty()
-				stateValue := 0.0
-				if room.DehumidifierState == "on" {
-					stateValue = 1.0
-				}
-				dehumidifierStateDataPoint.SetDoubleValue(stateValue)
-				dehumidifierStateDataPoint.SetTimestamp(timestamp)
-				dehumidifierStateDataPoint.Attributes().PutStr("wavinsentio.dehumidifier.state", room.DehumidifierState)
-			}
+			u.recordRoom(timestamp, device, room)
 		}
 	}
 
-	return m, nil
+	return u.mb.Emit(), nil
+}
+
+// recordDevice records the metrics of the device and emits them under
+// the device resource.
+func (u *devicesUnmarshaler) recordDevice(ts pcommon.Timestamp, device ws2.Device) {
+	sentio := device.LastConfig.Sentio
+
+	u.mb.RecordWavinsentioDeviceLastHeartbeatDataPoint(ts, device.LastHeartbeat.UnixNano())
+
+	for _, sensor := range sentio.OutdoorTemperatureSensors {
+		u.mb.RecordWavinsentioDeviceOutdoorTemperatureDataPoint(ts, sensor.OutdoorTemperature, sensor.ID)
+	}
+
+	// The local mode doesn't know the firmware.
+	if device.FirmwareInstalled != "" {
+		u.mb.RecordWavinsentioDeviceFirmwareDataPoint(ts, 1, device.FirmwareInstalled, device.FirmwareAvailable)
+	}
+
+	recordStateSet(ts, hcModes, device.HcMode, u.mb.RecordWavinsentioDeviceHcModeDataPoint)
+	recordStateSet(ts, standbyModes, sentio.StandbyMode, u.mb.RecordWavinsentioDeviceStandbyModeDataPoint)
+	recordStateSet(ts, vacationModes, sentio.VacationSettings.VacationMode, u.mb.RecordWavinsentioDeviceVacationModeDataPoint)
+	recordStateSet(ts, quietModes, sentio.QuietSettings.Mode, u.mb.RecordWavinsentioDeviceQuietModeDataPoint)
+
+	rb := u.mb.NewResourceBuilder()
+	rb.SetWavinsentioDeviceName(device.Name)
+	rb.SetWavinsentioDeviceSerialNumber(device.SerialNumber)
+	rb.SetWavinsentioDeviceType(device.Type)
+	u.mb.EmitForResource(metadata.WithResource(rb.Emit()))
+}
+
+// recordRoom records the metrics of the room and emits them under the
+// room resource. The resource only holds the attributes identifying the
+// room: the settings that change over time are recorded as metrics, so
+// they don't start a new series when they change.
+func (u *devicesUnmarshaler) recordRoom(ts pcommon.Timestamp, device ws2.Device, room ws2.Room) {
+	u.mb.RecordWavinsentioRoomInfoDataPoint(ts, 1, room.Title)
+	u.mb.RecordWavinsentioRoomTemperatureAirDataPoint(ts, room.AirTemperature)
+	u.mb.RecordWavinsentioRoomTemperatureSetpointDataPoint(ts, room.SetpointTemperature)
+	// The values missing in the local mode are zero: don't record them.
+	if room.MaxSetpointTemperature > 0 {
+		u.mb.RecordWavinsentioRoomTemperatureSetpointMinDataPoint(ts, room.MinSetpointTemperature)
+		u.mb.RecordWavinsentioRoomTemperatureSetpointMaxDataPoint(ts, room.MaxSetpointTemperature)
+	}
+	if room.Humidity > 0 {
+		u.mb.RecordWavinsentioRoomHumidityDataPoint(ts, room.Humidity)
+		u.mb.RecordWavinsentioRoomTemperatureDewPointDataPoint(ts, dewPoint(room.AirTemperature, room.Humidity))
+	}
+
+	for _, preset := range room.DehumidificationPresets {
+		u.mb.RecordWavinsentioRoomHumiditySetpointDataPoint(ts, preset.Setpoint, preset.HcMode)
+	}
+
+	recordStateSet(ts, dehumidifierStates, room.DehumidifierState, u.mb.RecordWavinsentioRoomDehumidifierStateDataPoint)
+	recordStateSet(ts, temperatureStates, room.TemperatureState, u.mb.RecordWavinsentioRoomTemperatureStateDataPoint)
+	recordStateSet(ts, vacationModes, room.VacationMode, u.mb.RecordWavinsentioRoomVacationModeDataPoint)
+	recordStateSet(ts, lockModes, room.LockMode, u.mb.RecordWavinsentioRoomLockModeDataPoint)
+
+	rb := u.mb.NewResourceBuilder()
+	rb.SetWavinsentioDeviceName(device.Name)
+	rb.SetWavinsentioDeviceSerialNumber(device.SerialNumber)
+	rb.SetWavinsentioRoomID(room.ID)
+	resource := rb.Emit()
+	u.rooms.putAttributes(resource.Attributes(), room)
+	options := []metadata.ResourceMetricsOption{metadata.WithResource(resource)}
+
+	if room.TemperatureState != "" {
+		u.mb.RecordWavinsentioRoomHeatingDemandDataPoint(ts, boolToInt(room.TemperatureState == temperatureStateHeating))
+		u.mb.RecordWavinsentioRoomCoolingDemandDataPoint(ts, boolToInt(room.TemperatureState == temperatureStateCooling))
+
+		activity := u.activity.observe(roomKey(device, room), room.TemperatureState, ts.AsTime())
+		u.mb.RecordWavinsentioRoomHeatingDurationDataPoint(ts, activity.HeatingDuration)
+		u.mb.RecordWavinsentioRoomCoolingDurationDataPoint(ts, activity.CoolingDuration)
+		u.mb.RecordWavinsentioRoomHeatingCyclesDataPoint(ts, activity.HeatingCycles)
+		u.mb.RecordWavinsentioRoomCoolingCyclesDataPoint(ts, activity.CoolingCycles)
+		options = append(options, metadata.WithStartTimeOverride(pcommon.NewTimestampFromTime(activity.StartTime)))
+	}
+
+	u.mb.EmitForResource(options...)
+}
+
+// roomKey returns the key identifying the room across scrapes.
+func roomKey(device ws2.Device, room ws2.Room) string {
+	return device.SerialNumber + "/" + room.ID
+}
+
+// dewPoint returns the dew point in °C for the air temperature (°C) and
+// relative humidity (%), using the Magnus formula.
+func dewPoint(temperature, humidity float64) float64 {
+	const b, c = 17.62, 243.12
+	gamma := math.Log(humidity/100) + b*temperature/(c+temperature)
+	return c * gamma / (b - gamma)
 }
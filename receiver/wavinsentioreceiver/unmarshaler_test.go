@@ -0,0 +1,209 @@
+package wavinsentioreceiver
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+	"go.uber.org/zap"
+
+	ws2 "github.com/zmoog/ws/v2/ws"
+
+	"github.com/zmoog/collector/receiver/wavinsentioreceiver/internal/metadata"
+)
+
+func loadDevices(t *testing.T) []ws2.Device {
+	t.Helper()
+
+	data, err := os.ReadFile("testdata/devices.json")
+	require.NoError(t, err, "Failed to read test data file")
+
+	var devices ws2.Devices
+	require.NoError(t, json.Unmarshal(data, &devices), "Failed to unmarshal test data")
+
+	return devices.Devices
+}
+
+func newTestUnmarshaler() *devicesUnmarshaler {
+	return &devicesUnmarshaler{
+		logger:   zap.NewNop(),
+		mb:       metadata.NewMetricsBuilder(metadata.DefaultMetricsBuilderConfig(), receivertest.NewNopSettings(metadata.Type)),
+		activity: newActivityTracker(2 * DefaultCollectionInterval),
+	}
+}
+
+// findMetric returns the metric with the given name in the resource metrics.
+func findMetric(t *testing.T, rm pmetric.ResourceMetrics, name string) pmetric.Metric {
+	t.Helper()
+
+	metrics := rm.ScopeMetrics().At(0).Metrics()
+	for i := 0; i < metrics.Len(); i++ {
+		if metrics.At(i).Name() == name {
+			return metrics.At(i)
+		}
+	}
+	require.Failf(t, "metric not found", "metric %s not found", name)
+	return pmetric.Metric{}
+}
+
+// stateSet returns the values of a state-set gauge by state.
+func stateSet(m pmetric.Metric) map[string]int64 {
+	values := make(map[string]int64)
+	dps := m.Gauge().DataPoints()
+	for i := 0; i < dps.Len(); i++ {
+		state, _ := dps.At(i).Attributes().Get("state")
+		values[state.Str()] = dps.At(i).IntValue()
+	}
+	return values
+}
+
+func TestDevicesUnmarshaler_UnmarshalMetrics(t *testing.T) {
+	metrics, err := newTestUnmarshaler().UnmarshalMetrics(loadDevices(t))
+	require.NoError(t, err)
+
+	// One resource for the device, one for each room.
+	require.Equal(t, 3, metrics.ResourceMetrics().Len())
+
+	t.Run("device", func(t *testing.T) {
+		rm := metrics.ResourceMetrics().At(0)
+
+		serialNumber, ok := rm.Resource().Attributes().Get("wavinsentio.device.serial_number")
+		require.True(t, ok)
+		assert.Equal(t, "my-serial-number", serialNumber.Str())
+		assert.Equal(t, "github.com/zmoog/collector/receiver/wavinsentioreceiver", rm.ScopeMetrics().At(0).Scope().Name())
+
+		outdoor := findMetric(t, rm, "wavinsentio.device.outdoor_temperature").Gauge().DataPoints().At(0)
+		assert.Equal(t, 4.5, outdoor.DoubleValue())
+		sensorID, _ := outdoor.Attributes().Get("wavinsentio.sensor.id")
+		assert.Equal(t, "sensor-1", sensorID.Str())
+
+		// The resource only holds the device identity.
+		assert.Equal(t, 3, rm.Resource().Attributes().Len())
+
+		firmware := findMetric(t, rm, "wavinsentio.device.firmware").Gauge().DataPoints().At(0)
+		installed, _ := firmware.Attributes().Get("wavinsentio.firmware.installed")
+		assert.Equal(t, "1.1.0", installed.Str())
+		available, _ := firmware.Attributes().Get("wavinsentio.firmware.available")
+		assert.Equal(t, "1.2.0", available.Str())
+
+		assert.Equal(t, map[string]int64{"HC_MODE_HEATING": 1, "HC_MODE_COOLING": 0}, stateSet(findMetric(t, rm, "wavinsentio.device.hc_mode")))
+		assert.Equal(t, map[string]int64{"STANDBY_MODE_OFF": 1, "STANDBY_MODE_ON": 0}, stateSet(findMetric(t, rm, "wavinsentio.device.standby_mode")))
+		assert.Equal(t, map[string]int64{"VACATION_MODE_OFF": 1, "VACATION_MODE_ON": 0}, stateSet(findMetric(t, rm, "wavinsentio.device.vacation_mode")))
+		assert.Equal(t, map[string]int64{"QUIET_MODE_OFF": 1, "QUIET_MODE_ON": 0}, stateSet(findMetric(t, rm, "wavinsentio.device.quiet_mode")))
+	})
+
+	t.Run("heating room", func(t *testing.T) {
+		rm := metrics.ResourceMetrics().At(1)
+
+		assert.Equal(t, map[string]any{
+			"wavinsentio.device.name":          "devices/1234",
+			"wavinsentio.device.serial_number": "my-serial-number",
+			"wavinsentio.room.id":              "room-1",
+		}, rm.Resource().Attributes().AsRaw())
+
+		// The title can change, so it is a data point attribute.
+		info := findMetric(t, rm, "wavinsentio.room.info").Gauge().DataPoints().At(0)
+		assert.Equal(t, int64(1), info.IntValue())
+		assert.Equal(t, map[string]any{"wavinsentio.room.title": "Living Room"}, info.Attributes().AsRaw())
+
+		for name, value := range map[string]float64{
+			"wavinsentio.room.temperature.air":          20.0,
+			"wavinsentio.room.temperature.setpoint":     21.5,
+			"wavinsentio.room.temperature.setpoint.min": 6.0,
+			"wavinsentio.room.temperature.setpoint.max": 30.0,
+			"wavinsentio.room.humidity":                 50.0,
+			"wavinsentio.room.humidity.setpoint":        60.0,
+		} {
+			assert.Equal(t, value, findMetric(t, rm, name).Gauge().DataPoints().At(0).DoubleValue(), name)
+		}
+
+		dewPoint := findMetric(t, rm, "wavinsentio.room.temperature.dew_point").Gauge().DataPoints().At(0)
+		assert.InDelta(t, 9.26, dewPoint.DoubleValue(), 0.01)
+
+		assert.Equal(t, int64(1), findMetric(t, rm, "wavinsentio.room.heating.demand").Gauge().DataPoints().At(0).IntValue())
+		assert.Equal(t, int64(0), findMetric(t, rm, "wavinsentio.room.cooling.demand").Gauge().DataPoints().At(0).IntValue())
+
+		assert.Equal(t, map[string]int64{
+			"TEMPERATURE_STATE_IDLE":            0,
+			"TEMPERATURE_STATE_HEATING":         1,
+			"TEMPERATURE_STATE_COOLING":         0,
+			"TEMPERATURE_STATE_BLOCKED_HEATING": 0,
+			"TEMPERATURE_STATE_BLOCKED_COOLING": 0,
+		}, stateSet(findMetric(t, rm, "wavinsentio.room.temperature_state")))
+
+		// The first observation starts the activity, but not a cycle:
+		// the room may have been heating for a while.
+		heatingCycles := findMetric(t, rm, "wavinsentio.room.heating.cycles").Sum()
+		assert.True(t, heatingCycles.IsMonotonic())
+		assert.Equal(t, pmetric.AggregationTemporalityCumulative, heatingCycles.AggregationTemporality())
+		assert.Equal(t, int64(0), heatingCycles.DataPoints().At(0).IntValue())
+		assert.Equal(t, 0.0, findMetric(t, rm, "wavinsentio.room.heating.duration").Sum().DataPoints().At(0).DoubleValue())
+		assert.Equal(t, heatingCycles.DataPoints().At(0).Timestamp(), heatingCycles.DataPoints().At(0).StartTimestamp())
+
+		assert.Equal(t, map[string]int64{
+			"DEHUMIDIFIER_STATE_IDLE":    1,
+			"DEHUMIDIFIER_STATE_RUNNING": 0,
+		}, stateSet(findMetric(t, rm, "wavinsentio.room.dehumidifier.state")))
+	})
+
+	t.Run("idle room", func(t *testing.T) {
+		rm := metrics.ResourceMetrics().At(2)
+
+		assert.Equal(t, int64(0), findMetric(t, rm, "wavinsentio.room.heating.demand").Gauge().DataPoints().At(0).IntValue())
+
+		assert.Equal(t, map[string]int64{"LOCK_MODE_UNLOCKED": 0, "LOCK_MODE_LOCKED": 1}, stateSet(findMetric(t, rm, "wavinsentio.room.lock_mode")))
+	})
+}
+
+func TestRecordStateSet(t *testing.T) {
+	recorded := make(map[string]int64)
+	record := func(_ pcommon.Timestamp, val int64, state string) {
+		recorded[state] = val
+	}
+
+	recordStateSet(0, lockModes, "", record)
+	assert.Empty(t, recorded, "unknown current state")
+
+	recordStateSet(0, lockModes, "LOCK_MODE_PARTIAL", record)
+	assert.Equal(t, map[string]int64{
+		"LOCK_MODE_UNLOCKED": 0,
+		"LOCK_MODE_LOCKED":   0,
+		"LOCK_MODE_PARTIAL":  1,
+	}, recorded, "state missing from the known states")
+}
+
+func TestDewPoint(t *testing.T) {
+	tests := []struct {
+		temperature float64
+		humidity    float64
+		expected    float64
+	}{
+		{temperature: 20, humidity: 100, expected: 20},
+		{temperature: 20, humidity: 50, expected: 9.26},
+		{temperature: 25, humidity: 60, expected: 16.69},
+	}
+
+	for _, tt := range tests {
+		assert.InDelta(t, tt.expected, dewPoint(tt.temperature, tt.humidity), 0.01)
+	}
+}
+
+func TestDevicesUnmarshaler_RoomAttributes(t *testing.T) {
+	u := newTestUnmarshaler()
+	u.rooms = RoomsConfig{Attributes: map[string]map[string]any{"Living Room": {"floor": "ground", "area": 32.5}}}
+
+	metrics, err := u.UnmarshalMetrics(loadDevices(t))
+	require.NoError(t, err)
+
+	attributes := metrics.ResourceMetrics().At(1).Resource().Attributes().AsRaw()
+	assert.Equal(t, "ground", attributes["floor"])
+	assert.Equal(t, 32.5, attributes["area"])
+	assert.Equal(t, "room-1", attributes["wavinsentio.room.id"])
+
+	assert.NotContains(t, metrics.ResourceMetrics().At(2).Resource().Attributes().AsRaw(), "floor")
+}
@@ -0,0 +1,176 @@
+// Package wavinapi is a Wavin Sentio API client shared by the Wavin
+// Sentio receiver and exporter.
+package wavinapi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/zmoog/ws/v2/ws"
+	"github.com/zmoog/ws/v2/ws/identity"
+)
+
+// ClientConfig holds the settings to access the Wavin Sentio API.
+type ClientConfig struct {
+	Endpoint  string `mapstructure:"endpoint"`
+	Username  string `mapstructure:"username"`
+	Password  string `mapstructure:"password"`
+	WebApiKey string `mapstructure:"web_api_key"`
+}
+
+// Client is a Wavin Sentio API client speaking the Connect protocol
+// with JSON messages.
+type Client struct {
+	httpClient *http.Client
+	identity   identity.Manager
+	endpoint   string
+}
+
+// NewClient creates a new Wavin Sentio API client logging in with the
+// configured credentials.
+func NewClient(cfg ClientConfig) *Client {
+	return NewClientWithIdentity(NewIdentityManager(cfg), cfg.Endpoint)
+}
+
+// NewClientWithIdentity creates a new Wavin Sentio API client getting
+// its tokens from the identity manager.
+func NewClientWithIdentity(manager identity.Manager, endpoint string) *Client {
+	return &Client{
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+		identity:   manager,
+		endpoint:   strings.TrimRight(endpoint, "/"),
+	}
+}
+
+// Login gets a token from the identity manager, logging in if needed,
+// to check the credentials.
+func (c *Client) Login(_ context.Context) error {
+	_, err := c.identity.GetToken()
+	return err
+}
+
+// ListDevices returns the devices of the account.
+func (c *Client) ListDevices(ctx context.Context) ([]ws.Device, error) {
+	var devices ws.Devices
+	if err := c.call(ctx, "ListDevices", struct{}{}, &devices); err != nil {
+		return nil, err
+	}
+	return devices.Devices, nil
+}
+
+// StatusError is returned when the API answers with an unexpected
+// status code. Code and Message are set when the body is a Connect
+// error.
+type StatusError struct {
+	StatusCode int
+	Path       string
+	Code       string
+	Message    string
+}
+
+func (e *StatusError) Error() string {
+	if e.Code != "" {
+		return fmt.Sprintf("unexpected status code %d from %s: %s: %s", e.StatusCode, e.Path, e.Code, e.Message)
+	}
+	return fmt.Sprintf("unexpected status code %d from %s: %s", e.StatusCode, e.Path, e.Message)
+}
+
+// RoomUpdate holds the room settings to change. The empty fields are
+// left unchanged.
+type RoomUpdate struct {
+	ID                  string   `json:"id"`
+	SetpointTemperature *float64 `json:"setpointTemperature,omitempty"`
+	VacationMode        string   `json:"vacationMode,omitempty"`
+	LockMode            string   `json:"lockMode,omitempty"`
+}
+
+// UpdateRooms changes the settings of the rooms in the device
+// configuration configName (the name of the device's last config).
+func (c *Client) UpdateRooms(ctx context.Context, configName string, rooms []RoomUpdate) error {
+	type sentio struct {
+		Rooms []RoomUpdate `json:"rooms"`
+	}
+	type config struct {
+		Name   string `json:"name"`
+		Sentio sentio `json:"sentio"`
+	}
+	body := struct {
+		Config config `json:"config"`
+	}{
+		Config: config{Name: configName, Sentio: sentio{Rooms: rooms}},
+	}
+
+	return c.call(ctx, "UpdateConfig", body, nil)
+}
+
+// call invokes the method with the Connect protocol, using JSON for the
+// request and the response.
+func (c *Client) call(ctx context.Context, method string, body any, v any) error {
+	token, err := c.identity.GetToken()
+	if err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.endpoint+"/"+method, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Connect-Protocol-Version", "1")
+	req.Header.Set("Authorization", "Bearer "+token.ID)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			err = urlErr.Err
+		}
+		return &TransportError{URL: req.URL.String(), Err: err}
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		statusErr := &StatusError{
+			StatusCode: resp.StatusCode,
+			Path:       req.URL.Path,
+			Message:    strings.TrimSpace(string(respBody)),
+		}
+		var connectErr struct {
+			Code    string `json:"code"`
+			Message string `json:"message"`
+		}
+		if json.Unmarshal(respBody, &connectErr) == nil && connectErr.Code != "" {
+			statusErr.Code = connectErr.Code
+			statusErr.Message = connectErr.Message
+		}
+		// The API rejected the token: log in again on the next call.
+		if resp.StatusCode == http.StatusUnauthorized {
+			if manager, ok := c.identity.(interface{ Invalidate() }); ok {
+				manager.Invalidate()
+			}
+		}
+		return statusErr
+	}
+
+	if v == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return fmt.Errorf("parse %s: %w", req.URL.Path, err)
+	}
+	return nil
+}
@@ -0,0 +1,293 @@
+package wavinapi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/zmoog/ws/v2/ws/identity"
+)
+
+const (
+	signInEndpoint = "https://identitytoolkit.googleapis.com/v1/accounts:signInWithPassword"
+	tokenEndpoint  = "https://securetoken.googleapis.com/v1/token"
+
+	// refreshMargin is how long before its expiration we refresh the
+	// token, so a slow refresh does not leave us without a valid token.
+	refreshMargin = 5 * time.Minute
+
+	// The login is suspended for initialAuthBackoff after the identity
+	// provider rejects us, doubling on each rejection up to
+	// maxAuthBackoff, so we don't hammer it with bad credentials.
+	initialAuthBackoff = 30 * time.Second
+	maxAuthBackoff     = 30 * time.Minute
+)
+
+// AuthError is returned when the identity provider rejects the login
+// or the token refresh.
+type AuthError struct {
+	StatusCode int
+	// Reason is the identity provider error, like INVALID_PASSWORD or
+	// TOKEN_EXPIRED.
+	Reason string
+}
+
+func (e *AuthError) Error() string {
+	return fmt.Sprintf("identity provider rejected the request with status code %d: %s", e.StatusCode, e.Reason)
+}
+
+// Permanent returns true if retrying can't succeed without changing the
+// credentials, like a wrong password or an invalid web API key.
+func (e *AuthError) Permanent() bool {
+	return e.StatusCode >= 400 && e.StatusCode < 500 && e.StatusCode != http.StatusTooManyRequests
+}
+
+// TransportError is returned when a request can't reach the identity
+// provider or the API.
+type TransportError struct {
+	URL string
+	Err error
+}
+
+func (e *TransportError) Error() string {
+	return fmt.Sprintf("request to %s failed: %v", e.URL, e.Err)
+}
+
+func (e *TransportError) Unwrap() error {
+	return e.Err
+}
+
+// TokenStore persists the refresh token across restarts. The store is
+// an optimization: the implementations handle their own failures, and
+// without a stored token the manager logs in with the credentials.
+type TokenStore interface {
+	LoadRefreshToken(ctx context.Context) string
+	SaveRefreshToken(ctx context.Context, refreshToken string)
+}
+
+// IdentityManager logs in to the Wavin Sentio identity provider and
+// keeps the tokens in memory. It implements identity.Manager.
+//
+// Unlike the ws in-memory manager, it refreshes the token before it
+// expires, returns typed errors, and backs off after a rejection.
+type IdentityManager struct {
+	httpClient *http.Client
+	cfg        ClientConfig
+	signInURL  string
+	tokenURL   string
+	now        func() time.Time
+	store      TokenStore
+
+	mu    sync.Mutex
+	token identity.Token
+	// loaded is true once the refresh token was read from the store.
+	loaded bool
+	// retryAt is when the login is allowed again after a rejection.
+	retryAt time.Time
+	backoff time.Duration
+	lastErr error
+}
+
+// NewIdentityManager creates an identity manager logging in with the
+// configured credentials.
+func NewIdentityManager(cfg ClientConfig) *IdentityManager {
+	return &IdentityManager{
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+		cfg:        cfg,
+		signInURL:  signInEndpoint,
+		tokenURL:   tokenEndpoint,
+		now:        time.Now,
+	}
+}
+
+// NewIdentityManagerWithStore creates an identity manager starting from
+// the refresh token in the store, and saving the new ones to it.
+func NewIdentityManagerWithStore(cfg ClientConfig, store TokenStore) *IdentityManager {
+	m := NewIdentityManager(cfg)
+	m.store = store
+	return m
+}
+
+// GetToken returns the current token, refreshing it when it is about to
+// expire. When the refresh fails, the current token is used until it
+// expires.
+func (m *IdentityManager) GetToken() (identity.Token, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := m.now()
+	valid := m.token.ID != "" && now.Before(m.token.ExpiresAt)
+	if valid && now.Before(m.token.ExpiresAt.Add(-refreshMargin)) {
+		return m.token, nil
+	}
+
+	if now.Before(m.retryAt) {
+		if valid {
+			return m.token, nil
+		}
+		return identity.Token{}, fmt.Errorf("login suspended until %s: %w", m.retryAt.Format(time.RFC3339), m.lastErr)
+	}
+
+	if m.store != nil && !m.loaded {
+		m.token.RefreshToken = m.store.LoadRefreshToken(context.Background())
+		m.loaded = true
+	}
+
+	token, err := m.login()
+	if err != nil {
+		var authErr *AuthError
+		if errors.As(err, &authErr) {
+			m.backoff = min(max(2*m.backoff, initialAuthBackoff), maxAuthBackoff)
+			m.retryAt = now.Add(m.backoff)
+			m.lastErr = err
+		}
+		if valid {
+			return m.token, nil
+		}
+		return identity.Token{}, err
+	}
+
+	if m.store != nil && token.RefreshToken != m.token.RefreshToken {
+		m.store.SaveRefreshToken(context.Background(), token.RefreshToken)
+	}
+
+	m.token = token
+	m.backoff = 0
+	m.retryAt = time.Time{}
+	m.lastErr = nil
+	return token, nil
+}
+
+// Invalidate discards the current ID token after the API rejected it.
+// The next GetToken refreshes it.
+func (m *IdentityManager) Invalidate() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.token = identity.Token{RefreshToken: m.token.RefreshToken}
+}
+
+// login refreshes the token, falling back to the credentials when there
+// is no refresh token or the identity provider rejects it, like after a
+// password change.
+func (m *IdentityManager) login() (identity.Token, error) {
+	if m.token.RefreshToken != "" {
+		token, err := m.refresh(m.token.RefreshToken)
+		var authErr *AuthError
+		if !errors.As(err, &authErr) || !authErr.Permanent() {
+			return token, err
+		}
+		m.token.RefreshToken = ""
+	}
+	return m.signIn()
+}
+
+// signIn logs in with the username and password.
+func (m *IdentityManager) signIn() (identity.Token, error) {
+	req := map[string]any{
+		"email":             m.cfg.Username,
+		"password":          m.cfg.Password,
+		"clientType":        "CLIENT_TYPE_WEB",
+		"returnSecureToken": true,
+	}
+
+	var resp struct {
+		IDToken      string `json:"idToken"`
+		RefreshToken string `json:"refreshToken"`
+		ExpiresIn    string `json:"expiresIn"`
+		LocalID      string `json:"localId"`
+	}
+	if err := m.post(m.signInURL, req, &resp); err != nil {
+		return identity.Token{}, err
+	}
+
+	return m.newToken(resp.IDToken, resp.RefreshToken, resp.ExpiresIn, resp.LocalID)
+}
+
+// refresh exchanges the refresh token for a new token.
+func (m *IdentityManager) refresh(refreshToken string) (identity.Token, error) {
+	req := map[string]any{
+		"grant_type":    "refresh_token",
+		"refresh_token": refreshToken,
+	}
+
+	var resp struct {
+		IDToken      string `json:"id_token"`
+		RefreshToken string `json:"refresh_token"`
+		ExpiresIn    string `json:"expires_in"`
+		UserID       string `json:"user_id"`
+	}
+	if err := m.post(m.tokenURL, req, &resp); err != nil {
+		return identity.Token{}, err
+	}
+
+	return m.newToken(resp.IDToken, resp.RefreshToken, resp.ExpiresIn, resp.UserID)
+}
+
+func (m *IdentityManager) newToken(id, refreshToken, expiresIn, localID string) (identity.Token, error) {
+	seconds, err := strconv.Atoi(expiresIn)
+	if err != nil {
+		return identity.Token{}, fmt.Errorf("invalid token expiration %q: %w", expiresIn, err)
+	}
+
+	return identity.Token{
+		ID:           id,
+		RefreshToken: refreshToken,
+		LocalID:      localID,
+		ExpiresIn:    seconds,
+		ExpiresAt:    m.now().Add(time.Duration(seconds) * time.Second),
+	}, nil
+}
+
+// post sends the request to the identity provider and decodes the
+// response in v.
+func (m *IdentityManager) post(endpoint string, body any, v any) error {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, endpoint+"?key="+m.cfg.WebApiKey, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := m.httpClient.Do(req)
+	if err != nil {
+		// The URL error holds the web API key: keep the cause only.
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			err = urlErr.Err
+		}
+		return &TransportError{URL: endpoint, Err: err}
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		authErr := &AuthError{StatusCode: resp.StatusCode, Reason: string(bytes.TrimSpace(respBody))}
+		var identityErr struct {
+			Error struct {
+				Message string `json:"message"`
+			} `json:"error"`
+		}
+		if json.Unmarshal(respBody, &identityErr) == nil && identityErr.Error.Message != "" {
+			authErr.Reason = identityErr.Error.Message
+		}
+		return authErr
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return fmt.Errorf("parse identity provider response: %w", err)
+	}
+	return nil
+}
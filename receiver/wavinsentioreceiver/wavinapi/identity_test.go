@@ -0,0 +1,316 @@
+package wavinapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeIdentityProvider is a fake identity provider issuing numbered
+// tokens, and a fake Wavin Sentio API accepting the last issued one.
+type fakeIdentityProvider struct {
+	mu       sync.Mutex
+	password string
+	issued   int
+	signIns  int
+	refreshs int
+	// refreshStatus, when set, is the status code of the refreshes.
+	refreshStatus int
+}
+
+func (f *fakeIdentityProvider) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if r.URL.Path == "/ListDevices" {
+		if r.Header.Get("Authorization") != fmt.Sprintf("Bearer id-%d", f.issued) {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"code":"unauthenticated","message":"invalid token"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"devices":[{"name":"devices/1234"}]}`))
+		return
+	}
+
+	if r.URL.Query().Get("key") != "my-web-api-key" {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"error":{"code":400,"message":"API key not valid. Please pass a valid API key."}}`))
+		return
+	}
+
+	var req map[string]any
+	_ = json.NewDecoder(r.Body).Decode(&req)
+
+	switch r.URL.Path {
+	case "/signIn":
+		f.signIns++
+		if req["password"] != f.password {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"error":{"code":400,"message":"INVALID_PASSWORD"}}`))
+			return
+		}
+		f.issued++
+		_ = json.NewEncoder(w).Encode(map[string]string{
+			"idToken":      fmt.Sprintf("id-%d", f.issued),
+			"refreshToken": fmt.Sprintf("refresh-%d", f.issued),
+			"expiresIn":    "3600",
+		})
+	case "/token":
+		f.refreshs++
+		if f.refreshStatus != 0 {
+			w.WriteHeader(f.refreshStatus)
+			_, _ = w.Write([]byte(`{"error":{"code":400,"message":"TOKEN_EXPIRED"}}`))
+			return
+		}
+		if req["refresh_token"] != fmt.Sprintf("refresh-%d", f.issued) {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"error":{"code":400,"message":"INVALID_REFRESH_TOKEN"}}`))
+			return
+		}
+		f.issued++
+		_ = json.NewEncoder(w).Encode(map[string]string{
+			"id_token":      fmt.Sprintf("id-%d", f.issued),
+			"refresh_token": fmt.Sprintf("refresh-%d", f.issued),
+			"expires_in":    "3600",
+		})
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func (f *fakeIdentityProvider) counts() (signIns, refreshs int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.signIns, f.refreshs
+}
+
+// fakeClock is a clock moving forward only when told to.
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+func newTestIdentityManager(t *testing.T, password string) (*IdentityManager, *fakeIdentityProvider, *fakeClock, string) {
+	t.Helper()
+
+	provider := &fakeIdentityProvider{password: "my-password"}
+	server := httptest.NewServer(provider)
+	t.Cleanup(server.Close)
+
+	clock := &fakeClock{now: time.Date(2025, 1, 12, 10, 0, 0, 0, time.UTC)}
+	m := NewIdentityManager(ClientConfig{
+		Username:  "my-username",
+		Password:  password,
+		WebApiKey: "my-web-api-key",
+	})
+	m.signInURL = server.URL + "/signIn"
+	m.tokenURL = server.URL + "/token"
+	m.now = clock.Now
+
+	return m, provider, clock, server.URL
+}
+
+func TestIdentityManager_GetToken(t *testing.T) {
+	m, provider, clock, _ := newTestIdentityManager(t, "my-password")
+
+	token, err := m.GetToken()
+	require.NoError(t, err)
+	assert.Equal(t, "id-1", token.ID)
+	assert.Equal(t, clock.now.Add(time.Hour), token.ExpiresAt)
+
+	clock.now = clock.now.Add(50 * time.Minute)
+	token, err = m.GetToken()
+	require.NoError(t, err)
+	assert.Equal(t, "id-1", token.ID, "the token is still valid")
+
+	clock.now = clock.now.Add(6 * time.Minute)
+	token, err = m.GetToken()
+	require.NoError(t, err)
+	assert.Equal(t, "id-2", token.ID, "the token is refreshed before it expires")
+	assert.Equal(t, "refresh-2", token.RefreshToken)
+
+	signIns, refreshs := provider.counts()
+	assert.Equal(t, 1, signIns)
+	assert.Equal(t, 1, refreshs)
+}
+
+func TestIdentityManager_GetTokenRefreshFailure(t *testing.T) {
+	m, provider, clock, _ := newTestIdentityManager(t, "my-password")
+
+	_, err := m.GetToken()
+	require.NoError(t, err)
+
+	provider.mu.Lock()
+	provider.refreshStatus = http.StatusServiceUnavailable
+	provider.mu.Unlock()
+
+	clock.now = clock.now.Add(56 * time.Minute)
+	token, err := m.GetToken()
+	require.NoError(t, err)
+	assert.Equal(t, "id-1", token.ID, "the current token is used until it expires")
+
+	// The identity provider is not called again during the backoff.
+	clock.now = clock.now.Add(10 * time.Second)
+	_, err = m.GetToken()
+	require.NoError(t, err)
+	_, refreshs := provider.counts()
+	assert.Equal(t, 1, refreshs)
+
+	clock.now = clock.now.Add(5 * time.Minute)
+	_, err = m.GetToken()
+	var authErr *AuthError
+	require.ErrorAs(t, err, &authErr)
+	assert.Equal(t, http.StatusServiceUnavailable, authErr.StatusCode)
+	assert.False(t, authErr.Permanent())
+}
+
+func TestIdentityManager_GetTokenRejectedRefreshToken(t *testing.T) {
+	m, provider, clock, _ := newTestIdentityManager(t, "my-password")
+
+	_, err := m.GetToken()
+	require.NoError(t, err)
+
+	provider.mu.Lock()
+	provider.refreshStatus = http.StatusBadRequest
+	provider.mu.Unlock()
+
+	clock.now = clock.now.Add(2 * time.Hour)
+	token, err := m.GetToken()
+	require.NoError(t, err)
+	assert.Equal(t, "id-2", token.ID, "logs in again with the credentials")
+
+	signIns, refreshs := provider.counts()
+	assert.Equal(t, 2, signIns)
+	assert.Equal(t, 1, refreshs)
+}
+
+// memoryTokenStore is a token store keeping the refresh token in memory.
+type memoryTokenStore struct {
+	refreshToken string
+	saves        int
+}
+
+func (s *memoryTokenStore) LoadRefreshToken(context.Context) string {
+	return s.refreshToken
+}
+
+func (s *memoryTokenStore) SaveRefreshToken(_ context.Context, refreshToken string) {
+	s.refreshToken = refreshToken
+	s.saves++
+}
+
+func TestIdentityManager_GetTokenStoredRefreshToken(t *testing.T) {
+	m, provider, clock, _ := newTestIdentityManager(t, "my-password")
+	store := &memoryTokenStore{refreshToken: "refresh-1"}
+	m.store = store
+
+	// The refresh token of a previous run.
+	provider.issued = 1
+
+	token, err := m.GetToken()
+	require.NoError(t, err)
+	assert.Equal(t, "id-2", token.ID)
+	assert.Equal(t, "refresh-2", store.refreshToken)
+
+	clock.now = clock.now.Add(30 * time.Minute)
+	_, err = m.GetToken()
+	require.NoError(t, err)
+	assert.Equal(t, 1, store.saves, "the token did not change")
+
+	signIns, refreshs := provider.counts()
+	assert.Equal(t, 0, signIns, "no password login")
+	assert.Equal(t, 1, refreshs)
+}
+
+func TestIdentityManager_GetTokenRejectedStoredRefreshToken(t *testing.T) {
+	m, provider, _, _ := newTestIdentityManager(t, "my-password")
+	store := &memoryTokenStore{refreshToken: "revoked"}
+	m.store = store
+
+	token, err := m.GetToken()
+	require.NoError(t, err)
+	assert.Equal(t, "id-1", token.ID)
+	assert.Equal(t, "refresh-1", store.refreshToken)
+
+	signIns, refreshs := provider.counts()
+	assert.Equal(t, 1, signIns)
+	assert.Equal(t, 1, refreshs)
+}
+
+func TestIdentityManager_GetTokenInvalidCredentials(t *testing.T) {
+	m, provider, clock, _ := newTestIdentityManager(t, "wrong-password")
+
+	_, err := m.GetToken()
+	var authErr *AuthError
+	require.ErrorAs(t, err, &authErr)
+	assert.Equal(t, "INVALID_PASSWORD", authErr.Reason)
+	assert.True(t, authErr.Permanent())
+
+	// The login is suspended during the backoff, doubling at each
+	// rejection.
+	for _, backoff := range []time.Duration{initialAuthBackoff, 2 * initialAuthBackoff, 4 * initialAuthBackoff} {
+		clock.now = clock.now.Add(backoff - time.Second)
+		_, err = m.GetToken()
+		require.ErrorAs(t, err, &authErr)
+		assert.Contains(t, err.Error(), "login suspended")
+
+		clock.now = clock.now.Add(time.Second)
+		_, err = m.GetToken()
+		require.ErrorAs(t, err, &authErr)
+		assert.NotContains(t, err.Error(), "login suspended")
+	}
+
+	signIns, _ := provider.counts()
+	assert.Equal(t, 4, signIns)
+}
+
+func TestIdentityManager_GetTokenTransportError(t *testing.T) {
+	m := NewIdentityManager(ClientConfig{WebApiKey: "my-web-api-key"})
+	m.signInURL = "http://127.0.0.1:0/signIn"
+
+	_, err := m.GetToken()
+	var transportErr *TransportError
+	require.ErrorAs(t, err, &transportErr)
+	assert.Equal(t, "http://127.0.0.1:0/signIn", transportErr.URL)
+	assert.NotContains(t, err.Error(), "my-web-api-key")
+}
+
+func TestClient_ListDevicesInvalidToken(t *testing.T) {
+	m, provider, _, endpoint := newTestIdentityManager(t, "my-password")
+	client := NewClientWithIdentity(m, endpoint)
+
+	devices, err := client.ListDevices(context.Background())
+	require.NoError(t, err)
+	require.Len(t, devices, 1)
+	assert.Equal(t, "devices/1234", devices[0].Name)
+
+	// The tokens are revoked: the API rejects the ID token once, then
+	// the client logs in again.
+	provider.mu.Lock()
+	provider.issued++
+	provider.mu.Unlock()
+
+	_, err = client.ListDevices(context.Background())
+	var statusErr *StatusError
+	require.ErrorAs(t, err, &statusErr)
+	assert.Equal(t, http.StatusUnauthorized, statusErr.StatusCode)
+	assert.Equal(t, "unauthenticated", statusErr.Code)
+
+	_, err = client.ListDevices(context.Background())
+	require.NoError(t, err)
+
+	signIns, refreshs := provider.counts()
+	assert.Equal(t, 2, signIns)
+	assert.Equal(t, 1, refreshs, "the revoked refresh token is rejected")
+}
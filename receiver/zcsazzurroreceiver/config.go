@@ -3,8 +3,15 @@ package zcsazzurroreceiver
 import (
 	"fmt"
 	"time"
+	// The time zones are embedded for the images without a zoneinfo
+	// database.
+	_ "time/tzdata"
 
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/scraper/scraperhelper"
+
+	"github.com/zmoog/collector/receiver/zcsazzurroreceiver/internal/metadata"
 )
 
 const (
@@ -13,13 +20,55 @@ const (
 
 type Config struct {
 	scraperhelper.ControllerConfig `mapstructure:",squash"`
-	ClientID                       string `mapstructure:"client_id"`
-	AuthKey                        string `mapstructure:"auth_key"`
-	ThingKey                       string `mapstructure:"thing_key"`
+	// ClientID and AuthKey are the credentials of the thing_key thing,
+	// and the default credentials of the things.
+	ClientID string `mapstructure:"client_id"`
+	AuthKey  string `mapstructure:"auth_key"`
+	ThingKey string `mapstructure:"thing_key"`
+	// Things lists the inverters to scrape, in place of thing_key.
+	Things []ThingConfig `mapstructure:"things"`
+	// MaxConcurrency is the maximum number of things fetched at the
+	// same time.
+	MaxConcurrency int `mapstructure:"max_concurrency"`
+	// StorageID is the storage extension keeping the last update of each
+	// thing, so a restart doesn't emit the last reading again.
+	StorageID *component.ID `mapstructure:"storage"`
+	// CacheSize is the maximum number of things tracked in memory.
+	CacheSize uint32 `mapstructure:"cache_size"`
+	// Timezone is the IANA time zone of the plants, like Europe/Rome,
+	// where the inverters reset their daily counters at midnight. When
+	// empty, the receiver uses UTC.
+	Timezone string `mapstructure:"timezone"`
+	// Modbus reads the inverter on the local network instead of the cloud
+	// API when its endpoint is set.
+	Modbus ModbusConfig `mapstructure:"modbus"`
+	// MetricsBuilderConfig configures the metrics emitted with the
+	// receiver.zcsazzurro.useSemconvMetrics feature gate.
+	metadata.MetricsBuilderConfig `mapstructure:",squash"`
+}
+
+// ThingConfig configures an inverter.
+type ThingConfig struct {
+	// Name is a friendly name of the thing, added to its resource.
+	Name string `mapstructure:"name"`
+	// ClientID and AuthKey default to the receiver ones.
+	ClientID string `mapstructure:"client_id"`
+	AuthKey  string `mapstructure:"auth_key"`
+	ThingKey string `mapstructure:"thing_key"`
+	// Attributes holds additional resource attributes of the thing, like
+	// the site or the array orientation.
+	Attributes map[string]any `mapstructure:"attributes"`
+	// Timezone defaults to the receiver one.
+	Timezone string `mapstructure:"timezone"`
 }
 
 func (cfg *Config) Validate() error {
-	if cfg.CollectionInterval < MinCollectionInterval {
+	minCollectionInterval := MinCollectionInterval
+	if cfg.local() {
+		minCollectionInterval = MinModbusCollectionInterval
+	}
+
+	if cfg.CollectionInterval < minCollectionInterval {
 		// ZCS updates data every 5 minutes, so it makes no sense
 		// to have a smaller interval.
 		//
@@ -32,17 +81,94 @@ func (cfg *Config) Validate() error {
 		//
 		// So, having a smaller interval than the update
 		// interval is not a problem.
-		return fmt.Errorf("collection_interval must be at least %s", MinCollectionInterval)
+		//
+		// The local mode reads the inverter directly, which
+		// updates its registers every few seconds.
+		return fmt.Errorf("collection_interval must be at least %s", minCollectionInterval)
 	}
 
-	if cfg.AuthKey == "" {
-		return fmt.Errorf("auth_key is required")
+	if cfg.MaxConcurrency < 1 {
+		return fmt.Errorf("max_concurrency must be at least 1")
 	}
-	if cfg.ClientID == "" {
-		return fmt.Errorf("client_id is required")
+	if cfg.CacheSize < 1 {
+		return fmt.Errorf("cache_size must be at least 1")
 	}
-	if cfg.ThingKey == "" {
+
+	if len(cfg.Things) > 0 && cfg.ThingKey != "" {
+		return fmt.Errorf("thing_key and things can't be used together")
+	}
+	if len(cfg.Things) == 0 && cfg.ThingKey == "" {
 		return fmt.Errorf("thing_key is required")
 	}
+
+	if cfg.local() {
+		if len(cfg.Things) > 0 {
+			return fmt.Errorf("modbus and things can't be used together")
+		}
+		if err := cfg.Modbus.validate(); err != nil {
+			return err
+		}
+	}
+
+	thingKeys := make(map[string]bool)
+	for i, thing := range cfg.things() {
+		var prefix string
+		if len(cfg.Things) > 0 {
+			prefix = fmt.Sprintf("things[%d].", i)
+		}
+
+		// The local mode doesn't use the cloud API.
+		if thing.AuthKey == "" && !cfg.local() {
+			return fmt.Errorf("%sauth_key is required", prefix)
+		}
+		if thing.ClientID == "" && !cfg.local() {
+			return fmt.Errorf("%sclient_id is required", prefix)
+		}
+		if thing.ThingKey == "" {
+			return fmt.Errorf("%sthing_key is required", prefix)
+		}
+		if thingKeys[thing.ThingKey] {
+			return fmt.Errorf("%sthing_key: duplicate %q", prefix, thing.ThingKey)
+		}
+		thingKeys[thing.ThingKey] = true
+
+		for name, value := range thing.Attributes {
+			if err := pcommon.NewValueEmpty().FromRaw(value); err != nil {
+				return fmt.Errorf("%sattributes: %q: %w", prefix, name, err)
+			}
+		}
+
+		if _, err := time.LoadLocation(thing.Timezone); err != nil {
+			return fmt.Errorf("%stimezone: %w", prefix, err)
+		}
+	}
 	return nil
 }
+
+// things returns the things to scrape: the thing_key one, or the things
+// with the receiver credentials as defaults.
+func (cfg *Config) things() []ThingConfig {
+	if cfg.ThingKey != "" {
+		return []ThingConfig{{ClientID: cfg.ClientID, AuthKey: cfg.AuthKey, ThingKey: cfg.ThingKey, Timezone: cfg.Timezone}}
+	}
+
+	things := make([]ThingConfig, 0, len(cfg.Things))
+	for _, thing := range cfg.Things {
+		if thing.ClientID == "" {
+			thing.ClientID = cfg.ClientID
+		}
+		if thing.AuthKey == "" {
+			thing.AuthKey = cfg.AuthKey
+		}
+		if thing.Timezone == "" {
+			thing.Timezone = cfg.Timezone
+		}
+		things = append(things, thing)
+	}
+	return things
+}
+
+// local returns true if the receiver reads the inverter over Modbus.
+func (cfg *Config) local() bool {
+	return cfg.Modbus.Endpoint != ""
+}
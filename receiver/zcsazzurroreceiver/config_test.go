@@ -0,0 +1,110 @@
+package zcsazzurroreceiver
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name     string
+		modify   func(cfg *Config)
+		expected string
+	}{
+		{name: "single thing", modify: func(cfg *Config) { cfg.Things = nil; cfg.ThingKey = "thing-1" }},
+		{name: "things", modify: func(*Config) {}},
+		{name: "no thing", modify: func(cfg *Config) { cfg.Things = nil }, expected: "thing_key is required"},
+		{name: "single thing without auth key", modify: func(cfg *Config) { cfg.Things = nil; cfg.ThingKey = "thing-1"; cfg.AuthKey = "" }, expected: "auth_key is required"},
+		{name: "both", modify: func(cfg *Config) { cfg.ThingKey = "thing-1" }, expected: "thing_key and things can't be used together"},
+		{name: "thing without auth key", modify: func(cfg *Config) { cfg.AuthKey = "" }, expected: "things[1].auth_key is required"},
+		{name: "thing without key", modify: func(cfg *Config) { cfg.Things[1].ThingKey = "" }, expected: "things[1].thing_key is required"},
+		{name: "duplicate thing", modify: func(cfg *Config) { cfg.Things[1].ThingKey = "thing-1" }, expected: `things[1].thing_key: duplicate "thing-1"`},
+		{name: "max concurrency", modify: func(cfg *Config) { cfg.MaxConcurrency = 0 }, expected: "max_concurrency must be at least 1"},
+		{name: "cache size", modify: func(cfg *Config) { cfg.CacheSize = 0 }, expected: "cache_size must be at least 1"},
+		{name: "timezone", modify: func(cfg *Config) { cfg.Things[1].Timezone = "UTC" }},
+		{name: "no timezone", modify: func(cfg *Config) { cfg.Timezone = "" }},
+		{
+			name:     "invalid timezone",
+			modify:   func(cfg *Config) { cfg.Timezone = "Europe/Atlantis" },
+			expected: "things[0].timezone: unknown time zone Europe/Atlantis",
+		},
+		{
+			name: "modbus",
+			modify: func(cfg *Config) {
+				cfg.Things = nil
+				cfg.ThingKey = "thing-1"
+				cfg.AuthKey = ""
+				cfg.CollectionInterval = 5 * time.Second
+				cfg.Modbus = newTestModbusConfig("192.168.1.20:502")
+			},
+		},
+		{
+			name: "modbus interval",
+			modify: func(cfg *Config) {
+				cfg.Things = nil
+				cfg.ThingKey = "thing-1"
+				cfg.CollectionInterval = 500 * time.Millisecond
+				cfg.Modbus = newTestModbusConfig("192.168.1.20:502")
+			},
+			expected: "collection_interval must be at least 1s",
+		},
+		{
+			name:     "modbus with things",
+			modify:   func(cfg *Config) { cfg.Modbus = newTestModbusConfig("192.168.1.20:502") },
+			expected: "modbus and things can't be used together",
+		},
+		{
+			name: "modbus unknown register",
+			modify: func(cfg *Config) {
+				cfg.Things = nil
+				cfg.ThingKey = "thing-1"
+				cfg.Modbus = newTestModbusConfig("192.168.1.20:502")
+				cfg.Modbus.Registers = map[string]RegisterConfig{"power_total": {Address: 5}}
+			},
+			expected: `modbus.registers: unknown register "power_total"`,
+		},
+		{
+			name: "modbus invalid register",
+			modify: func(cfg *Config) {
+				cfg.Things = nil
+				cfg.ThingKey = "thing-1"
+				cfg.Modbus = newTestModbusConfig("192.168.1.20:502")
+				cfg.Modbus.Registers = map[string]RegisterConfig{"power_importing": {Address: 1, Words: 4}}
+			},
+			expected: "modbus.registers.power_importing: words must be 1 or 2",
+		},
+		{
+			name: "modbus unknown register type",
+			modify: func(cfg *Config) {
+				cfg.Things = nil
+				cfg.ThingKey = "thing-1"
+				cfg.Modbus = newTestModbusConfig("192.168.1.20:502")
+				cfg.Modbus.Registers = map[string]RegisterConfig{"power_importing": {Address: 1, Type: "coil"}}
+			},
+			expected: `modbus.registers.power_importing: unknown register type "coil"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			cfg.ClientID = "my-client-id"
+			cfg.AuthKey = "my-auth-key"
+			cfg.Timezone = "Europe/Rome"
+			cfg.Things = []ThingConfig{
+				{ThingKey: "thing-1", AuthKey: "other-auth-key"},
+				{ThingKey: "thing-2"},
+			}
+			tt.modify(cfg)
+
+			err := cfg.Validate()
+			if tt.expected == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.expected)
+			}
+		})
+	}
+}
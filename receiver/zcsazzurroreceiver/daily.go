@@ -0,0 +1,93 @@
+package zcsazzurroreceiver
+
+import (
+	"time"
+
+	"github.com/elastic/go-freelru"
+
+	"github.com/zmoog/zcs/azzurro"
+)
+
+// startOfDay returns the midnight of t in loc, which is not 24 hours
+// before the next one on the days the daylight saving time changes.
+func startOfDay(t time.Time, loc *time.Location) time.Time {
+	year, month, day := t.In(loc).Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, loc)
+}
+
+// dailyCounters are the daily energy counters of a thing seen in a
+// scrape, with the start time of their current cycle.
+type dailyCounters struct {
+	start      time.Time
+	lastUpdate time.Time
+	values     [7]float64
+}
+
+func dailyValues(metrics azzurro.InverterMetrics) [7]float64 {
+	return [7]float64{
+		metrics.EnergyAutoconsuming,
+		metrics.EnergyCharging,
+		metrics.EnergyConsuming,
+		metrics.EnergyDischarging,
+		metrics.EnergyExporting,
+		metrics.EnergyGenerating,
+		metrics.EnergyImporting,
+	}
+}
+
+// dailyResetTracker tracks the start time of the daily counters of each
+// thing. The inverter clock doesn't always follow the daylight saving
+// time, so the start moves when the counters actually reset, and not at
+// the midnight of the time zone.
+type dailyResetTracker struct {
+	cache *freelru.SyncedLRU[string, dailyCounters]
+}
+
+func newDailyResetTracker(capacity uint32) (*dailyResetTracker, error) {
+	cache, err := freelru.NewSynced[string, dailyCounters](capacity, hashString)
+	if err != nil {
+		return nil, err
+	}
+	return &dailyResetTracker{cache: cache}, nil
+}
+
+// start returns the start time of the daily counters of the thing. It
+// is the local midnight for the first scrape, and it moves when a counter
+// drops: to the local midnight, when it is after the previous scrape, or
+// else to the previous scrape. The counters reset at least once a day,
+// so the start also moves when the scrapes are a day or more apart.
+func (t *dailyResetTracker) start(thingKey string, metrics azzurro.InverterMetrics, loc *time.Location) time.Time {
+	current := dailyCounters{
+		start:      startOfDay(metrics.LastUpdate, loc),
+		lastUpdate: metrics.LastUpdate,
+		values:     dailyValues(metrics),
+	}
+
+	previous, found := t.cache.Get(thingKey)
+	switch {
+	case !found:
+	case !metrics.LastUpdate.After(previous.lastUpdate):
+		// An old sample, outside of the tracked cycle.
+		return current.start
+	case metrics.LastUpdate.Sub(previous.lastUpdate) >= 24*time.Hour:
+	case dropped(previous.values, current.values):
+		if !current.start.After(previous.lastUpdate) {
+			current.start = previous.lastUpdate
+		}
+	default:
+		current.start = previous.start
+	}
+
+	t.cache.Add(thingKey, current)
+	return current.start
+}
+
+// dropped returns true if any counter is lower than before.
+func dropped(previous, current [7]float64) bool {
+	for i := range current {
+		if current[i] < previous[i] {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,90 @@
+package zcsazzurroreceiver
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zmoog/zcs/azzurro"
+)
+
+func TestStartOfDay(t *testing.T) {
+	rome, err := time.LoadLocation("Europe/Rome")
+	require.NoError(t, err)
+
+	// The daylight saving time ends on 2024-10-27: the day lasts 25 hours.
+	assert.Equal(t, time.Date(2024, 10, 26, 22, 0, 0, 0, time.UTC), startOfDay(time.Date(2024, 10, 27, 12, 0, 0, 0, time.UTC), rome).UTC())
+	assert.Equal(t, time.Date(2024, 10, 27, 23, 0, 0, 0, time.UTC), startOfDay(time.Date(2024, 10, 28, 12, 0, 0, 0, time.UTC), rome).UTC())
+	// Before midnight UTC, it is already the next day in Rome.
+	assert.Equal(t, time.Date(2024, 10, 27, 23, 0, 0, 0, time.UTC), startOfDay(time.Date(2024, 10, 27, 23, 30, 0, 0, time.UTC), rome).UTC())
+}
+
+func TestDailyResetTracker_Start(t *testing.T) {
+	rome, err := time.LoadLocation("Europe/Rome")
+	require.NoError(t, err)
+
+	sample := func(lastUpdate time.Time, generating float64) azzurro.InverterMetrics {
+		return azzurro.InverterMetrics{LastUpdate: lastUpdate, EnergyGenerating: generating, EnergyConsuming: 5}
+	}
+	midnight := time.Date(2024, 10, 26, 22, 0, 0, 0, time.UTC) // 2024-10-27 in Rome
+
+	tests := []struct {
+		name     string
+		samples  []azzurro.InverterMetrics
+		expected time.Time
+	}{
+		{
+			name:     "first scrape",
+			samples:  []azzurro.InverterMetrics{sample(midnight.Add(10*time.Hour), 12)},
+			expected: midnight,
+		},
+		{
+			name: "no reset across the local midnight",
+			samples: []azzurro.InverterMetrics{
+				sample(midnight.Add(-5*time.Minute), 20),
+				sample(midnight.Add(5*time.Minute), 20),
+			},
+			expected: midnight.Add(-24 * time.Hour),
+		},
+		{
+			name: "reset at the local midnight",
+			samples: []azzurro.InverterMetrics{
+				sample(midnight.Add(-5*time.Minute), 20),
+				sample(midnight.Add(5*time.Minute), 0),
+			},
+			expected: midnight,
+		},
+		{
+			name: "reset an hour late",
+			samples: []azzurro.InverterMetrics{
+				sample(midnight.Add(-5*time.Minute), 20),
+				sample(midnight.Add(55*time.Minute), 20),
+				sample(midnight.Add(65*time.Minute), 0),
+			},
+			expected: midnight.Add(55 * time.Minute),
+		},
+		{
+			name: "scrapes a day apart",
+			samples: []azzurro.InverterMetrics{
+				sample(midnight.Add(-14*time.Hour), 10),
+				sample(midnight.Add(10*time.Hour), 12),
+			},
+			expected: midnight,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tracker, err := newDailyResetTracker(10)
+			require.NoError(t, err)
+
+			var start time.Time
+			for _, s := range tt.samples {
+				start = tracker.start("thing-1", s, rome)
+			}
+			assert.Equal(t, tt.expected, start.UTC())
+		})
+	}
+}
@@ -27,6 +27,13 @@ func createDefaultConfig() component.Config {
 	cfg.CollectionInterval = DefaultCollectionInterval
 	return &Config{
 		ControllerConfig: cfg,
+		MaxConcurrency:   4,
+		CacheSize:        1000,
+		Modbus: ModbusConfig{
+			UnitID:  1,
+			Timeout: 10 * time.Second,
+		},
+		MetricsBuilderConfig: metadata.DefaultMetricsBuilderConfig(),
 	}
 }
 
@@ -45,8 +52,7 @@ func createMetricsReceiver(ctx context.Context, settings receiver.Settings, base
 		return nil, fmt.Errorf("invalid config type")
 	}
 
-	// Initialize LRU cache with capacity for 1000 things
-	cache, err := freelru.NewSynced[string, time.Time](1000, hashString)
+	cache, err := freelru.NewSynced[string, time.Time](cfg.CacheSize, hashString)
 	if err != nil {
 		return nil, err
 	}
@@ -56,6 +62,7 @@ func createMetricsReceiver(ctx context.Context, settings receiver.Settings, base
 	metrics, err := scraper.NewMetrics(
 		zcsazzurroScraper.scrape,
 		scraper.WithStart(zcsazzurroScraper.start),
+		scraper.WithShutdown(zcsazzurroScraper.shutdown),
 	)
 	if err != nil {
 		return nil, err
@@ -0,0 +1,124 @@
+// Code generated by mdatagen. DO NOT EDIT.
+
+package metadata
+
+import (
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/filter"
+)
+
+// MetricConfig provides common config for a particular metric.
+type MetricConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+
+	enabledSetByUser bool
+}
+
+func (ms *MetricConfig) Unmarshal(parser *confmap.Conf) error {
+	if parser == nil {
+		return nil
+	}
+	err := parser.Unmarshal(ms)
+	if err != nil {
+		return err
+	}
+	ms.enabledSetByUser = parser.IsSet("enabled")
+	return nil
+}
+
+// MetricsConfig provides config for zcsazzurro metrics.
+type MetricsConfig struct {
+	ZcsazzurroBatteryCharge              MetricConfig `mapstructure:"zcsazzurro.battery.charge"`
+	ZcsazzurroBatteryCycles              MetricConfig `mapstructure:"zcsazzurro.battery.cycles"`
+	ZcsazzurroBatteryRoundTripEfficiency MetricConfig `mapstructure:"zcsazzurro.battery.round_trip_efficiency"`
+	ZcsazzurroEnergy                     MetricConfig `mapstructure:"zcsazzurro.energy"`
+	ZcsazzurroGridDependency             MetricConfig `mapstructure:"zcsazzurro.grid_dependency"`
+	ZcsazzurroPower                      MetricConfig `mapstructure:"zcsazzurro.power"`
+	ZcsazzurroSelfConsumption            MetricConfig `mapstructure:"zcsazzurro.self_consumption"`
+	ZcsazzurroSelfSufficiency            MetricConfig `mapstructure:"zcsazzurro.self_sufficiency"`
+}
+
+func DefaultMetricsConfig() MetricsConfig {
+	return MetricsConfig{
+		ZcsazzurroBatteryCharge: MetricConfig{
+			Enabled: true,
+		},
+		ZcsazzurroBatteryCycles: MetricConfig{
+			Enabled: true,
+		},
+		ZcsazzurroBatteryRoundTripEfficiency: MetricConfig{
+			Enabled: true,
+		},
+		ZcsazzurroEnergy: MetricConfig{
+			Enabled: true,
+		},
+		ZcsazzurroGridDependency: MetricConfig{
+			Enabled: true,
+		},
+		ZcsazzurroPower: MetricConfig{
+			Enabled: true,
+		},
+		ZcsazzurroSelfConsumption: MetricConfig{
+			Enabled: true,
+		},
+		ZcsazzurroSelfSufficiency: MetricConfig{
+			Enabled: true,
+		},
+	}
+}
+
+// ResourceAttributeConfig provides common config for a particular resource attribute.
+type ResourceAttributeConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+	// Experimental: MetricsInclude defines a list of filters for attribute values.
+	// If the list is not empty, only metrics with matching resource attribute values will be emitted.
+	MetricsInclude []filter.Config `mapstructure:"metrics_include"`
+	// Experimental: MetricsExclude defines a list of filters for attribute values.
+	// If the list is not empty, metrics with matching resource attribute values will not be emitted.
+	// MetricsInclude has higher priority than MetricsExclude.
+	MetricsExclude []filter.Config `mapstructure:"metrics_exclude"`
+
+	enabledSetByUser bool
+}
+
+func (rac *ResourceAttributeConfig) Unmarshal(parser *confmap.Conf) error {
+	if parser == nil {
+		return nil
+	}
+	err := parser.Unmarshal(rac)
+	if err != nil {
+		return err
+	}
+	rac.enabledSetByUser = parser.IsSet("enabled")
+	return nil
+}
+
+// ResourceAttributesConfig provides config for zcsazzurro resource attributes.
+type ResourceAttributesConfig struct {
+	DeviceID           ResourceAttributeConfig `mapstructure:"device.id"`
+	DeviceManufacturer ResourceAttributeConfig `mapstructure:"device.manufacturer"`
+}
+
+func DefaultResourceAttributesConfig() ResourceAttributesConfig {
+	return ResourceAttributesConfig{
+		DeviceID: ResourceAttributeConfig{
+			Enabled: true,
+		},
+		DeviceManufacturer: ResourceAttributeConfig{
+			Enabled: true,
+		},
+	}
+}
+
+// MetricsBuilderConfig is a configuration for zcsazzurro metrics builder.
+type MetricsBuilderConfig struct {
+	Metrics            MetricsConfig            `mapstructure:"metrics"`
+	ResourceAttributes ResourceAttributesConfig `mapstructure:"resource_attributes"`
+}
+
+func DefaultMetricsBuilderConfig() MetricsBuilderConfig {
+	return MetricsBuilderConfig{
+		Metrics:            DefaultMetricsConfig(),
+		ResourceAttributes: DefaultResourceAttributesConfig(),
+	}
+}
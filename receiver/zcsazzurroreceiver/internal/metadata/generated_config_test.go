@@ -0,0 +1,127 @@
+// Code generated by mdatagen. DO NOT EDIT.
+
+package metadata
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+)
+
+func TestMetricsBuilderConfig(t *testing.T) {
+	tests := []struct {
+		name string
+		want MetricsBuilderConfig
+	}{
+		{
+			name: "default",
+			want: DefaultMetricsBuilderConfig(),
+		},
+		{
+			name: "all_set",
+			want: MetricsBuilderConfig{
+				Metrics: MetricsConfig{
+					ZcsazzurroBatteryCharge:              MetricConfig{Enabled: true},
+					ZcsazzurroBatteryCycles:              MetricConfig{Enabled: true},
+					ZcsazzurroBatteryRoundTripEfficiency: MetricConfig{Enabled: true},
+					ZcsazzurroEnergy:                     MetricConfig{Enabled: true},
+					ZcsazzurroGridDependency:             MetricConfig{Enabled: true},
+					ZcsazzurroPower:                      MetricConfig{Enabled: true},
+					ZcsazzurroSelfConsumption:            MetricConfig{Enabled: true},
+					ZcsazzurroSelfSufficiency:            MetricConfig{Enabled: true},
+				},
+				ResourceAttributes: ResourceAttributesConfig{
+					DeviceID:           ResourceAttributeConfig{Enabled: true},
+					DeviceManufacturer: ResourceAttributeConfig{Enabled: true},
+				},
+			},
+		},
+		{
+			name: "none_set",
+			want: MetricsBuilderConfig{
+				Metrics: MetricsConfig{
+					ZcsazzurroBatteryCharge:              MetricConfig{Enabled: false},
+					ZcsazzurroBatteryCycles:              MetricConfig{Enabled: false},
+					ZcsazzurroBatteryRoundTripEfficiency: MetricConfig{Enabled: false},
+					ZcsazzurroEnergy:                     MetricConfig{Enabled: false},
+					ZcsazzurroGridDependency:             MetricConfig{Enabled: false},
+					ZcsazzurroPower:                      MetricConfig{Enabled: false},
+					ZcsazzurroSelfConsumption:            MetricConfig{Enabled: false},
+					ZcsazzurroSelfSufficiency:            MetricConfig{Enabled: false},
+				},
+				ResourceAttributes: ResourceAttributesConfig{
+					DeviceID:           ResourceAttributeConfig{Enabled: false},
+					DeviceManufacturer: ResourceAttributeConfig{Enabled: false},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := loadMetricsBuilderConfig(t, tt.name)
+			diff := cmp.Diff(tt.want, cfg, cmpopts.IgnoreUnexported(MetricConfig{}, ResourceAttributeConfig{}))
+			require.Emptyf(t, diff, "Config mismatch (-expected +actual):\n%s", diff)
+		})
+	}
+}
+
+func loadMetricsBuilderConfig(t *testing.T, name string) MetricsBuilderConfig {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+	sub, err := cm.Sub(name)
+	require.NoError(t, err)
+	cfg := DefaultMetricsBuilderConfig()
+	require.NoError(t, sub.Unmarshal(&cfg, confmap.WithIgnoreUnused()))
+	return cfg
+}
+
+func TestResourceAttributesConfig(t *testing.T) {
+	tests := []struct {
+		name string
+		want ResourceAttributesConfig
+	}{
+		{
+			name: "default",
+			want: DefaultResourceAttributesConfig(),
+		},
+		{
+			name: "all_set",
+			want: ResourceAttributesConfig{
+				DeviceID:           ResourceAttributeConfig{Enabled: true},
+				DeviceManufacturer: ResourceAttributeConfig{Enabled: true},
+			},
+		},
+		{
+			name: "none_set",
+			want: ResourceAttributesConfig{
+				DeviceID:           ResourceAttributeConfig{Enabled: false},
+				DeviceManufacturer: ResourceAttributeConfig{Enabled: false},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := loadResourceAttributesConfig(t, tt.name)
+			diff := cmp.Diff(tt.want, cfg, cmpopts.IgnoreUnexported(ResourceAttributeConfig{}))
+			require.Emptyf(t, diff, "Config mismatch (-expected +actual):\n%s", diff)
+		})
+	}
+}
+
+func loadResourceAttributesConfig(t *testing.T, name string) ResourceAttributesConfig {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+	sub, err := cm.Sub(name)
+	require.NoError(t, err)
+	sub, err = sub.Sub("resource_attributes")
+	require.NoError(t, err)
+	cfg := DefaultResourceAttributesConfig()
+	require.NoError(t, sub.Unmarshal(&cfg))
+	return cfg
+}
@@ -0,0 +1,805 @@
+// Code generated by mdatagen. DO NOT EDIT.
+
+package metadata
+
+import (
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/filter"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/receiver"
+)
+
+// AttributeDirection specifies the value direction attribute.
+type AttributeDirection int
+
+const (
+	_ AttributeDirection = iota
+	AttributeDirectionAutoconsuming
+	AttributeDirectionCharging
+	AttributeDirectionConsuming
+	AttributeDirectionDischarging
+	AttributeDirectionExporting
+	AttributeDirectionGenerating
+	AttributeDirectionImporting
+)
+
+// String returns the string representation of the AttributeDirection.
+func (av AttributeDirection) String() string {
+	switch av {
+	case AttributeDirectionAutoconsuming:
+		return "autoconsuming"
+	case AttributeDirectionCharging:
+		return "charging"
+	case AttributeDirectionConsuming:
+		return "consuming"
+	case AttributeDirectionDischarging:
+		return "discharging"
+	case AttributeDirectionExporting:
+		return "exporting"
+	case AttributeDirectionGenerating:
+		return "generating"
+	case AttributeDirectionImporting:
+		return "importing"
+	}
+	return ""
+}
+
+// MapAttributeDirection is a helper map of string to AttributeDirection attribute value.
+var MapAttributeDirection = map[string]AttributeDirection{
+	"autoconsuming": AttributeDirectionAutoconsuming,
+	"charging":      AttributeDirectionCharging,
+	"consuming":     AttributeDirectionConsuming,
+	"discharging":   AttributeDirectionDischarging,
+	"exporting":     AttributeDirectionExporting,
+	"generating":    AttributeDirectionGenerating,
+	"importing":     AttributeDirectionImporting,
+}
+
+// AttributeFlow specifies the value flow attribute.
+type AttributeFlow int
+
+const (
+	_ AttributeFlow = iota
+	AttributeFlowAutoconsuming
+	AttributeFlowCharging
+	AttributeFlowConsuming
+	AttributeFlowDischarging
+	AttributeFlowExporting
+	AttributeFlowGenerating
+	AttributeFlowImporting
+)
+
+// String returns the string representation of the AttributeFlow.
+func (av AttributeFlow) String() string {
+	switch av {
+	case AttributeFlowAutoconsuming:
+		return "autoconsuming"
+	case AttributeFlowCharging:
+		return "charging"
+	case AttributeFlowConsuming:
+		return "consuming"
+	case AttributeFlowDischarging:
+		return "discharging"
+	case AttributeFlowExporting:
+		return "exporting"
+	case AttributeFlowGenerating:
+		return "generating"
+	case AttributeFlowImporting:
+		return "importing"
+	}
+	return ""
+}
+
+// MapAttributeFlow is a helper map of string to AttributeFlow attribute value.
+var MapAttributeFlow = map[string]AttributeFlow{
+	"autoconsuming": AttributeFlowAutoconsuming,
+	"charging":      AttributeFlowCharging,
+	"consuming":     AttributeFlowConsuming,
+	"discharging":   AttributeFlowDischarging,
+	"exporting":     AttributeFlowExporting,
+	"generating":    AttributeFlowGenerating,
+	"importing":     AttributeFlowImporting,
+}
+
+// AttributePeriod specifies the value period attribute.
+type AttributePeriod int
+
+const (
+	_ AttributePeriod = iota
+	AttributePeriodDay
+	AttributePeriodLifetime
+)
+
+// String returns the string representation of the AttributePeriod.
+func (av AttributePeriod) String() string {
+	switch av {
+	case AttributePeriodDay:
+		return "day"
+	case AttributePeriodLifetime:
+		return "lifetime"
+	}
+	return ""
+}
+
+// MapAttributePeriod is a helper map of string to AttributePeriod attribute value.
+var MapAttributePeriod = map[string]AttributePeriod{
+	"day":      AttributePeriodDay,
+	"lifetime": AttributePeriodLifetime,
+}
+
+var MetricsInfo = metricsInfo{
+	ZcsazzurroBatteryCharge: metricInfo{
+		Name: "zcsazzurro.battery.charge",
+	},
+	ZcsazzurroBatteryCycles: metricInfo{
+		Name: "zcsazzurro.battery.cycles",
+	},
+	ZcsazzurroBatteryRoundTripEfficiency: metricInfo{
+		Name: "zcsazzurro.battery.round_trip_efficiency",
+	},
+	ZcsazzurroEnergy: metricInfo{
+		Name: "zcsazzurro.energy",
+	},
+	ZcsazzurroGridDependency: metricInfo{
+		Name: "zcsazzurro.grid_dependency",
+	},
+	ZcsazzurroPower: metricInfo{
+		Name: "zcsazzurro.power",
+	},
+	ZcsazzurroSelfConsumption: metricInfo{
+		Name: "zcsazzurro.self_consumption",
+	},
+	ZcsazzurroSelfSufficiency: metricInfo{
+		Name: "zcsazzurro.self_sufficiency",
+	},
+}
+
+type metricsInfo struct {
+	ZcsazzurroBatteryCharge              metricInfo
+	ZcsazzurroBatteryCycles              metricInfo
+	ZcsazzurroBatteryRoundTripEfficiency metricInfo
+	ZcsazzurroEnergy                     metricInfo
+	ZcsazzurroGridDependency             metricInfo
+	ZcsazzurroPower                      metricInfo
+	ZcsazzurroSelfConsumption            metricInfo
+	ZcsazzurroSelfSufficiency            metricInfo
+}
+
+type metricInfo struct {
+	Name string
+}
+
+type metricZcsazzurroBatteryCharge struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills zcsazzurro.battery.charge metric with initial data.
+func (m *metricZcsazzurroBatteryCharge) init() {
+	m.data.SetName("zcsazzurro.battery.charge")
+	m.data.SetDescription("Battery state of charge.")
+	m.data.SetUnit("1")
+	m.data.SetEmptyGauge()
+}
+
+func (m *metricZcsazzurroBatteryCharge) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val float64) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Gauge().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetDoubleValue(val)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricZcsazzurroBatteryCharge) updateCapacity() {
+	if m.data.Gauge().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Gauge().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricZcsazzurroBatteryCharge) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Gauge().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricZcsazzurroBatteryCharge(cfg MetricConfig) metricZcsazzurroBatteryCharge {
+	m := metricZcsazzurroBatteryCharge{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricZcsazzurroBatteryCycles struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills zcsazzurro.battery.cycles metric with initial data.
+func (m *metricZcsazzurroBatteryCycles) init() {
+	m.data.SetName("zcsazzurro.battery.cycles")
+	m.data.SetDescription("Number of charge cycles of the battery.")
+	m.data.SetUnit("{cycle}")
+	m.data.SetEmptySum()
+	m.data.Sum().SetIsMonotonic(true)
+	m.data.Sum().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+}
+
+func (m *metricZcsazzurroBatteryCycles) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Sum().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(val)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricZcsazzurroBatteryCycles) updateCapacity() {
+	if m.data.Sum().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Sum().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricZcsazzurroBatteryCycles) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Sum().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricZcsazzurroBatteryCycles(cfg MetricConfig) metricZcsazzurroBatteryCycles {
+	m := metricZcsazzurroBatteryCycles{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricZcsazzurroBatteryRoundTripEfficiency struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills zcsazzurro.battery.round_trip_efficiency metric with initial data.
+func (m *metricZcsazzurroBatteryRoundTripEfficiency) init() {
+	m.data.SetName("zcsazzurro.battery.round_trip_efficiency")
+	m.data.SetDescription("Energy discharged from the battery over the energy charged.")
+	m.data.SetUnit("1")
+	m.data.SetEmptyGauge()
+	m.data.Gauge().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricZcsazzurroBatteryRoundTripEfficiency) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val float64, periodAttributeValue string) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Gauge().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetDoubleValue(val)
+	dp.Attributes().PutStr("period", periodAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricZcsazzurroBatteryRoundTripEfficiency) updateCapacity() {
+	if m.data.Gauge().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Gauge().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricZcsazzurroBatteryRoundTripEfficiency) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Gauge().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricZcsazzurroBatteryRoundTripEfficiency(cfg MetricConfig) metricZcsazzurroBatteryRoundTripEfficiency {
+	m := metricZcsazzurroBatteryRoundTripEfficiency{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricZcsazzurroEnergy struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills zcsazzurro.energy metric with initial data.
+func (m *metricZcsazzurroEnergy) init() {
+	m.data.SetName("zcsazzurro.energy")
+	m.data.SetDescription("Energy flowed through the inverter since the start of the period.")
+	m.data.SetUnit("Wh")
+	m.data.SetEmptySum()
+	m.data.Sum().SetIsMonotonic(true)
+	m.data.Sum().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+	m.data.Sum().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricZcsazzurroEnergy) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val float64, flowAttributeValue string, periodAttributeValue string) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Sum().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetDoubleValue(val)
+	dp.Attributes().PutStr("flow", flowAttributeValue)
+	dp.Attributes().PutStr("period", periodAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricZcsazzurroEnergy) updateCapacity() {
+	if m.data.Sum().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Sum().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricZcsazzurroEnergy) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Sum().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricZcsazzurroEnergy(cfg MetricConfig) metricZcsazzurroEnergy {
+	m := metricZcsazzurroEnergy{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricZcsazzurroGridDependency struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills zcsazzurro.grid_dependency metric with initial data.
+func (m *metricZcsazzurroGridDependency) init() {
+	m.data.SetName("zcsazzurro.grid_dependency")
+	m.data.SetDescription("Share of the consumption imported from the grid.")
+	m.data.SetUnit("1")
+	m.data.SetEmptyGauge()
+	m.data.Gauge().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricZcsazzurroGridDependency) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val float64, periodAttributeValue string) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Gauge().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetDoubleValue(val)
+	dp.Attributes().PutStr("period", periodAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricZcsazzurroGridDependency) updateCapacity() {
+	if m.data.Gauge().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Gauge().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricZcsazzurroGridDependency) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Gauge().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricZcsazzurroGridDependency(cfg MetricConfig) metricZcsazzurroGridDependency {
+	m := metricZcsazzurroGridDependency{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricZcsazzurroPower struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills zcsazzurro.power metric with initial data.
+func (m *metricZcsazzurroPower) init() {
+	m.data.SetName("zcsazzurro.power")
+	m.data.SetDescription("Power flowing through the inverter.")
+	m.data.SetUnit("W")
+	m.data.SetEmptyGauge()
+	m.data.Gauge().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricZcsazzurroPower) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val float64, directionAttributeValue string) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Gauge().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetDoubleValue(val)
+	dp.Attributes().PutStr("direction", directionAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricZcsazzurroPower) updateCapacity() {
+	if m.data.Gauge().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Gauge().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricZcsazzurroPower) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Gauge().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricZcsazzurroPower(cfg MetricConfig) metricZcsazzurroPower {
+	m := metricZcsazzurroPower{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricZcsazzurroSelfConsumption struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills zcsazzurro.self_consumption metric with initial data.
+func (m *metricZcsazzurroSelfConsumption) init() {
+	m.data.SetName("zcsazzurro.self_consumption")
+	m.data.SetDescription("Share of the generation not exported to the grid.")
+	m.data.SetUnit("1")
+	m.data.SetEmptyGauge()
+	m.data.Gauge().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricZcsazzurroSelfConsumption) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val float64, periodAttributeValue string) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Gauge().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetDoubleValue(val)
+	dp.Attributes().PutStr("period", periodAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricZcsazzurroSelfConsumption) updateCapacity() {
+	if m.data.Gauge().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Gauge().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricZcsazzurroSelfConsumption) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Gauge().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricZcsazzurroSelfConsumption(cfg MetricConfig) metricZcsazzurroSelfConsumption {
+	m := metricZcsazzurroSelfConsumption{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricZcsazzurroSelfSufficiency struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills zcsazzurro.self_sufficiency metric with initial data.
+func (m *metricZcsazzurroSelfSufficiency) init() {
+	m.data.SetName("zcsazzurro.self_sufficiency")
+	m.data.SetDescription("Share of the consumption not imported from the grid.")
+	m.data.SetUnit("1")
+	m.data.SetEmptyGauge()
+	m.data.Gauge().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricZcsazzurroSelfSufficiency) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val float64, periodAttributeValue string) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Gauge().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetDoubleValue(val)
+	dp.Attributes().PutStr("period", periodAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricZcsazzurroSelfSufficiency) updateCapacity() {
+	if m.data.Gauge().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Gauge().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricZcsazzurroSelfSufficiency) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Gauge().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricZcsazzurroSelfSufficiency(cfg MetricConfig) metricZcsazzurroSelfSufficiency {
+	m := metricZcsazzurroSelfSufficiency{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+// MetricsBuilder provides an interface for scrapers to report metrics while taking care of all the transformations
+// required to produce metric representation defined in metadata and user config.
+type MetricsBuilder struct {
+	config                                     MetricsBuilderConfig // config of the metrics builder.
+	startTime                                  pcommon.Timestamp    // start time that will be applied to all recorded data points.
+	metricsCapacity                            int                  // maximum observed number of metrics per resource.
+	metricsBuffer                              pmetric.Metrics      // accumulates metrics data before emitting.
+	buildInfo                                  component.BuildInfo  // contains version information.
+	resourceAttributeIncludeFilter             map[string]filter.Filter
+	resourceAttributeExcludeFilter             map[string]filter.Filter
+	metricZcsazzurroBatteryCharge              metricZcsazzurroBatteryCharge
+	metricZcsazzurroBatteryCycles              metricZcsazzurroBatteryCycles
+	metricZcsazzurroBatteryRoundTripEfficiency metricZcsazzurroBatteryRoundTripEfficiency
+	metricZcsazzurroEnergy                     metricZcsazzurroEnergy
+	metricZcsazzurroGridDependency             metricZcsazzurroGridDependency
+	metricZcsazzurroPower                      metricZcsazzurroPower
+	metricZcsazzurroSelfConsumption            metricZcsazzurroSelfConsumption
+	metricZcsazzurroSelfSufficiency            metricZcsazzurroSelfSufficiency
+}
+
+// MetricBuilderOption applies changes to default metrics builder.
+type MetricBuilderOption interface {
+	apply(*MetricsBuilder)
+}
+
+type metricBuilderOptionFunc func(mb *MetricsBuilder)
+
+func (mbof metricBuilderOptionFunc) apply(mb *MetricsBuilder) {
+	mbof(mb)
+}
+
+// WithStartTime sets startTime on the metrics builder.
+func WithStartTime(startTime pcommon.Timestamp) MetricBuilderOption {
+	return metricBuilderOptionFunc(func(mb *MetricsBuilder) {
+		mb.startTime = startTime
+	})
+}
+func NewMetricsBuilder(mbc MetricsBuilderConfig, settings receiver.Settings, options ...MetricBuilderOption) *MetricsBuilder {
+	mb := &MetricsBuilder{
+		config:                        mbc,
+		startTime:                     pcommon.NewTimestampFromTime(time.Now()),
+		metricsBuffer:                 pmetric.NewMetrics(),
+		buildInfo:                     settings.BuildInfo,
+		metricZcsazzurroBatteryCharge: newMetricZcsazzurroBatteryCharge(mbc.Metrics.ZcsazzurroBatteryCharge),
+		metricZcsazzurroBatteryCycles: newMetricZcsazzurroBatteryCycles(mbc.Metrics.ZcsazzurroBatteryCycles),
+		metricZcsazzurroBatteryRoundTripEfficiency: newMetricZcsazzurroBatteryRoundTripEfficiency(mbc.Metrics.ZcsazzurroBatteryRoundTripEfficiency),
+		metricZcsazzurroEnergy:                     newMetricZcsazzurroEnergy(mbc.Metrics.ZcsazzurroEnergy),
+		metricZcsazzurroGridDependency:             newMetricZcsazzurroGridDependency(mbc.Metrics.ZcsazzurroGridDependency),
+		metricZcsazzurroPower:                      newMetricZcsazzurroPower(mbc.Metrics.ZcsazzurroPower),
+		metricZcsazzurroSelfConsumption:            newMetricZcsazzurroSelfConsumption(mbc.Metrics.ZcsazzurroSelfConsumption),
+		metricZcsazzurroSelfSufficiency:            newMetricZcsazzurroSelfSufficiency(mbc.Metrics.ZcsazzurroSelfSufficiency),
+		resourceAttributeIncludeFilter:             make(map[string]filter.Filter),
+		resourceAttributeExcludeFilter:             make(map[string]filter.Filter),
+	}
+	if mbc.ResourceAttributes.DeviceID.MetricsInclude != nil {
+		mb.resourceAttributeIncludeFilter["device.id"] = filter.CreateFilter(mbc.ResourceAttributes.DeviceID.MetricsInclude)
+	}
+	if mbc.ResourceAttributes.DeviceID.MetricsExclude != nil {
+		mb.resourceAttributeExcludeFilter["device.id"] = filter.CreateFilter(mbc.ResourceAttributes.DeviceID.MetricsExclude)
+	}
+	if mbc.ResourceAttributes.DeviceManufacturer.MetricsInclude != nil {
+		mb.resourceAttributeIncludeFilter["device.manufacturer"] = filter.CreateFilter(mbc.ResourceAttributes.DeviceManufacturer.MetricsInclude)
+	}
+	if mbc.ResourceAttributes.DeviceManufacturer.MetricsExclude != nil {
+		mb.resourceAttributeExcludeFilter["device.manufacturer"] = filter.CreateFilter(mbc.ResourceAttributes.DeviceManufacturer.MetricsExclude)
+	}
+
+	for _, op := range options {
+		op.apply(mb)
+	}
+	return mb
+}
+
+// NewResourceBuilder returns a new resource builder that should be used to build a resource associated with for the emitted metrics.
+func (mb *MetricsBuilder) NewResourceBuilder() *ResourceBuilder {
+	return NewResourceBuilder(mb.config.ResourceAttributes)
+}
+
+// updateCapacity updates max length of metrics and resource attributes that will be used for the slice capacity.
+func (mb *MetricsBuilder) updateCapacity(rm pmetric.ResourceMetrics) {
+	if mb.metricsCapacity < rm.ScopeMetrics().At(0).Metrics().Len() {
+		mb.metricsCapacity = rm.ScopeMetrics().At(0).Metrics().Len()
+	}
+}
+
+// ResourceMetricsOption applies changes to provided resource metrics.
+type ResourceMetricsOption interface {
+	apply(pmetric.ResourceMetrics)
+}
+
+type resourceMetricsOptionFunc func(pmetric.ResourceMetrics)
+
+func (rmof resourceMetricsOptionFunc) apply(rm pmetric.ResourceMetrics) {
+	rmof(rm)
+}
+
+// WithResource sets the provided resource on the emitted ResourceMetrics.
+// It's recommended to use ResourceBuilder to create the resource.
+func WithResource(res pcommon.Resource) ResourceMetricsOption {
+	return resourceMetricsOptionFunc(func(rm pmetric.ResourceMetrics) {
+		res.CopyTo(rm.Resource())
+	})
+}
+
+// WithStartTimeOverride overrides start time for all the resource metrics data points.
+// This option should be only used if different start time has to be set on metrics coming from different resources.
+func WithStartTimeOverride(start pcommon.Timestamp) ResourceMetricsOption {
+	return resourceMetricsOptionFunc(func(rm pmetric.ResourceMetrics) {
+		var dps pmetric.NumberDataPointSlice
+		metrics := rm.ScopeMetrics().At(0).Metrics()
+		for i := 0; i < metrics.Len(); i++ {
+			switch metrics.At(i).Type() {
+			case pmetric.MetricTypeGauge:
+				dps = metrics.At(i).Gauge().DataPoints()
+			case pmetric.MetricTypeSum:
+				dps = metrics.At(i).Sum().DataPoints()
+			}
+			for j := 0; j < dps.Len(); j++ {
+				dps.At(j).SetStartTimestamp(start)
+			}
+		}
+	})
+}
+
+// EmitForResource saves all the generated metrics under a new resource and updates the internal state to be ready for
+// recording another set of data points as part of another resource. This function can be helpful when one scraper
+// needs to emit metrics from several resources. Otherwise calling this function is not required,
+// just `Emit` function can be called instead.
+// Resource attributes should be provided as ResourceMetricsOption arguments.
+func (mb *MetricsBuilder) EmitForResource(options ...ResourceMetricsOption) {
+	rm := pmetric.NewResourceMetrics()
+	ils := rm.ScopeMetrics().AppendEmpty()
+	ils.Scope().SetName(ScopeName)
+	ils.Scope().SetVersion(mb.buildInfo.Version)
+	ils.Metrics().EnsureCapacity(mb.metricsCapacity)
+	mb.metricZcsazzurroBatteryCharge.emit(ils.Metrics())
+	mb.metricZcsazzurroBatteryCycles.emit(ils.Metrics())
+	mb.metricZcsazzurroBatteryRoundTripEfficiency.emit(ils.Metrics())
+	mb.metricZcsazzurroEnergy.emit(ils.Metrics())
+	mb.metricZcsazzurroGridDependency.emit(ils.Metrics())
+	mb.metricZcsazzurroPower.emit(ils.Metrics())
+	mb.metricZcsazzurroSelfConsumption.emit(ils.Metrics())
+	mb.metricZcsazzurroSelfSufficiency.emit(ils.Metrics())
+
+	for _, op := range options {
+		op.apply(rm)
+	}
+	for attr, filter := range mb.resourceAttributeIncludeFilter {
+		if val, ok := rm.Resource().Attributes().Get(attr); ok && !filter.Matches(val.AsString()) {
+			return
+		}
+	}
+	for attr, filter := range mb.resourceAttributeExcludeFilter {
+		if val, ok := rm.Resource().Attributes().Get(attr); ok && filter.Matches(val.AsString()) {
+			return
+		}
+	}
+
+	if ils.Metrics().Len() > 0 {
+		mb.updateCapacity(rm)
+		rm.MoveTo(mb.metricsBuffer.ResourceMetrics().AppendEmpty())
+	}
+}
+
+// Emit returns all the metrics accumulated by the metrics builder and updates the internal state to be ready for
+// recording another set of metrics. This function will be responsible for applying all the transformations required to
+// produce metric representation defined in metadata and user config, e.g. delta or cumulative.
+func (mb *MetricsBuilder) Emit(options ...ResourceMetricsOption) pmetric.Metrics {
+	mb.EmitForResource(options...)
+	metrics := mb.metricsBuffer
+	mb.metricsBuffer = pmetric.NewMetrics()
+	return metrics
+}
+
+// RecordZcsazzurroBatteryChargeDataPoint adds a data point to zcsazzurro.battery.charge metric.
+func (mb *MetricsBuilder) RecordZcsazzurroBatteryChargeDataPoint(ts pcommon.Timestamp, val float64) {
+	mb.metricZcsazzurroBatteryCharge.recordDataPoint(mb.startTime, ts, val)
+}
+
+// RecordZcsazzurroBatteryCyclesDataPoint adds a data point to zcsazzurro.battery.cycles metric.
+func (mb *MetricsBuilder) RecordZcsazzurroBatteryCyclesDataPoint(ts pcommon.Timestamp, val int64) {
+	mb.metricZcsazzurroBatteryCycles.recordDataPoint(mb.startTime, ts, val)
+}
+
+// RecordZcsazzurroBatteryRoundTripEfficiencyDataPoint adds a data point to zcsazzurro.battery.round_trip_efficiency metric.
+func (mb *MetricsBuilder) RecordZcsazzurroBatteryRoundTripEfficiencyDataPoint(ts pcommon.Timestamp, val float64, periodAttributeValue AttributePeriod) {
+	mb.metricZcsazzurroBatteryRoundTripEfficiency.recordDataPoint(mb.startTime, ts, val, periodAttributeValue.String())
+}
+
+// RecordZcsazzurroEnergyDataPoint adds a data point to zcsazzurro.energy metric.
+func (mb *MetricsBuilder) RecordZcsazzurroEnergyDataPoint(ts pcommon.Timestamp, val float64, flowAttributeValue AttributeFlow, periodAttributeValue AttributePeriod) {
+	mb.metricZcsazzurroEnergy.recordDataPoint(mb.startTime, ts, val, flowAttributeValue.String(), periodAttributeValue.String())
+}
+
+// RecordZcsazzurroGridDependencyDataPoint adds a data point to zcsazzurro.grid_dependency metric.
+func (mb *MetricsBuilder) RecordZcsazzurroGridDependencyDataPoint(ts pcommon.Timestamp, val float64, periodAttributeValue AttributePeriod) {
+	mb.metricZcsazzurroGridDependency.recordDataPoint(mb.startTime, ts, val, periodAttributeValue.String())
+}
+
+// RecordZcsazzurroPowerDataPoint adds a data point to zcsazzurro.power metric.
+func (mb *MetricsBuilder) RecordZcsazzurroPowerDataPoint(ts pcommon.Timestamp, val float64, directionAttributeValue AttributeDirection) {
+	mb.metricZcsazzurroPower.recordDataPoint(mb.startTime, ts, val, directionAttributeValue.String())
+}
+
+// RecordZcsazzurroSelfConsumptionDataPoint adds a data point to zcsazzurro.self_consumption metric.
+func (mb *MetricsBuilder) RecordZcsazzurroSelfConsumptionDataPoint(ts pcommon.Timestamp, val float64, periodAttributeValue AttributePeriod) {
+	mb.metricZcsazzurroSelfConsumption.recordDataPoint(mb.startTime, ts, val, periodAttributeValue.String())
+}
+
+// RecordZcsazzurroSelfSufficiencyDataPoint adds a data point to zcsazzurro.self_sufficiency metric.
+func (mb *MetricsBuilder) RecordZcsazzurroSelfSufficiencyDataPoint(ts pcommon.Timestamp, val float64, periodAttributeValue AttributePeriod) {
+	mb.metricZcsazzurroSelfSufficiency.recordDataPoint(mb.startTime, ts, val, periodAttributeValue.String())
+}
+
+// Reset resets metrics builder to its initial state. It should be used when external metrics source is restarted,
+// and metrics builder should update its startTime and reset it's internal state accordingly.
+func (mb *MetricsBuilder) Reset(options ...MetricBuilderOption) {
+	mb.startTime = pcommon.NewTimestampFromTime(time.Now())
+	for _, op := range options {
+		op.apply(mb)
+	}
+}
@@ -0,0 +1,253 @@
+// Code generated by mdatagen. DO NOT EDIT.
+
+package metadata
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+type testDataSet int
+
+const (
+	testDataSetDefault testDataSet = iota
+	testDataSetAll
+	testDataSetNone
+)
+
+func TestMetricsBuilder(t *testing.T) {
+	tests := []struct {
+		name        string
+		metricsSet  testDataSet
+		resAttrsSet testDataSet
+		expectEmpty bool
+	}{
+		{
+			name: "default",
+		},
+		{
+			name:        "all_set",
+			metricsSet:  testDataSetAll,
+			resAttrsSet: testDataSetAll,
+		},
+		{
+			name:        "none_set",
+			metricsSet:  testDataSetNone,
+			resAttrsSet: testDataSetNone,
+			expectEmpty: true,
+		},
+		{
+			name:        "filter_set_include",
+			resAttrsSet: testDataSetAll,
+		},
+		{
+			name:        "filter_set_exclude",
+			resAttrsSet: testDataSetAll,
+			expectEmpty: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start := pcommon.Timestamp(1_000_000_000)
+			ts := pcommon.Timestamp(1_000_001_000)
+			observedZapCore, observedLogs := observer.New(zap.WarnLevel)
+			settings := receivertest.NewNopSettings(receivertest.NopType)
+			settings.Logger = zap.New(observedZapCore)
+			mb := NewMetricsBuilder(loadMetricsBuilderConfig(t, tt.name), settings, WithStartTime(start))
+
+			expectedWarnings := 0
+
+			assert.Equal(t, expectedWarnings, observedLogs.Len())
+
+			defaultMetricsCount := 0
+			allMetricsCount := 0
+
+			defaultMetricsCount++
+			allMetricsCount++
+			mb.RecordZcsazzurroBatteryChargeDataPoint(ts, 1)
+
+			defaultMetricsCount++
+			allMetricsCount++
+			mb.RecordZcsazzurroBatteryCyclesDataPoint(ts, 1)
+
+			defaultMetricsCount++
+			allMetricsCount++
+			mb.RecordZcsazzurroBatteryRoundTripEfficiencyDataPoint(ts, 1, AttributePeriodDay)
+
+			defaultMetricsCount++
+			allMetricsCount++
+			mb.RecordZcsazzurroEnergyDataPoint(ts, 1, AttributeFlowAutoconsuming, AttributePeriodDay)
+
+			defaultMetricsCount++
+			allMetricsCount++
+			mb.RecordZcsazzurroGridDependencyDataPoint(ts, 1, AttributePeriodDay)
+
+			defaultMetricsCount++
+			allMetricsCount++
+			mb.RecordZcsazzurroPowerDataPoint(ts, 1, AttributeDirectionAutoconsuming)
+
+			defaultMetricsCount++
+			allMetricsCount++
+			mb.RecordZcsazzurroSelfConsumptionDataPoint(ts, 1, AttributePeriodDay)
+
+			defaultMetricsCount++
+			allMetricsCount++
+			mb.RecordZcsazzurroSelfSufficiencyDataPoint(ts, 1, AttributePeriodDay)
+
+			rb := mb.NewResourceBuilder()
+			rb.SetDeviceID("device.id-val")
+			rb.SetDeviceManufacturer("device.manufacturer-val")
+			res := rb.Emit()
+			metrics := mb.Emit(WithResource(res))
+
+			if tt.expectEmpty {
+				assert.Equal(t, 0, metrics.ResourceMetrics().Len())
+				return
+			}
+
+			assert.Equal(t, 1, metrics.ResourceMetrics().Len())
+			rm := metrics.ResourceMetrics().At(0)
+			assert.Equal(t, res, rm.Resource())
+			assert.Equal(t, 1, rm.ScopeMetrics().Len())
+			ms := rm.ScopeMetrics().At(0).Metrics()
+			if tt.metricsSet == testDataSetDefault {
+				assert.Equal(t, defaultMetricsCount, ms.Len())
+			}
+			if tt.metricsSet == testDataSetAll {
+				assert.Equal(t, allMetricsCount, ms.Len())
+			}
+			validatedMetrics := make(map[string]bool)
+			for i := 0; i < ms.Len(); i++ {
+				switch ms.At(i).Name() {
+				case "zcsazzurro.battery.charge":
+					assert.False(t, validatedMetrics["zcsazzurro.battery.charge"], "Found a duplicate in the metrics slice: zcsazzurro.battery.charge")
+					validatedMetrics["zcsazzurro.battery.charge"] = true
+					assert.Equal(t, pmetric.MetricTypeGauge, ms.At(i).Type())
+					assert.Equal(t, 1, ms.At(i).Gauge().DataPoints().Len())
+					assert.Equal(t, "Battery state of charge.", ms.At(i).Description())
+					assert.Equal(t, "1", ms.At(i).Unit())
+					dp := ms.At(i).Gauge().DataPoints().At(0)
+					assert.Equal(t, start, dp.StartTimestamp())
+					assert.Equal(t, ts, dp.Timestamp())
+					assert.Equal(t, pmetric.NumberDataPointValueTypeDouble, dp.ValueType())
+					assert.InDelta(t, float64(1), dp.DoubleValue(), 0.01)
+				case "zcsazzurro.battery.cycles":
+					assert.False(t, validatedMetrics["zcsazzurro.battery.cycles"], "Found a duplicate in the metrics slice: zcsazzurro.battery.cycles")
+					validatedMetrics["zcsazzurro.battery.cycles"] = true
+					assert.Equal(t, pmetric.MetricTypeSum, ms.At(i).Type())
+					assert.Equal(t, 1, ms.At(i).Sum().DataPoints().Len())
+					assert.Equal(t, "Number of charge cycles of the battery.", ms.At(i).Description())
+					assert.Equal(t, "{cycle}", ms.At(i).Unit())
+					assert.True(t, ms.At(i).Sum().IsMonotonic())
+					assert.Equal(t, pmetric.AggregationTemporalityCumulative, ms.At(i).Sum().AggregationTemporality())
+					dp := ms.At(i).Sum().DataPoints().At(0)
+					assert.Equal(t, start, dp.StartTimestamp())
+					assert.Equal(t, ts, dp.Timestamp())
+					assert.Equal(t, pmetric.NumberDataPointValueTypeInt, dp.ValueType())
+					assert.Equal(t, int64(1), dp.IntValue())
+				case "zcsazzurro.battery.round_trip_efficiency":
+					assert.False(t, validatedMetrics["zcsazzurro.battery.round_trip_efficiency"], "Found a duplicate in the metrics slice: zcsazzurro.battery.round_trip_efficiency")
+					validatedMetrics["zcsazzurro.battery.round_trip_efficiency"] = true
+					assert.Equal(t, pmetric.MetricTypeGauge, ms.At(i).Type())
+					assert.Equal(t, 1, ms.At(i).Gauge().DataPoints().Len())
+					assert.Equal(t, "Energy discharged from the battery over the energy charged.", ms.At(i).Description())
+					assert.Equal(t, "1", ms.At(i).Unit())
+					dp := ms.At(i).Gauge().DataPoints().At(0)
+					assert.Equal(t, start, dp.StartTimestamp())
+					assert.Equal(t, ts, dp.Timestamp())
+					assert.Equal(t, pmetric.NumberDataPointValueTypeDouble, dp.ValueType())
+					assert.InDelta(t, float64(1), dp.DoubleValue(), 0.01)
+					attrVal, ok := dp.Attributes().Get("period")
+					assert.True(t, ok)
+					assert.Equal(t, "day", attrVal.Str())
+				case "zcsazzurro.energy":
+					assert.False(t, validatedMetrics["zcsazzurro.energy"], "Found a duplicate in the metrics slice: zcsazzurro.energy")
+					validatedMetrics["zcsazzurro.energy"] = true
+					assert.Equal(t, pmetric.MetricTypeSum, ms.At(i).Type())
+					assert.Equal(t, 1, ms.At(i).Sum().DataPoints().Len())
+					assert.Equal(t, "Energy flowed through the inverter since the start of the period.", ms.At(i).Description())
+					assert.Equal(t, "Wh", ms.At(i).Unit())
+					assert.True(t, ms.At(i).Sum().IsMonotonic())
+					assert.Equal(t, pmetric.AggregationTemporalityCumulative, ms.At(i).Sum().AggregationTemporality())
+					dp := ms.At(i).Sum().DataPoints().At(0)
+					assert.Equal(t, start, dp.StartTimestamp())
+					assert.Equal(t, ts, dp.Timestamp())
+					assert.Equal(t, pmetric.NumberDataPointValueTypeDouble, dp.ValueType())
+					assert.InDelta(t, float64(1), dp.DoubleValue(), 0.01)
+					attrVal, ok := dp.Attributes().Get("flow")
+					assert.True(t, ok)
+					assert.Equal(t, "autoconsuming", attrVal.Str())
+					attrVal, ok = dp.Attributes().Get("period")
+					assert.True(t, ok)
+					assert.Equal(t, "day", attrVal.Str())
+				case "zcsazzurro.grid_dependency":
+					assert.False(t, validatedMetrics["zcsazzurro.grid_dependency"], "Found a duplicate in the metrics slice: zcsazzurro.grid_dependency")
+					validatedMetrics["zcsazzurro.grid_dependency"] = true
+					assert.Equal(t, pmetric.MetricTypeGauge, ms.At(i).Type())
+					assert.Equal(t, 1, ms.At(i).Gauge().DataPoints().Len())
+					assert.Equal(t, "Share of the consumption imported from the grid.", ms.At(i).Description())
+					assert.Equal(t, "1", ms.At(i).Unit())
+					dp := ms.At(i).Gauge().DataPoints().At(0)
+					assert.Equal(t, start, dp.StartTimestamp())
+					assert.Equal(t, ts, dp.Timestamp())
+					assert.Equal(t, pmetric.NumberDataPointValueTypeDouble, dp.ValueType())
+					assert.InDelta(t, float64(1), dp.DoubleValue(), 0.01)
+					attrVal, ok := dp.Attributes().Get("period")
+					assert.True(t, ok)
+					assert.Equal(t, "day", attrVal.Str())
+				case "zcsazzurro.power":
+					assert.False(t, validatedMetrics["zcsazzurro.power"], "Found a duplicate in the metrics slice: zcsazzurro.power")
+					validatedMetrics["zcsazzurro.power"] = true
+					assert.Equal(t, pmetric.MetricTypeGauge, ms.At(i).Type())
+					assert.Equal(t, 1, ms.At(i).Gauge().DataPoints().Len())
+					assert.Equal(t, "Power flowing through the inverter.", ms.At(i).Description())
+					assert.Equal(t, "W", ms.At(i).Unit())
+					dp := ms.At(i).Gauge().DataPoints().At(0)
+					assert.Equal(t, start, dp.StartTimestamp())
+					assert.Equal(t, ts, dp.Timestamp())
+					assert.Equal(t, pmetric.NumberDataPointValueTypeDouble, dp.ValueType())
+					assert.InDelta(t, float64(1), dp.DoubleValue(), 0.01)
+					attrVal, ok := dp.Attributes().Get("direction")
+					assert.True(t, ok)
+					assert.Equal(t, "autoconsuming", attrVal.Str())
+				case "zcsazzurro.self_consumption":
+					assert.False(t, validatedMetrics["zcsazzurro.self_consumption"], "Found a duplicate in the metrics slice: zcsazzurro.self_consumption")
+					validatedMetrics["zcsazzurro.self_consumption"] = true
+					assert.Equal(t, pmetric.MetricTypeGauge, ms.At(i).Type())
+					assert.Equal(t, 1, ms.At(i).Gauge().DataPoints().Len())
+					assert.Equal(t, "Share of the generation not exported to the grid.", ms.At(i).Description())
+					assert.Equal(t, "1", ms.At(i).Unit())
+					dp := ms.At(i).Gauge().DataPoints().At(0)
+					assert.Equal(t, start, dp.StartTimestamp())
+					assert.Equal(t, ts, dp.Timestamp())
+					assert.Equal(t, pmetric.NumberDataPointValueTypeDouble, dp.ValueType())
+					assert.InDelta(t, float64(1), dp.DoubleValue(), 0.01)
+					attrVal, ok := dp.Attributes().Get("period")
+					assert.True(t, ok)
+					assert.Equal(t, "day", attrVal.Str())
+				case "zcsazzurro.self_sufficiency":
+					assert.False(t, validatedMetrics["zcsazzurro.self_sufficiency"], "Found a duplicate in the metrics slice: zcsazzurro.self_sufficiency")
+					validatedMetrics["zcsazzurro.self_sufficiency"] = true
+					assert.Equal(t, pmetric.MetricTypeGauge, ms.At(i).Type())
+					assert.Equal(t, 1, ms.At(i).Gauge().DataPoints().Len())
+					assert.Equal(t, "Share of the consumption not imported from the grid.", ms.At(i).Description())
+					assert.Equal(t, "1", ms.At(i).Unit())
+					dp := ms.At(i).Gauge().DataPoints().At(0)
+					assert.Equal(t, start, dp.StartTimestamp())
+					assert.Equal(t, ts, dp.Timestamp())
+					assert.Equal(t, pmetric.NumberDataPointValueTypeDouble, dp.ValueType())
+					assert.InDelta(t, float64(1), dp.DoubleValue(), 0.01)
+					attrVal, ok := dp.Attributes().Get("period")
+					assert.True(t, ok)
+					assert.Equal(t, "day", attrVal.Str())
+				}
+			}
+		})
+	}
+}
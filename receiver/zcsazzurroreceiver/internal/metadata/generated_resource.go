@@ -0,0 +1,43 @@
+// Code generated by mdatagen. DO NOT EDIT.
+
+package metadata
+
+import (
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+// ResourceBuilder is a helper struct to build resources predefined in metadata.yaml.
+// The ResourceBuilder is not thread-safe and must not to be used in multiple goroutines.
+type ResourceBuilder struct {
+	config ResourceAttributesConfig
+	res    pcommon.Resource
+}
+
+// NewResourceBuilder creates a new ResourceBuilder. This method should be called on the start of the application.
+func NewResourceBuilder(rac ResourceAttributesConfig) *ResourceBuilder {
+	return &ResourceBuilder{
+		config: rac,
+		res:    pcommon.NewResource(),
+	}
+}
+
+// SetDeviceID sets provided value as "device.id" attribute.
+func (rb *ResourceBuilder) SetDeviceID(val string) {
+	if rb.config.DeviceID.Enabled {
+		rb.res.Attributes().PutStr("device.id", val)
+	}
+}
+
+// SetDeviceManufacturer sets provided value as "device.manufacturer" attribute.
+func (rb *ResourceBuilder) SetDeviceManufacturer(val string) {
+	if rb.config.DeviceManufacturer.Enabled {
+		rb.res.Attributes().PutStr("device.manufacturer", val)
+	}
+}
+
+// Emit returns the built resource and resets the internal builder state.
+func (rb *ResourceBuilder) Emit() pcommon.Resource {
+	r := rb.res
+	rb.res = pcommon.NewResource()
+	return r
+}
@@ -0,0 +1,104 @@
+package zcsazzurroreceiver
+
+import (
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/zmoog/zcs/azzurro"
+
+	"github.com/zmoog/collector/receiver/zcsazzurroreceiver/internal/metadata"
+)
+
+// energyCounters holds the energy flows of a period, in kWh.
+type energyCounters struct {
+	charging    float64
+	consuming   float64
+	discharging float64
+	exporting   float64
+	generating  float64
+	importing   float64
+}
+
+func dailyEnergy(metrics azzurro.InverterMetrics) energyCounters {
+	return energyCounters{
+		charging:    metrics.EnergyCharging,
+		consuming:   metrics.EnergyConsuming,
+		discharging: metrics.EnergyDischarging,
+		exporting:   metrics.EnergyExporting,
+		generating:  metrics.EnergyGenerating,
+		importing:   metrics.EnergyImporting,
+	}
+}
+
+func lifetimeEnergy(metrics azzurro.InverterMetrics) energyCounters {
+	return energyCounters{
+		charging:    metrics.EnergyChargingTotal,
+		consuming:   metrics.EnergyConsumingTotal,
+		discharging: metrics.EnergyDischargingTotal,
+		exporting:   metrics.EnergyExportingTotal,
+		generating:  metrics.EnergyGeneratingTotal,
+		importing:   metrics.EnergyImportingTotal,
+	}
+}
+
+// energyKPI is a ratio derived from the energy counters. The ratios are
+// computed from the grid flows, so they don't depend on what the
+// inverter counts as autoconsumed.
+type energyKPI struct {
+	// legacyName is the name of the daily metric in the legacy schema;
+	// the lifetime one has the _total suffix.
+	legacyName  string
+	description string
+	// value returns the ratio, or false when it is undefined, like the
+	// self-consumption of a day without generation.
+	value  func(e energyCounters) (float64, bool)
+	record func(mb *metadata.MetricsBuilder, ts pcommon.Timestamp, val float64, period metadata.AttributePeriod)
+}
+
+var energyKPIs = []energyKPI{
+	{
+		legacyName:  "self_sufficiency_ratio",
+		description: "Share of the consumption not imported from the grid",
+		value: func(e energyCounters) (float64, bool) {
+			return ratio(e.consuming-e.importing, e.consuming)
+		},
+		record: (*metadata.MetricsBuilder).RecordZcsazzurroSelfSufficiencyDataPoint,
+	},
+	{
+		legacyName:  "self_consumption_ratio",
+		description: "Share of the generation not exported to the grid",
+		value: func(e energyCounters) (float64, bool) {
+			return ratio(e.generating-e.exporting, e.generating)
+		},
+		record: (*metadata.MetricsBuilder).RecordZcsazzurroSelfConsumptionDataPoint,
+	},
+	{
+		legacyName:  "grid_dependency_ratio",
+		description: "Share of the consumption imported from the grid",
+		value: func(e energyCounters) (float64, bool) {
+			return ratio(e.importing, e.consuming)
+		},
+		record: (*metadata.MetricsBuilder).RecordZcsazzurroGridDependencyDataPoint,
+	},
+	{
+		legacyName:  "battery_round_trip_efficiency_ratio",
+		description: "Energy discharged from the battery over the energy charged",
+		value: func(e energyCounters) (float64, bool) {
+			// Not capped: over a day, the battery can discharge what
+			// it charged the day before.
+			if e.charging <= 0 {
+				return 0, false
+			}
+			return e.discharging / e.charging, true
+		},
+		record: (*metadata.MetricsBuilder).RecordZcsazzurroBatteryRoundTripEfficiencyDataPoint,
+	},
+}
+
+// ratio returns part over total, between 0 and 1, or false when total
+// is not positive.
+func ratio(part, total float64) (float64, bool) {
+	if total <= 0 {
+		return 0, false
+	}
+	return min(max(part/total, 0), 1), true
+}
@@ -0,0 +1,80 @@
+package zcsazzurroreceiver
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zmoog/zcs/azzurro"
+)
+
+func TestEnergyKPIs(t *testing.T) {
+	data, err := os.ReadFile("testdata/response.json")
+	require.NoError(t, err)
+	var response azzurro.RealtimeDataResponse
+	require.NoError(t, json.Unmarshal(data, &response))
+	metrics := response.RealtimeData.Params.Value[0]["my-serial-number"]
+
+	tests := []struct {
+		name     string
+		energy   energyCounters
+		expected map[string]float64
+	}{
+		{
+			name:   "day",
+			energy: dailyEnergy(metrics),
+			expected: map[string]float64{
+				"self_sufficiency_ratio": (27.4 - 3.66) / 27.4,
+				"self_consumption_ratio": (20.46 - 0.18) / 20.46,
+				"grid_dependency_ratio":  3.66 / 27.4,
+				// The battery discharged the charge of the day before.
+				"battery_round_trip_efficiency_ratio": 8.13 / 4.67,
+			},
+		},
+		{
+			name:   "lifetime",
+			energy: lifetimeEnergy(metrics),
+			expected: map[string]float64{
+				"self_sufficiency_ratio":              (2099.9 - 545.8) / 2099.9,
+				"self_consumption_ratio":              (3265.2 - 1327.9) / 3265.2,
+				"grid_dependency_ratio":               545.8 / 2099.9,
+				"battery_round_trip_efficiency_ratio": 739.8 / 807.0,
+			},
+		},
+		{
+			name:     "night without battery",
+			energy:   energyCounters{consuming: 2, importing: 2},
+			expected: map[string]float64{"self_sufficiency_ratio": 0, "grid_dependency_ratio": 1},
+		},
+		{
+			name: "capped",
+			// The counters are not read at the same instant.
+			energy:   energyCounters{consuming: 2, importing: 2.1, generating: 1, exporting: 1.2},
+			expected: map[string]float64{"self_sufficiency_ratio": 0, "self_consumption_ratio": 0, "grid_dependency_ratio": 1},
+		},
+		{
+			name:     "undefined",
+			energy:   energyCounters{},
+			expected: map[string]float64{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actual := make(map[string]float64)
+			for _, kpi := range energyKPIs {
+				if value, ok := kpi.value(tt.energy); ok {
+					actual[kpi.legacyName] = value
+				}
+			}
+
+			require.Len(t, actual, len(tt.expected))
+			for name, value := range tt.expected {
+				assert.InDelta(t, value, actual[name], 1e-9, name)
+			}
+		})
+	}
+}
@@ -1,26 +1,52 @@
 package zcsazzurroreceiver
 
 import (
+	"sync"
 	"time"
 
+	"go.opentelemetry.io/collector/featuregate"
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/receiver"
 	"go.uber.org/zap"
 
 	"github.com/zmoog/zcs/azzurro"
+
+	"github.com/zmoog/collector/receiver/zcsazzurroreceiver/internal/metadata"
 )
 
 const (
-	scopeName    = "github.com/zmoog/collector/receiver/zcsazzurroreceiver"
 	scopeVersion = "v0.2.0"
+
+	// thingFindLayout is the layout of the time the thing was installed.
+	thingFindLayout = "2006-01-02T15:04:05Z"
+	manufacturer    = "ZCS"
+)
+
+// semconvMetricsGate switches from the legacy metrics, like
+// power_generating and energy_importing_total, to the metrics of
+// metadata.yaml. The legacy metrics stay available by disabling it once
+// it is enabled by default.
+var semconvMetricsGate = featuregate.GlobalRegistry().MustRegister(
+	"receiver.zcsazzurro.useSemconvMetrics",
+	featuregate.StageAlpha,
+	featuregate.WithRegisterDescription("Emits the zcsazzurro.* metrics with device.* resource attributes, in place of the legacy unnamespaced metrics and thing_key."),
+	featuregate.WithRegisterFromVersion("v0.3.0"),
 )
 
 type azzurroRealtimeDataMarshaler struct {
 	logger *zap.Logger
+	// mb builds the metrics of metadata.yaml. It is shared by the things,
+	// so it is guarded by mu.
+	mb *metadata.MetricsBuilder
+	mu sync.Mutex
 }
 
-func newAzzurroRealtimeDataMarshaler(logger *zap.Logger) *azzurroRealtimeDataMarshaler {
-	return &azzurroRealtimeDataMarshaler{logger: logger}
+func newAzzurroRealtimeDataMarshaler(cfg metadata.MetricsBuilderConfig, settings receiver.Settings) *azzurroRealtimeDataMarshaler {
+	return &azzurroRealtimeDataMarshaler{
+		logger: settings.Logger,
+		mb:     metadata.NewMetricsBuilder(cfg, settings),
+	}
 }
 
 func (m *azzurroRealtimeDataMarshaler) addGaugeIntMetric(scopeMetrics pmetric.ScopeMetrics, name, desc, unit string, value int, timestamp pcommon.Timestamp) {
@@ -84,13 +110,121 @@ func (m *azzurroRealtimeDataMarshaler) addSumIntMetric(scopeMetrics pmetric.Scop
 	dp.SetStartTimestamp(startTimestamp)
 }
 
-func (m *azzurroRealtimeDataMarshaler) UnmarshalMetrics(thingKey string, metrics azzurro.InverterMetrics) (pmetric.Metrics, error) {
+// UnmarshalMetrics returns the metrics of the thing. The daily energy
+// sums start at dailyStart, when the inverter last reset them.
+func (m *azzurroRealtimeDataMarshaler) UnmarshalMetrics(thingKey string, metrics azzurro.InverterMetrics, dailyStart time.Time) (pmetric.Metrics, error) {
+	if semconvMetricsGate.IsEnabled() {
+		return m.unmarshalSemconvMetrics(thingKey, metrics, dailyStart), nil
+	}
+	return m.unmarshalLegacyMetrics(thingKey, metrics, dailyStart)
+}
+
+// unmarshalSemconvMetrics returns the metrics of metadata.yaml.
+func (m *azzurroRealtimeDataMarshaler) unmarshalSemconvMetrics(thingKey string, metrics azzurro.InverterMetrics, dailyStart time.Time) pmetric.Metrics {
+	timestamp := pcommon.NewTimestampFromTime(metrics.LastUpdate)
+	installed := m.thingFindTime(metrics, dailyStart)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	power := []struct {
+		value     float64
+		direction metadata.AttributeDirection
+	}{
+		{metrics.PowerAutoconsuming, metadata.AttributeDirectionAutoconsuming},
+		{metrics.PowerCharging, metadata.AttributeDirectionCharging},
+		{metrics.PowerConsuming, metadata.AttributeDirectionConsuming},
+		{metrics.PowerDischarging, metadata.AttributeDirectionDischarging},
+		{metrics.PowerExporting, metadata.AttributeDirectionExporting},
+		{metrics.PowerGenerating, metadata.AttributeDirectionGenerating},
+		{metrics.PowerImporting, metadata.AttributeDirectionImporting},
+	}
+	for _, p := range power {
+		m.mb.RecordZcsazzurroPowerDataPoint(timestamp, p.value, p.direction)
+	}
+
+	// The API reports the energy in kWh.
+	energy := []struct {
+		day, lifetime float64
+		flow          metadata.AttributeFlow
+	}{
+		{metrics.EnergyAutoconsuming, metrics.EnergyAutoconsumingTotal, metadata.AttributeFlowAutoconsuming},
+		{metrics.EnergyCharging, metrics.EnergyChargingTotal, metadata.AttributeFlowCharging},
+		{metrics.EnergyConsuming, metrics.EnergyConsumingTotal, metadata.AttributeFlowConsuming},
+		{metrics.EnergyDischarging, metrics.EnergyDischargingTotal, metadata.AttributeFlowDischarging},
+		{metrics.EnergyExporting, metrics.EnergyExportingTotal, metadata.AttributeFlowExporting},
+		{metrics.EnergyGenerating, metrics.EnergyGeneratingTotal, metadata.AttributeFlowGenerating},
+		{metrics.EnergyImporting, metrics.EnergyImportingTotal, metadata.AttributeFlowImporting},
+	}
+	for _, e := range energy {
+		m.mb.RecordZcsazzurroEnergyDataPoint(timestamp, e.day*1000, e.flow, metadata.AttributePeriodDay)
+		m.mb.RecordZcsazzurroEnergyDataPoint(timestamp, e.lifetime*1000, e.flow, metadata.AttributePeriodLifetime)
+	}
+
+	for _, kpi := range energyKPIs {
+		if value, ok := kpi.value(dailyEnergy(metrics)); ok {
+			kpi.record(m.mb, timestamp, value, metadata.AttributePeriodDay)
+		}
+		if value, ok := kpi.value(lifetimeEnergy(metrics)); ok {
+			kpi.record(m.mb, timestamp, value, metadata.AttributePeriodLifetime)
+		}
+	}
+
+	m.mb.RecordZcsazzurroBatteryChargeDataPoint(timestamp, float64(metrics.BatterySoC)/100)
+	m.mb.RecordZcsazzurroBatteryCyclesDataPoint(timestamp, int64(metrics.BatteryCycletime))
+
+	rb := m.mb.NewResourceBuilder()
+	rb.SetDeviceID(thingKey)
+	rb.SetDeviceManufacturer(manufacturer)
+	md := m.mb.Emit(metadata.WithResource(rb.Emit()))
+
+	setSumStartTimestamps(md, pcommon.NewTimestampFromTime(dailyStart), pcommon.NewTimestampFromTime(installed))
+	return md
+}
+
+// setSumStartTimestamps sets the start time of the sums: the daily
+// energy counters start at daily, and the lifetime ones at lifetime.
+func setSumStartTimestamps(md pmetric.Metrics, daily, lifetime pcommon.Timestamp) {
+	for i := 0; i < md.ResourceMetrics().Len(); i++ {
+		scopeMetrics := md.ResourceMetrics().At(i).ScopeMetrics()
+		for j := 0; j < scopeMetrics.Len(); j++ {
+			metrics := scopeMetrics.At(j).Metrics()
+			for k := 0; k < metrics.Len(); k++ {
+				if metrics.At(k).Type() != pmetric.MetricTypeSum {
+					continue
+				}
+				dps := metrics.At(k).Sum().DataPoints()
+				for l := 0; l < dps.Len(); l++ {
+					start := lifetime
+					if period, ok := dps.At(l).Attributes().Get("period"); ok && period.Str() == metadata.AttributePeriodDay.String() {
+						start = daily
+					}
+					dps.At(l).SetStartTimestamp(start)
+				}
+			}
+		}
+	}
+}
+
+// thingFindTime returns the time the thing was installed, the start of
+// the lifetime counters, or dailyStart when unknown.
+func (m *azzurroRealtimeDataMarshaler) thingFindTime(metrics azzurro.InverterMetrics, dailyStart time.Time) time.Time {
+	thingFindTime, err := time.Parse(thingFindLayout, metrics.ThingFind)
+	if err != nil {
+		m.logger.Warn("Failed to parse thingFind timestamp, using daily start", zap.String("thingFind", metrics.ThingFind), zap.Error(err))
+		return dailyStart
+	}
+	return thingFindTime
+}
+
+// unmarshalLegacyMetrics returns the unnamespaced metrics.
+func (m *azzurroRealtimeDataMarshaler) unmarshalLegacyMetrics(thingKey string, metrics azzurro.InverterMetrics, dailyStart time.Time) (pmetric.Metrics, error) {
 	md := pmetric.NewMetrics()
 
 	resourceMetrics := md.ResourceMetrics().AppendEmpty()
 
 	scopeMetrics := resourceMetrics.ScopeMetrics().AppendEmpty()
-	scopeMetrics.Scope().SetName(scopeName)
+	scopeMetrics.Scope().SetName(metadata.ScopeName)
 	scopeMetrics.Scope().SetVersion(scopeVersion)
 
 	// ----------------------------------------------------------------
@@ -104,15 +238,10 @@ func (m *azzurroRealtimeDataMarshaler) UnmarshalMetrics(thingKey string, metrics
 	// ----------------------------------------------------------------
 	timestamp := pcommon.Timestamp(metrics.LastUpdate.UnixNano())
 
-	// I assume the start of today is the start of the daily metrics
-	startOfTodayTimestamp := pcommon.Timestamp(metrics.LastUpdate.Truncate(24 * time.Hour).UnixNano())
+	startOfTodayTimestamp := pcommon.Timestamp(dailyStart.UnixNano())
 
 	// Parse thingFind for total metrics start timestamp
-	thingFindTime, err := time.Parse("2006-01-02T15:04:05Z", metrics.ThingFind)
-	if err != nil {
-		m.logger.Warn("Failed to parse thingFind timestamp, using daily start", zap.String("thingFind", metrics.ThingFind), zap.Error(err))
-		thingFindTime = metrics.LastUpdate.Truncate(24 * time.Hour)
-	}
+	thingFindTime := m.thingFindTime(metrics, dailyStart)
 
 	// I assume thingFind is the discovery timestamp, when the thing was
 	// first installed. This is the start of the total cumulative metrics.
@@ -156,5 +285,17 @@ func (m *azzurroRealtimeDataMarshaler) UnmarshalMetrics(thingKey string, metrics
 	m.addSumFloatMetric(scopeMetrics, "energy_generating_total", "Energy generating total", "kWh", metrics.EnergyGeneratingTotal, timestamp, thingDiscoveryTimestamp)
 	m.addSumFloatMetric(scopeMetrics, "energy_importing_total", "Energy importing total", "kWh", metrics.EnergyImportingTotal, timestamp, thingDiscoveryTimestamp)
 
+	// ----------------------------------------------------------------
+	// Ratios derived from the energy metrics
+	// ----------------------------------------------------------------
+	for _, kpi := range energyKPIs {
+		if value, ok := kpi.value(dailyEnergy(metrics)); ok {
+			m.addGaugeFloatMetric(scopeMetrics, kpi.legacyName, kpi.description, "1", value, timestamp)
+		}
+		if value, ok := kpi.value(lifetimeEnergy(metrics)); ok {
+			m.addGaugeFloatMetric(scopeMetrics, kpi.legacyName+"_total", kpi.description+" total", "1", value, timestamp)
+		}
+	}
+
 	return md, nil
 }
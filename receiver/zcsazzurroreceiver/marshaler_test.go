@@ -9,11 +9,15 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/featuregate"
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/receiver/receivertest"
 	"go.uber.org/zap"
 
 	"github.com/zmoog/zcs/azzurro"
+
+	"github.com/zmoog/collector/receiver/zcsazzurroreceiver/internal/metadata"
 )
 
 func TestAzzurroRealtimeDataMarshaler_UnmarshalMetrics(t *testing.T) {
@@ -36,7 +40,7 @@ func TestAzzurroRealtimeDataMarshaler_UnmarshalMetrics(t *testing.T) {
 		deviceKey := "my-serial-number"
 		deviceMetrics := response.RealtimeData.Params.Value[0][deviceKey]
 
-		metrics, err := marshaler.UnmarshalMetrics(deviceKey, deviceMetrics)
+		metrics, err := marshaler.UnmarshalMetrics(deviceKey, deviceMetrics, startOfDay(deviceMetrics.LastUpdate, time.UTC))
 		require.NoError(t, err)
 
 		// Verify metrics structure
@@ -55,11 +59,12 @@ func TestAzzurroRealtimeDataMarshaler_UnmarshalMetrics(t *testing.T) {
 		scopeMetrics := resourceMetrics.ScopeMetrics().At(0)
 
 		scope := scopeMetrics.Scope()
-		assert.Equal(t, scopeName, scope.Name())
+		assert.Equal(t, metadata.ScopeName, scope.Name())
 		assert.Equal(t, scopeVersion, scope.Version())
 
-		// Expected metrics count: 7 power + 2 battery (soc + total) + 7 energy (daily) + 7 energy (total) = 23 metrics
-		expectedMetricsCount := 23
+		// Expected metrics count: 7 power + 2 battery (soc + total) + 7 energy (daily) + 7 energy (total)
+		// + 4 ratios (daily) + 4 ratios (total) = 31 metrics
+		expectedMetricsCount := 31
 		assert.Equal(t, expectedMetricsCount, scopeMetrics.Metrics().Len(), "Should have %d metrics", expectedMetricsCount)
 
 		// Verify specific metrics
@@ -121,7 +126,7 @@ func TestAzzurroRealtimeDataMarshaler_UnmarshalMetrics(t *testing.T) {
 		// Create empty metrics
 		emptyMetrics := azzurro.InverterMetrics{}
 
-		metrics, err := marshaler.UnmarshalMetrics("test-device", emptyMetrics)
+		metrics, err := marshaler.UnmarshalMetrics("test-device", emptyMetrics, time.Time{})
 		require.NoError(t, err)
 		// Should still create metrics even with empty data
 		assert.Equal(t, 1, metrics.ResourceMetrics().Len(), "Should have 1 resource metric even for empty data")
@@ -146,7 +151,7 @@ func TestAzzurroRealtimeDataMarshaler_MetricValues(t *testing.T) {
 	deviceKey := "my-serial-number"
 	deviceMetrics := response.RealtimeData.Params.Value[0][deviceKey]
 
-	metrics, err := marshaler.UnmarshalMetrics(deviceKey, deviceMetrics)
+	metrics, err := marshaler.UnmarshalMetrics(deviceKey, deviceMetrics, startOfDay(deviceMetrics.LastUpdate, time.UTC))
 	require.NoError(t, err)
 
 	resourceMetrics := metrics.ResourceMetrics().At(0)
@@ -199,7 +204,10 @@ func TestAzzurroRealtimeDataMarshaler_Timestamp(t *testing.T) {
 	logger := zap.NewNop()
 	marshaler := &azzurroRealtimeDataMarshaler{logger: logger}
 
-	metrics, err := marshaler.UnmarshalMetrics("test-device", testMetrics)
+	rome, err := time.LoadLocation("Europe/Rome")
+	require.NoError(t, err)
+
+	metrics, err := marshaler.UnmarshalMetrics("test-device", testMetrics, startOfDay(testTime, rome))
 	require.NoError(t, err)
 
 	resourceMetrics := metrics.ResourceMetrics().At(0)
@@ -207,7 +215,8 @@ func TestAzzurroRealtimeDataMarshaler_Timestamp(t *testing.T) {
 
 	// Check that all metrics have the correct timestamp
 	expectedTimestamp := pcommon.Timestamp(testTime.UnixNano())
-	expectedDailyStartTimestamp := pcommon.Timestamp(testTime.Truncate(24 * time.Hour).UnixNano())
+	// Midnight in Rome, during the daylight saving time.
+	expectedDailyStartTimestamp := pcommon.Timestamp(time.Date(2024, 10, 21, 22, 0, 0, 0, time.UTC).UnixNano())
 
 	// Parse thingFind for total metrics start timestamp
 	thingFindTime, err := time.Parse("2006-01-02T15:04:05Z", "2024-06-04T08:55:36Z")
@@ -236,3 +245,79 @@ func TestAzzurroRealtimeDataMarshaler_Timestamp(t *testing.T) {
 		}
 	}
 }
+
+func TestAzzurroRealtimeDataMarshaler_SemconvMetrics(t *testing.T) {
+	require.NoError(t, featuregate.GlobalRegistry().Set(semconvMetricsGate.ID(), true))
+	t.Cleanup(func() {
+		require.NoError(t, featuregate.GlobalRegistry().Set(semconvMetricsGate.ID(), false))
+	})
+
+	data, err := os.ReadFile("testdata/response.json")
+	require.NoError(t, err)
+	var response azzurro.RealtimeDataResponse
+	require.NoError(t, json.Unmarshal(data, &response))
+	deviceMetrics := response.RealtimeData.Params.Value[0]["my-serial-number"]
+
+	marshaler := newAzzurroRealtimeDataMarshaler(metadata.DefaultMetricsBuilderConfig(), receivertest.NewNopSettings(metadata.Type))
+	dailyStart := startOfDay(deviceMetrics.LastUpdate, time.UTC)
+	metrics, err := marshaler.UnmarshalMetrics("my-serial-number", deviceMetrics, dailyStart)
+	require.NoError(t, err)
+	require.Equal(t, 1, metrics.ResourceMetrics().Len())
+
+	resourceMetrics := metrics.ResourceMetrics().At(0)
+	assert.Equal(t, map[string]any{
+		"device.id":           "my-serial-number",
+		"device.manufacturer": "ZCS",
+	}, resourceMetrics.Resource().Attributes().AsRaw())
+
+	scopeMetrics := resourceMetrics.ScopeMetrics().At(0)
+	assert.Equal(t, metadata.ScopeName, scopeMetrics.Scope().Name())
+
+	byName := make(map[string]pmetric.Metric)
+	for i := 0; i < scopeMetrics.Metrics().Len(); i++ {
+		byName[scopeMetrics.Metrics().At(i).Name()] = scopeMetrics.Metrics().At(i)
+	}
+	require.Len(t, byName, 8)
+
+	power := byName["zcsazzurro.power"]
+	assert.Equal(t, "W", power.Unit())
+	require.Equal(t, 7, power.Gauge().DataPoints().Len())
+	for i := 0; i < power.Gauge().DataPoints().Len(); i++ {
+		dp := power.Gauge().DataPoints().At(i)
+		if direction, _ := dp.Attributes().Get("direction"); direction.Str() == "importing" {
+			assert.Equal(t, float64(950), dp.DoubleValue())
+		}
+	}
+
+	thingFind, err := time.Parse(thingFindLayout, deviceMetrics.ThingFind)
+	require.NoError(t, err)
+
+	energy := byName["zcsazzurro.energy"]
+	assert.Equal(t, "Wh", energy.Unit())
+	require.Equal(t, 14, energy.Sum().DataPoints().Len())
+	for i := 0; i < energy.Sum().DataPoints().Len(); i++ {
+		dp := energy.Sum().DataPoints().At(i)
+		flow, _ := dp.Attributes().Get("flow")
+		period, _ := dp.Attributes().Get("period")
+		switch period.Str() {
+		case "day":
+			assert.Equal(t, pcommon.NewTimestampFromTime(dailyStart), dp.StartTimestamp())
+			if flow.Str() == "consuming" {
+				assert.InDelta(t, 27400, dp.DoubleValue(), 1e-6, "kWh are converted to Wh")
+			}
+		case "lifetime":
+			assert.Equal(t, pcommon.NewTimestampFromTime(thingFind), dp.StartTimestamp())
+		default:
+			t.Errorf("unexpected period %q", period.Str())
+		}
+	}
+
+	selfSufficiency := byName["zcsazzurro.self_sufficiency"].Gauge().DataPoints()
+	require.Equal(t, 2, selfSufficiency.Len())
+	period, _ := selfSufficiency.At(0).Attributes().Get("period")
+	assert.Equal(t, "day", period.Str())
+	assert.InDelta(t, (27.4-3.66)/27.4, selfSufficiency.At(0).DoubleValue(), 1e-9)
+
+	assert.InDelta(t, 0.2, byName["zcsazzurro.battery.charge"].Gauge().DataPoints().At(0).DoubleValue(), 1e-9)
+	assert.Equal(t, pcommon.NewTimestampFromTime(thingFind), byName["zcsazzurro.battery.cycles"].Sum().DataPoints().At(0).StartTimestamp())
+}
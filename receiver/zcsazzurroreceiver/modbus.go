@@ -0,0 +1,193 @@
+package zcsazzurroreceiver
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/zmoog/zcs/azzurro"
+
+	"github.com/zmoog/collector/internal/modbus"
+)
+
+// MinModbusCollectionInterval is the minimum interval of the local mode:
+// the inverter updates its registers every few seconds.
+const MinModbusCollectionInterval = time.Second
+
+// ModbusConfig configures the local mode, reading the inverter over the
+// Modbus TCP interface of its datalogger instead of the cloud API.
+type ModbusConfig struct {
+	// Endpoint is the host:port of the Modbus TCP interface. The local
+	// mode is enabled when set.
+	Endpoint string `mapstructure:"endpoint"`
+	// UnitID is the Modbus unit identifier of the inverter.
+	UnitID byte `mapstructure:"unit_id"`
+	// Timeout is the connect and read timeout.
+	Timeout time.Duration `mapstructure:"timeout"`
+	// Registers maps the metrics, by legacy name like power_generating or
+	// energy_importing_total, to their registers. Replaces the default
+	// register map when set.
+	Registers map[string]RegisterConfig `mapstructure:"registers"`
+}
+
+// RegisterConfig locates a value in the Modbus registers.
+type RegisterConfig struct {
+	Address uint16 `mapstructure:"address"`
+	// Type is the register type: holding (default) or input.
+	Type string `mapstructure:"type"`
+	// Words is the number of registers holding the value: 1 (default),
+	// or 2 for a 32-bit value with the high word first.
+	Words int `mapstructure:"words"`
+	// Signed is true when the value is a two's complement integer.
+	Signed bool `mapstructure:"signed"`
+	// Scale converts the raw value to the unit of the metric: W, %, kWh
+	// or cycles. Defaults to 1.
+	Scale float64 `mapstructure:"scale"`
+}
+
+// modbusValues sets the value of each register on the metrics, by
+// register name.
+var modbusValues = map[string]func(m *azzurro.InverterMetrics, value float64){
+	"power_autoconsuming":        func(m *azzurro.InverterMetrics, v float64) { m.PowerAutoconsuming = v },
+	"power_charging":             func(m *azzurro.InverterMetrics, v float64) { m.PowerCharging = v },
+	"power_consuming":            func(m *azzurro.InverterMetrics, v float64) { m.PowerConsuming = v },
+	"power_discharging":          func(m *azzurro.InverterMetrics, v float64) { m.PowerDischarging = v },
+	"power_exporting":            func(m *azzurro.InverterMetrics, v float64) { m.PowerExporting = v },
+	"power_generating":           func(m *azzurro.InverterMetrics, v float64) { m.PowerGenerating = v },
+	"power_importing":            func(m *azzurro.InverterMetrics, v float64) { m.PowerImporting = v },
+	"battery_soc":                func(m *azzurro.InverterMetrics, v float64) { m.BatterySoC = int(math.Round(v)) },
+	"battery_cycletime_total":    func(m *azzurro.InverterMetrics, v float64) { m.BatteryCycletime = int(math.Round(v)) },
+	"energy_autoconsuming":       func(m *azzurro.InverterMetrics, v float64) { m.EnergyAutoconsuming = v },
+	"energy_charging":            func(m *azzurro.InverterMetrics, v float64) { m.EnergyCharging = v },
+	"energy_consuming":           func(m *azzurro.InverterMetrics, v float64) { m.EnergyConsuming = v },
+	"energy_discharging":         func(m *azzurro.InverterMetrics, v float64) { m.EnergyDischarging = v },
+	"energy_exporting":           func(m *azzurro.InverterMetrics, v float64) { m.EnergyExporting = v },
+	"energy_generating":          func(m *azzurro.InverterMetrics, v float64) { m.EnergyGenerating = v },
+	"energy_importing":           func(m *azzurro.InverterMetrics, v float64) { m.EnergyImporting = v },
+	"energy_autoconsuming_total": func(m *azzurro.InverterMetrics, v float64) { m.EnergyAutoconsumingTotal = v },
+	"energy_charging_total":      func(m *azzurro.InverterMetrics, v float64) { m.EnergyChargingTotal = v },
+	"energy_consuming_total":     func(m *azzurro.InverterMetrics, v float64) { m.EnergyConsumingTotal = v },
+	"energy_discharging_total":   func(m *azzurro.InverterMetrics, v float64) { m.EnergyDischargingTotal = v },
+	"energy_exporting_total":     func(m *azzurro.InverterMetrics, v float64) { m.EnergyExportingTotal = v },
+	"energy_generating_total":    func(m *azzurro.InverterMetrics, v float64) { m.EnergyGeneratingTotal = v },
+	"energy_importing_total":     func(m *azzurro.InverterMetrics, v float64) { m.EnergyImportingTotal = v },
+}
+
+// defaultModbusRegisters is the register map of the single-phase hybrid
+// inverters (1PH HYD3000-6000 ZSS), which use the register layout of the
+// Sofar ME3000SP protocol: the powers in hundredths of kW, the daily
+// energies in hundredths of kWh and the lifetime energies in kWh. The
+// grid and battery powers are signed net values in a single register,
+// so they aren't mapped.
+var defaultModbusRegisters = map[string]RegisterConfig{
+	"battery_soc":             {Address: 0x0210},
+	"power_consuming":         {Address: 0x0213, Scale: 10},
+	"power_generating":        {Address: 0x0215, Scale: 10},
+	"energy_generating":       {Address: 0x0218, Scale: 0.01},
+	"energy_exporting":        {Address: 0x0219, Scale: 0.01},
+	"energy_importing":        {Address: 0x021A, Scale: 0.01},
+	"energy_consuming":        {Address: 0x021B, Scale: 0.01},
+	"energy_generating_total": {Address: 0x021C, Words: 2},
+	"energy_exporting_total":  {Address: 0x021E, Words: 2},
+	"energy_importing_total":  {Address: 0x0220, Words: 2},
+	"energy_consuming_total":  {Address: 0x0222, Words: 2},
+}
+
+// registers returns the configured registers, or the default register
+// map.
+func (cfg ModbusConfig) registers() map[string]RegisterConfig {
+	if len(cfg.Registers) > 0 {
+		return cfg.Registers
+	}
+	return defaultModbusRegisters
+}
+
+func (cfg ModbusConfig) validate() error {
+	for _, name := range cfg.registerNames() {
+		if _, found := modbusValues[name]; !found {
+			return fmt.Errorf("modbus.registers: unknown register %q", name)
+		}
+		if err := cfg.registers()[name].register().Validate(); err != nil {
+			return fmt.Errorf("modbus.registers.%s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// registerNames returns the names of the registers, sorted so they are
+// checked and read in the same order.
+func (cfg ModbusConfig) registerNames() []string {
+	registers := cfg.registers()
+	names := make([]string, 0, len(registers))
+	for name := range registers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// register returns the register with the defaults: an unsigned 16-bit
+// holding register.
+func (r RegisterConfig) register() modbus.Register {
+	register := modbus.Register{
+		Address: r.Address,
+		Type:    r.Type,
+		Words:   r.Words,
+		Signed:  r.Signed,
+		Scale:   r.Scale,
+	}
+	if register.Type == "" {
+		register.Type = modbus.RegisterTypeHolding
+	}
+	if register.Words == 0 {
+		register.Words = 1
+	}
+	return register
+}
+
+// modbusClient reads the inverter over Modbus TCP and returns its values
+// as the realtime data of the cloud API, so the metrics are the same in
+// both modes.
+type modbusClient struct {
+	cfg    ModbusConfig
+	client *modbus.Client
+	now    func() time.Time
+	// started is the start of the lifetime counters, which the Modbus
+	// interface doesn't expose.
+	started time.Time
+}
+
+func newModbusClient(cfg ModbusConfig) *modbusClient {
+	return &modbusClient{
+		cfg:     cfg,
+		client:  modbus.NewClient(cfg.Endpoint, cfg.UnitID, cfg.Timeout),
+		now:     time.Now,
+		started: time.Now().UTC().Truncate(time.Second),
+	}
+}
+
+// FetchRealtimeData reads the registers and returns them as the realtime
+// data of the thing, updated now. The values without a register are
+// zero.
+func (c *modbusClient) FetchRealtimeData(_ context.Context, _ string) (azzurro.InverterMetrics, error) {
+	metrics := azzurro.InverterMetrics{
+		LastUpdate: c.now(),
+		ThingFind:  c.started.Format(thingFindLayout),
+	}
+	registers := c.cfg.registers()
+	for _, name := range c.cfg.registerNames() {
+		value, err := c.client.Read(registers[name].register())
+		if err != nil {
+			return azzurro.InverterMetrics{}, fmt.Errorf("read %s: %w", name, err)
+		}
+		modbusValues[name](&metrics, value)
+	}
+	return metrics, nil
+}
+
+// Close closes the connection to the inverter.
+func (c *modbusClient) Close() error {
+	return c.client.Close()
+}
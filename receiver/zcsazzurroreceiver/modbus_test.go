@@ -0,0 +1,134 @@
+package zcsazzurroreceiver
+
+import (
+	"testing"
+	"time"
+
+	"github.com/elastic/go-freelru"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+
+	"github.com/zmoog/collector/internal/modbus/modbustest"
+	"github.com/zmoog/collector/receiver/zcsazzurroreceiver/internal/metadata"
+)
+
+// newModbusServer returns a server answering with the registers of
+// testdata/modbus_registers.json, at the addresses of the default
+// register map.
+func newModbusServer(t *testing.T) *modbustest.Server {
+	t.Helper()
+
+	return modbustest.NewServer(t, modbustest.LoadRegisters(t, "testdata/modbus_registers.json"))
+}
+
+func newTestModbusConfig(endpoint string) ModbusConfig {
+	return ModbusConfig{
+		Endpoint: endpoint,
+		UnitID:   1,
+		Timeout:  time.Second,
+	}
+}
+
+func TestModbusClient_FetchRealtimeData(t *testing.T) {
+	server := newModbusServer(t)
+	now := time.Date(2024, 10, 22, 19, 46, 52, 0, time.UTC)
+
+	client := newModbusClient(newTestModbusConfig(server.Addr()))
+	client.now = func() time.Time { return now }
+	defer client.Close()
+
+	metrics, err := client.FetchRealtimeData(t.Context(), "my-serial-number")
+	require.NoError(t, err)
+	assert.Equal(t, now, metrics.LastUpdate)
+	assert.Equal(t, 950.0, metrics.PowerConsuming)
+	assert.Zero(t, metrics.PowerGenerating)
+	assert.Equal(t, 20, metrics.BatterySoC)
+	assert.Equal(t, 20.46, metrics.EnergyGenerating)
+	assert.Equal(t, 0.18, metrics.EnergyExporting)
+	assert.Equal(t, 3.66, metrics.EnergyImporting)
+	assert.Equal(t, 27.4, metrics.EnergyConsuming)
+	assert.Equal(t, 3265.0, metrics.EnergyGeneratingTotal, "32-bit register")
+	assert.Equal(t, 1328.0, metrics.EnergyExportingTotal)
+	assert.Equal(t, 546.0, metrics.EnergyImportingTotal)
+	assert.Equal(t, 2100.0, metrics.EnergyConsumingTotal)
+	assert.Zero(t, metrics.PowerImporting, "no register")
+}
+
+func TestModbusClient_FetchRealtimeDataRegisters(t *testing.T) {
+	server := newModbusServer(t)
+
+	// The configured registers replace the default register map.
+	cfg := newTestModbusConfig(server.Addr())
+	cfg.Registers = map[string]RegisterConfig{
+		"power_importing": {Address: 0x0213, Scale: 10},
+	}
+	client := newModbusClient(cfg)
+	defer client.Close()
+
+	metrics, err := client.FetchRealtimeData(t.Context(), "my-serial-number")
+	require.NoError(t, err)
+	assert.Equal(t, 950.0, metrics.PowerImporting)
+	assert.Zero(t, metrics.PowerConsuming)
+	assert.Zero(t, metrics.BatterySoC)
+}
+
+func TestModbusClient_MissingRegister(t *testing.T) {
+	server := newModbusServer(t)
+
+	cfg := newTestModbusConfig(server.Addr())
+	cfg.Registers = map[string]RegisterConfig{"energy_exporting": {Address: 999}}
+	client := newModbusClient(cfg)
+	defer client.Close()
+
+	_, err := client.FetchRealtimeData(t.Context(), "my-serial-number")
+	assert.ErrorContains(t, err, "read energy_exporting")
+}
+
+func TestScraper_Modbus(t *testing.T) {
+	server := newModbusServer(t)
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.CollectionInterval = 5 * time.Second
+	cfg.ThingKey = "my-serial-number"
+	cfg.Timezone = "Europe/Rome"
+	cfg.Modbus = newTestModbusConfig(server.Addr())
+	require.NoError(t, cfg.Validate(), "no cloud credentials needed")
+
+	cache, err := freelru.NewSynced[string, time.Time](cfg.CacheSize, hashString)
+	require.NoError(t, err)
+	s := newScraper(cfg, receivertest.NewNopSettings(metadata.Type), cache)
+	require.NoError(t, s.start(t.Context(), componenttest.NewNopHost()))
+	defer func() { require.NoError(t, s.shutdown(t.Context())) }()
+
+	metrics, err := s.scrape(t.Context())
+	require.NoError(t, err)
+	require.Equal(t, 1, metrics.ResourceMetrics().Len())
+
+	resourceMetrics := metrics.ResourceMetrics().At(0)
+	thingKey, _ := resourceMetrics.Resource().Attributes().Get("thing_key")
+	assert.Equal(t, "my-serial-number", thingKey.Str())
+
+	values := make(map[string]float64)
+	scopeMetrics := resourceMetrics.ScopeMetrics().At(0).Metrics()
+	for i := 0; i < scopeMetrics.Len(); i++ {
+		metric := scopeMetrics.At(i)
+		var dp pmetric.NumberDataPoint
+		if metric.Type() == pmetric.MetricTypeGauge {
+			dp = metric.Gauge().DataPoints().At(0)
+		} else {
+			dp = metric.Sum().DataPoints().At(0)
+		}
+		if dp.ValueType() == pmetric.NumberDataPointValueTypeInt {
+			values[metric.Name()] = float64(dp.IntValue())
+		} else {
+			values[metric.Name()] = dp.DoubleValue()
+		}
+	}
+	assert.Equal(t, 950.0, values["power_consuming"])
+	assert.Equal(t, 20.0, values["battery_soc"])
+	assert.Equal(t, 20.46, values["energy_generating"])
+	assert.Equal(t, 3265.0, values["energy_generating_total"])
+}
@@ -2,101 +2,283 @@ package zcsazzurroreceiver
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
 	"time"
 
 	"github.com/elastic/go-freelru"
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componentstatus"
+	"go.opentelemetry.io/collector/extension/xextension/storage"
+	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/receiver"
+	"go.opentelemetry.io/collector/scraper/scrapererror"
 	"go.uber.org/zap"
 
 	"github.com/zmoog/zcs/azzurro"
+
+	"github.com/zmoog/collector/receiver/zcsazzurroreceiver/zcsapi"
 )
 
 // zcsazzurroScraper is the struct that contains the ZCS Azzurro scraper.
 type zcsazzurroScraper struct {
-	cfg       *Config
-	settings  component.TelemetrySettings
-	client    *azzurro.Client
+	cfg      *Config
+	id       component.ID
+	settings component.TelemetrySettings
+	// endpoint is the ZCS API endpoint, the default one when empty.
+	endpoint string
+	// clients holds the API client of each thing, by thing key, or the
+	// Modbus client in the local mode.
+	clients   map[string]realtimeDataClient
 	marshaler *azzurroRealtimeDataMarshaler
 	cache     *freelru.SyncedLRU[string, time.Time]
+	// storageClient keeps the last update of the things, when enabled.
+	storageClient storage.Client
+	daily         *dailyResetTracker
+	// host receives the component status; degraded is true after
+	// reporting an error.
+	host     component.Host
+	degraded bool
+}
+
+// realtimeDataClient fetches the realtime data of a thing, from the cloud
+// API or from the inverter in the local mode.
+type realtimeDataClient interface {
+	FetchRealtimeData(ctx context.Context, thingKey string) (azzurro.InverterMetrics, error)
 }
 
 // newScraper is the function that creates a new ZCS Azzurro scraper.
 func newScraper(cfg *Config, settings receiver.Settings, cache *freelru.SyncedLRU[string, time.Time]) *zcsazzurroScraper {
-	client := azzurro.NewClient(cfg.AuthKey, cfg.ClientID)
 	return &zcsazzurroScraper{
 		cfg:       cfg,
+		id:        settings.ID,
 		settings:  settings.TelemetrySettings,
-		client:    client,
-		marshaler: newAzzurroRealtimeDataMarshaler(settings.Logger),
+		marshaler: newAzzurroRealtimeDataMarshaler(cfg.MetricsBuilderConfig, settings),
 		cache:     cache,
 	}
 }
 
-// start is the function that starts the ZCS Azzurro scraper.
-func (s *zcsazzurroScraper) start(_ context.Context, host component.Host) error {
-	// Nothing special needed for start - client is already initialized
-	return nil
-}
+// start is the function that starts the ZCS Azzurro scraper. It
+// creates the API clients of each thing, and opens the storage of the
+// last updates when enabled.
+func (s *zcsazzurroScraper) start(ctx context.Context, host component.Host) (err error) {
+	s.host = host
+	s.startClients()
 
-// scrape is the main function that scrapes the data from the ZCS Azzurro API.
-func (s *zcsazzurroScraper) scrape(ctx context.Context) (pmetric.Metrics, error) {
-	realtimeDataResponse, err := s.client.FetchRealtimeData(s.cfg.ThingKey)
+	for _, thing := range s.cfg.things() {
+		if thing.Timezone == "" {
+			s.settings.Logger.Warn("No timezone set, the daily energy sums start at midnight UTC",
+				zap.String("thingKey", thing.ThingKey))
+		}
+	}
+
+	s.daily, err = newDailyResetTracker(s.cfg.CacheSize)
 	if err != nil {
-		return pmetric.NewMetrics(), err
+		return err
 	}
 
-	if !realtimeDataResponse.RealtimeData.Success {
-		s.settings.Logger.Error("Failed to fetch realtime data", zap.Any("response", realtimeDataResponse))
-		return pmetric.NewMetrics(), nil // Return empty metrics instead of error for non-critical failures
+	if s.cfg.StorageID != nil {
+		s.storageClient, err = getStorageClient(ctx, host, *s.cfg.StorageID, s.id)
+	}
+	return err
+}
+
+// startClients creates the API clients of each thing.
+func (s *zcsazzurroScraper) startClients() {
+	endpoint := s.endpoint
+	if endpoint == "" {
+		endpoint = zcsapi.DefaultEndpoint
+	}
+
+	s.clients = make(map[string]realtimeDataClient)
+	if s.cfg.local() {
+		s.clients[s.cfg.ThingKey] = newModbusClient(s.cfg.Modbus)
+		return
+	}
+	for _, thing := range s.cfg.things() {
+		s.clients[thing.ThingKey] = zcsapi.NewClient(thing.AuthKey, thing.ClientID, endpoint)
+	}
+}
+
+// shutdown closes the storage clients, and the connection to the
+// inverter in the local mode.
+func (s *zcsazzurroScraper) shutdown(ctx context.Context) error {
+	var errs error
+	if s.storageClient != nil {
+		errs = s.storageClient.Close(ctx)
+	}
+	for _, client := range s.clients {
+		if closer, ok := client.(io.Closer); ok {
+			errs = errors.Join(errs, closer.Close())
+		}
 	}
+	return errs
+}
+
+// scrape is the main function that scrapes the data from the ZCS Azzurro
+// API. It returns the metrics of the things that succeeded.
+func (s *zcsazzurroScraper) scrape(ctx context.Context) (pmetric.Metrics, error) {
+	things := s.cfg.things()
+	results := make([]pmetric.Metrics, len(things))
+
+	errs := s.eachThing(things, func(i int, thing ThingConfig) (err error) {
+		results[i], err = s.scrapeThing(ctx, thing)
+		return err
+	})
 
 	// Aggregate all metrics from all things into a single pmetric.Metrics
 	allMetrics := pmetric.NewMetrics()
-	
-	for _, v := range realtimeDataResponse.RealtimeData.Params.Value {
-		for thingKey, metrics := range v {
-			if !s.shouldProcessThing(thingKey, metrics.LastUpdate) {
-				s.settings.Logger.Debug("Skipping thing - no new data",
-					zap.String("thingKey", thingKey),
-					zap.Time("lastUpdate", metrics.LastUpdate))
-				continue
-			}
-
-			processedMetrics, err := s.marshaler.UnmarshalMetrics(thingKey, metrics)
-			if err != nil {
-				s.settings.Logger.Error("Error unmarshalling zcsazzurro metrics", zap.Error(err))
-				continue
-			}
-
-			// Only update state after successful processing
-			s.updateThingState(thingKey, metrics.LastUpdate)
-			s.settings.Logger.Debug("Cache keys", zap.Any("keys", s.cache.Keys()))
-
-			s.settings.Logger.Info("Successfully processed metrics",
-				zap.String("thingKey", thingKey),
-				zap.Time("lastUpdate", metrics.LastUpdate))
-
-			// Merge metrics into the aggregated result
-			processedMetrics.ResourceMetrics().MoveAndAppendTo(allMetrics.ResourceMetrics())
+	for i := range things {
+		if errs[i] == nil {
+			results[i].ResourceMetrics().MoveAndAppendTo(allMetrics.ResourceMetrics())
 		}
 	}
 
+	err := thingsError(things, errs)
+	s.reportStatus(err)
+	return allMetrics, err
+}
+
+// eachThing calls scrape for each thing, up to max_concurrency at a time,
+// and returns the errors by thing.
+func (s *zcsazzurroScraper) eachThing(things []ThingConfig, scrape func(i int, thing ThingConfig) error) []error {
+	errs := make([]error, len(things))
+
+	semaphore := make(chan struct{}, s.cfg.MaxConcurrency)
+	var wg sync.WaitGroup
+	for i, thing := range things {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+
+			errs[i] = scrape(i, thing)
+		}()
+	}
+	wg.Wait()
+
+	return errs
+}
+
+// thingsError returns the errors of the things: a partial scrape error
+// when some things succeeded, so their data is still reported.
+func thingsError(things []ThingConfig, errs []error) error {
+	var err error
+	failed := 0
+	for i, thing := range things {
+		if errs[i] != nil {
+			failed++
+			err = errors.Join(err, fmt.Errorf("thing %s: %w", thing.ThingKey, errs[i]))
+		}
+	}
+
+	if err != nil && failed < len(things) {
+		return scrapererror.NewPartialScrapeError(err, failed)
+	}
+	return err
+}
+
+// scrapeThing fetches the realtime data of the thing and returns its
+// metrics when the data is new.
+func (s *zcsazzurroScraper) scrapeThing(ctx context.Context, thing ThingConfig) (pmetric.Metrics, error) {
+	metrics, err := s.clients[thing.ThingKey].FetchRealtimeData(ctx, thing.ThingKey)
+	if err != nil {
+		return pmetric.NewMetrics(), err
+	}
+
+	allMetrics := pmetric.NewMetrics()
+	// The time zone is checked when validating the config.
+	loc, _ := time.LoadLocation(thing.Timezone)
+
+	if s.shouldProcessThing(ctx, thing.ThingKey, metrics.LastUpdate) {
+		dailyStart := s.daily.start(thing.ThingKey, metrics, loc)
+		processedMetrics, err := s.marshaler.UnmarshalMetrics(thing.ThingKey, metrics, dailyStart)
+		if err != nil {
+			return pmetric.NewMetrics(), err
+		}
+
+		// Only update state after successful processing
+		s.updateThingState(ctx, thing.ThingKey, metrics.LastUpdate)
+
+		s.settings.Logger.Debug("Successfully processed metrics",
+			zap.String("thingKey", thing.ThingKey),
+			zap.Time("lastUpdate", metrics.LastUpdate))
+
+		for i := 0; i < processedMetrics.ResourceMetrics().Len(); i++ {
+			putThingAttributes(processedMetrics.ResourceMetrics().At(i).Resource().Attributes(), thing)
+		}
+		processedMetrics.ResourceMetrics().MoveAndAppendTo(allMetrics.ResourceMetrics())
+	} else {
+		s.settings.Logger.Debug("Skipping thing - no new data",
+			zap.String("thingKey", thing.ThingKey),
+			zap.Time("lastUpdate", metrics.LastUpdate))
+	}
+
 	return allMetrics, nil
 }
 
+// reportStatus reports the outcome of a scrape to the host. The failures
+// are recoverable, even when the API rejects the credentials of all the
+// things: the collector doesn't accept any status after a permanent
+// error, so the status couldn't go back to OK once the auth_key works
+// again.
+func (s *zcsazzurroScraper) reportStatus(err error) {
+	if s.host == nil {
+		return
+	}
+
+	if err == nil {
+		if s.degraded {
+			s.degraded = false
+			componentstatus.ReportStatus(s.host, componentstatus.NewEvent(componentstatus.StatusOK))
+		}
+		return
+	}
+
+	s.degraded = true
+	componentstatus.ReportStatus(s.host, componentstatus.NewRecoverableErrorEvent(err))
+}
+
+// putThingAttributes sets the name and the additional attributes of the
+// thing on m.
+func putThingAttributes(m pcommon.Map, thing ThingConfig) {
+	if thing.Name != "" {
+		m.PutStr("thing_name", thing.Name)
+	}
+	for name, value := range thing.Attributes {
+		// The values are checked when validating the config.
+		_ = m.PutEmpty(name).FromRaw(value)
+	}
+}
+
 // shouldProcessThing checks if we should process metrics for this thing
 // Returns true if:
 // - We haven't seen this thing before, OR
 // - The metrics timestamp is newer than what we last processed
-func (s *zcsazzurroScraper) shouldProcessThing(thingKey string, metricsTime time.Time) bool {
+//
+// The things missing in the cache, after a restart or an eviction, are
+// looked up in the storage when enabled.
+func (s *zcsazzurroScraper) shouldProcessThing(ctx context.Context, thingKey string, metricsTime time.Time) bool {
 	s.settings.Logger.Debug("Checking if should process thing",
 		zap.String("thingKey", thingKey),
 		zap.Time("metricsTime", metricsTime))
 
 	s.settings.Logger.Debug("Cache keys", zap.Any("keys", s.cache.Keys()))
 	lastUpdate, exists := s.cache.Get(thingKey)
+	if !exists && s.storageClient != nil {
+		var err error
+		lastUpdate, exists, err = loadLastUpdate(ctx, s.storageClient, thingKey)
+		if err != nil {
+			s.settings.Logger.Warn("Error loading the last update", zap.String("thingKey", thingKey), zap.Error(err))
+		}
+		if exists {
+			s.cache.Add(thingKey, lastUpdate)
+		}
+	}
 	if !exists {
 		s.settings.Logger.Debug("Thing not seen before, processing", zap.String("thingKey", thingKey))
 		return true
@@ -109,34 +291,15 @@ func (s *zcsazzurroScraper) shouldProcessThing(thingKey string, metricsTime time
 }
 
 // updateThingState updates the tracking state for a thing
-func (s *zcsazzurroScraper) updateThingState(thingKey string, metricsTime time.Time) {
+func (s *zcsazzurroScraper) updateThingState(ctx context.Context, thingKey string, metricsTime time.Time) {
 	s.settings.Logger.Debug("Updating thing state",
 		zap.String("thingKey", thingKey),
 		zap.Time("metricsTime", metricsTime))
 	s.cache.Add(thingKey, metricsTime)
-}
 
-// Legacy scraper struct and functions for backward compatibility
-// These are preserved but not used in the new implementation
-
-func NewScraper(clientID, authKey, thingKey string, logger *zap.Logger) *Scraper {
-	client := azzurro.NewClient(authKey, clientID)
-	return &Scraper{
-		client: client,
-		logger: logger,
-	}
-}
-
-type Scraper struct {
-	logger *zap.Logger
-	client *azzurro.Client
-}
-
-func (s *Scraper) Scrape(thingKey string) (azzurro.RealtimeDataResponse, error) {
-	response, err := s.client.FetchRealtimeData(thingKey)
-	if err != nil {
-		return azzurro.RealtimeDataResponse{}, err
+	if s.storageClient != nil {
+		if err := saveLastUpdate(ctx, s.storageClient, thingKey, metricsTime); err != nil {
+			s.settings.Logger.Warn("Error saving the last update", zap.String("thingKey", thingKey), zap.Error(err))
+		}
 	}
-
-	return response, nil
 }
@@ -0,0 +1,325 @@
+package zcsazzurroreceiver
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/elastic/go-freelru"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componentstatus"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+	"go.opentelemetry.io/collector/scraper/scrapererror"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/zmoog/collector/receiver/zcsazzurroreceiver/internal/metadata"
+	"github.com/zmoog/collector/receiver/zcsazzurroreceiver/zcsapi"
+)
+
+// newTestServer returns a ZCS API stand-in answering with
+// testdata/response.json for the requested thing. The requests with the
+// "wrong-key" auth key fail.
+func newTestServer(t *testing.T) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+
+	response, err := os.ReadFile("testdata/response.json")
+	require.NoError(t, err)
+
+	var inFlight, maxInFlight atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		current := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			peak := maxInFlight.Load()
+			if current <= peak || maxInFlight.CompareAndSwap(peak, current) {
+				break
+			}
+		}
+		// Leave time to the other requests to overlap.
+		time.Sleep(20 * time.Millisecond)
+
+		if r.Header.Get("Authorization") == "wrong-key" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		var request map[string]struct {
+			Params map[string]string `json:"params"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		thingKey := request["realtimeData"].Params["thingKey"]
+		_, _ = w.Write([]byte(strings.ReplaceAll(string(response), "my-serial-number", thingKey)))
+	}))
+	t.Cleanup(server.Close)
+
+	return server, &maxInFlight
+}
+
+func newTestScraper(t *testing.T, cfg *Config) *zcsazzurroScraper {
+	t.Helper()
+
+	server, _ := newTestServer(t)
+	return newTestScraperWithServer(t, cfg, server)
+}
+
+func newTestScraperWithServer(t *testing.T, cfg *Config, server *httptest.Server) *zcsazzurroScraper {
+	t.Helper()
+
+	return newTestScraperWithHost(t, cfg, server, componenttest.NewNopHost())
+}
+
+func newTestScraperWithHost(t *testing.T, cfg *Config, server *httptest.Server, host component.Host) *zcsazzurroScraper {
+	t.Helper()
+
+	cache, err := freelru.NewSynced[string, time.Time](cfg.CacheSize, hashString)
+	require.NoError(t, err)
+
+	s := newScraper(cfg, receivertest.NewNopSettings(metadata.Type), cache)
+	s.endpoint = server.URL
+	require.NoError(t, s.start(t.Context(), host))
+	return s
+}
+
+func TestScraper_ScrapeThings(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.ClientID = "my-client-id"
+	cfg.AuthKey = "my-auth-key"
+	cfg.MaxConcurrency = 2
+	cfg.Timezone = "Europe/Rome"
+	cfg.Things = []ThingConfig{
+		{Name: "garage", ThingKey: "thing-1", Attributes: map[string]any{"site": "home"}},
+		{ThingKey: "thing-2", AuthKey: "other-auth-key"},
+		{ThingKey: "thing-3"},
+	}
+	require.NoError(t, cfg.Validate())
+
+	server, maxInFlight := newTestServer(t)
+	s := newTestScraperWithServer(t, cfg, server)
+
+	metrics, err := s.scrape(t.Context())
+	require.NoError(t, err)
+	require.Equal(t, 3, metrics.ResourceMetrics().Len())
+	assert.LessOrEqual(t, maxInFlight.Load(), int32(2), "at most max_concurrency requests at a time")
+
+	attributes := metrics.ResourceMetrics().At(0).Resource().Attributes().AsRaw()
+	assert.Equal(t, map[string]any{"thing_key": "thing-1", "thing_name": "garage", "site": "home"}, attributes)
+	attributes = metrics.ResourceMetrics().At(1).Resource().Attributes().AsRaw()
+	assert.Equal(t, map[string]any{"thing_key": "thing-2"}, attributes)
+
+	// No new data since the previous scrape.
+	metrics, err = s.scrape(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, 0, metrics.ResourceMetrics().Len())
+}
+
+func TestScraper_ScrapeThingsPartialFailure(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.ClientID = "my-client-id"
+	cfg.AuthKey = "my-auth-key"
+	cfg.Things = []ThingConfig{
+		{ThingKey: "thing-1"},
+		{ThingKey: "thing-2", AuthKey: "wrong-key"},
+	}
+	s := newTestScraper(t, cfg)
+
+	metrics, err := s.scrape(t.Context())
+	require.Error(t, err)
+	assert.True(t, scrapererror.IsPartialScrapeError(err))
+	assert.ErrorContains(t, err, "thing thing-2")
+	assert.Equal(t, 1, metrics.ResourceMetrics().Len())
+
+	// All the things fail.
+	cfg.Things = cfg.Things[1:]
+	s = newTestScraper(t, cfg)
+	_, err = s.scrape(t.Context())
+	require.Error(t, err)
+	assert.False(t, scrapererror.IsPartialScrapeError(err))
+}
+
+func TestScraper_Timezone(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.ClientID = "my-client-id"
+	cfg.AuthKey = "my-auth-key"
+	cfg.Timezone = "Europe/Rome"
+	cfg.Things = []ThingConfig{
+		{ThingKey: "thing-1"},
+		{ThingKey: "thing-2", Timezone: "America/New_York"},
+	}
+	require.NoError(t, cfg.Validate())
+
+	s := newTestScraper(t, cfg)
+	metrics, err := s.scrape(t.Context())
+	require.NoError(t, err)
+	require.Equal(t, 2, metrics.ResourceMetrics().Len())
+
+	// The last update is 2024-10-22T19:46:52Z.
+	expected := []time.Time{
+		time.Date(2024, 10, 21, 22, 0, 0, 0, time.UTC), // the receiver time zone
+		time.Date(2024, 10, 22, 4, 0, 0, 0, time.UTC),  // the thing time zone
+	}
+	for i, start := range expected {
+		scopeMetrics := metrics.ResourceMetrics().At(i).ScopeMetrics().At(0)
+		for j := 0; j < scopeMetrics.Metrics().Len(); j++ {
+			metric := scopeMetrics.Metrics().At(j)
+			if metric.Name() == "energy_generating" {
+				assert.Equal(t, start, metric.Sum().DataPoints().At(0).StartTimestamp().AsTime())
+			}
+		}
+	}
+}
+
+func TestScraper_NoTimezone(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.ClientID = "my-client-id"
+	cfg.AuthKey = "my-auth-key"
+	cfg.ThingKey = "thing-1"
+	require.NoError(t, cfg.Validate())
+
+	server, _ := newTestServer(t)
+	cache, err := freelru.NewSynced[string, time.Time](cfg.CacheSize, hashString)
+	require.NoError(t, err)
+
+	core, logs := observer.New(zap.WarnLevel)
+	settings := receivertest.NewNopSettings(metadata.Type)
+	settings.Logger = zap.New(core)
+	s := newScraper(cfg, settings, cache)
+	s.endpoint = server.URL
+	require.NoError(t, s.start(t.Context(), componenttest.NewNopHost()))
+	require.Equal(t, 1, logs.FilterMessageSnippet("No timezone set").Len())
+
+	metrics, err := s.scrape(t.Context())
+	require.NoError(t, err)
+
+	scopeMetrics := metrics.ResourceMetrics().At(0).ScopeMetrics().At(0)
+	for i := 0; i < scopeMetrics.Metrics().Len(); i++ {
+		metric := scopeMetrics.Metrics().At(i)
+		if metric.Name() == "energy_generating" {
+			// The last update is 2024-10-22T19:46:52Z.
+			assert.Equal(t, time.Date(2024, 10, 22, 0, 0, 0, 0, time.UTC), metric.Sum().DataPoints().At(0).StartTimestamp().AsTime())
+		}
+	}
+}
+
+// statusTransitions are the status transitions accepted by the
+// collector, the other ones are rejected.
+var statusTransitions = map[componentstatus.Status][]componentstatus.Status{
+	componentstatus.StatusStarting: {
+		componentstatus.StatusOK, componentstatus.StatusRecoverableError, componentstatus.StatusPermanentError,
+		componentstatus.StatusFatalError, componentstatus.StatusStopping,
+	},
+	componentstatus.StatusOK: {
+		componentstatus.StatusRecoverableError, componentstatus.StatusPermanentError,
+		componentstatus.StatusFatalError, componentstatus.StatusStopping,
+	},
+	componentstatus.StatusRecoverableError: {
+		componentstatus.StatusOK, componentstatus.StatusRecoverableError, componentstatus.StatusPermanentError,
+		componentstatus.StatusFatalError, componentstatus.StatusStopping,
+	},
+	componentstatus.StatusPermanentError: {componentstatus.StatusStopping},
+	componentstatus.StatusStopping: {
+		componentstatus.StatusRecoverableError, componentstatus.StatusPermanentError,
+		componentstatus.StatusFatalError, componentstatus.StatusStopped,
+	},
+}
+
+// statusHost is a host recording the reported status events. Like the
+// collector, it rejects the invalid transitions, failing the test.
+type statusHost struct {
+	component.Host
+	t        *testing.T
+	statuses []componentstatus.Status
+}
+
+func newStatusHost(t *testing.T) *statusHost {
+	return &statusHost{Host: componenttest.NewNopHost(), t: t}
+}
+
+func (h *statusHost) Report(event *componentstatus.Event) {
+	current := componentstatus.StatusStarting
+	if len(h.statuses) > 0 {
+		current = h.statuses[len(h.statuses)-1]
+	}
+	if !slices.Contains(statusTransitions[current], event.Status()) {
+		h.t.Errorf("invalid status transition from %v to %v", current, event.Status())
+		return
+	}
+	h.statuses = append(h.statuses, event.Status())
+}
+
+func TestScraper_ReportStatus(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.ClientID = "my-client-id"
+	cfg.AuthKey = "wrong-key"
+	cfg.Things = []ThingConfig{{ThingKey: "thing-1"}}
+
+	server, _ := newTestServer(t)
+	host := newStatusHost(t)
+	s := newTestScraperWithHost(t, cfg, server, host)
+
+	// The API rejects the credentials of all the things.
+	_, err := s.scrape(t.Context())
+	var authErr *zcsapi.AuthError
+	require.ErrorAs(t, err, &authErr)
+	assert.Equal(t, []componentstatus.Status{componentstatus.StatusRecoverableError}, host.statuses)
+
+	// The credentials work again.
+	s.clients["thing-1"] = zcsapi.NewClient("my-auth-key", "my-client-id", server.URL)
+	metrics, err := s.scrape(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, 1, metrics.ResourceMetrics().Len())
+	assert.Equal(t, []componentstatus.Status{componentstatus.StatusRecoverableError, componentstatus.StatusOK}, host.statuses)
+
+	// Nothing to report while healthy.
+	_, err = s.scrape(t.Context())
+	require.NoError(t, err)
+	assert.Len(t, host.statuses, 2)
+}
+
+func TestScraper_ReportStatusPartialFailure(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.ClientID = "my-client-id"
+	cfg.AuthKey = "my-auth-key"
+	cfg.Things = []ThingConfig{
+		{ThingKey: "thing-1"},
+		{ThingKey: "thing-2", AuthKey: "wrong-key"},
+	}
+
+	server, _ := newTestServer(t)
+	host := newStatusHost(t)
+	s := newTestScraperWithHost(t, cfg, server, host)
+
+	_, err := s.scrape(t.Context())
+	require.Error(t, err)
+	assert.Equal(t, []componentstatus.Status{componentstatus.StatusRecoverableError}, host.statuses)
+}
+
+func TestScraper_ScrapeUnsuccessful(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"realtimeData": {"params": {}, "success": false}}`))
+	}))
+	t.Cleanup(server.Close)
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.ClientID = "my-client-id"
+	cfg.AuthKey = "my-auth-key"
+	cfg.ThingKey = "thing-1"
+	s := newTestScraperWithServer(t, cfg, server)
+
+	_, err := s.scrape(t.Context())
+	var commandErr *zcsapi.CommandError
+	require.ErrorAs(t, err, &commandErr, "not an empty scrape")
+}
@@ -0,0 +1,55 @@
+package zcsazzurroreceiver
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/extension/xextension/storage"
+)
+
+// getStorageClient returns the client of the storage extension
+// storageID.
+func getStorageClient(ctx context.Context, host component.Host, storageID component.ID, id component.ID) (storage.Client, error) {
+	ext, found := host.GetExtensions()[storageID]
+	if !found {
+		return nil, fmt.Errorf("storage extension %s not found", storageID)
+	}
+
+	storageExt, ok := ext.(storage.Extension)
+	if !ok {
+		return nil, fmt.Errorf("extension %s is not a storage extension", storageID)
+	}
+
+	return storageExt.GetClient(ctx, component.KindReceiver, id, "")
+}
+
+// loadLastUpdate returns the last update of the thing stored in the
+// storage, if any.
+func loadLastUpdate(ctx context.Context, client storage.Client, thingKey string) (time.Time, bool, error) {
+	data, err := client.Get(ctx, lastUpdateStorageKey(thingKey))
+	if err != nil || data == nil {
+		return time.Time{}, false, err
+	}
+
+	var lastUpdate time.Time
+	if err := lastUpdate.UnmarshalText(data); err != nil {
+		return time.Time{}, false, fmt.Errorf("parse last update: %w", err)
+	}
+	return lastUpdate, true, nil
+}
+
+// saveLastUpdate stores the last update of the thing.
+func saveLastUpdate(ctx context.Context, client storage.Client, thingKey string, lastUpdate time.Time) error {
+	data, err := lastUpdate.MarshalText()
+	if err != nil {
+		return err
+	}
+	return client.Set(ctx, lastUpdateStorageKey(thingKey), data)
+}
+
+// lastUpdateStorageKey returns the storage key of the thing last update.
+func lastUpdateStorageKey(thingKey string) string {
+	return "last_update." + thingKey
+}
@@ -0,0 +1,103 @@
+package zcsazzurroreceiver
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/extension/xextension/storage"
+)
+
+// memoryStorage is a storage extension keeping the data in memory.
+type memoryStorage struct {
+	component.StartFunc
+	component.ShutdownFunc
+	mu   sync.Mutex
+	data map[string][]byte
+}
+
+func (m *memoryStorage) GetClient(context.Context, component.Kind, component.ID, string) (storage.Client, error) {
+	return m, nil
+}
+
+func (m *memoryStorage) Get(_ context.Context, key string) ([]byte, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.data[key], nil
+}
+
+func (m *memoryStorage) Set(_ context.Context, key string, value []byte) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.data[key] = value
+	return nil
+}
+
+func (m *memoryStorage) Delete(_ context.Context, key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.data, key)
+	return nil
+}
+
+func (m *memoryStorage) Batch(context.Context, ...*storage.Operation) error {
+	return nil
+}
+
+func (m *memoryStorage) Close(context.Context) error {
+	return nil
+}
+
+// storageHost is a host with a memory storage extension.
+type storageHost struct {
+	component.Host
+	extensions map[component.ID]component.Component
+}
+
+func (h storageHost) GetExtensions() map[component.ID]component.Component {
+	return h.extensions
+}
+
+func newStorageHost(storageID component.ID, ext *memoryStorage) storageHost {
+	return storageHost{
+		Host:       componenttest.NewNopHost(),
+		extensions: map[component.ID]component.Component{storageID: ext},
+	}
+}
+
+func TestScraper_StorageLastUpdate(t *testing.T) {
+	storageID := component.MustNewID("file_storage")
+	ext := &memoryStorage{data: make(map[string][]byte)}
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.ClientID = "my-client-id"
+	cfg.AuthKey = "my-auth-key"
+	cfg.ThingKey = "thing-1"
+	cfg.StorageID = &storageID
+	require.NoError(t, cfg.Validate())
+
+	server, _ := newTestServer(t)
+	s := newTestScraperWithHost(t, cfg, server, newStorageHost(storageID, ext))
+	metrics, err := s.scrape(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, 1, metrics.ResourceMetrics().Len())
+	assert.Equal(t, "2024-10-22T19:46:52Z", string(ext.data[lastUpdateStorageKey("thing-1")]))
+	require.NoError(t, s.shutdown(t.Context()))
+
+	// After a restart, the last reading is not emitted again.
+	s = newTestScraperWithHost(t, cfg, server, newStorageHost(storageID, ext))
+	metrics, err = s.scrape(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, 0, metrics.ResourceMetrics().Len())
+
+	// Without the storage, it is.
+	cfg.StorageID = nil
+	s = newTestScraperWithHost(t, cfg, server, componenttest.NewNopHost())
+	metrics, err = s.scrape(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, 1, metrics.ResourceMetrics().Len())
+}
@@ -0,0 +1,234 @@
+// Package zcsapi is a ZCS Azzurro API client with context support, typed
+// errors and retries.
+package zcsapi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/zmoog/zcs/azzurro"
+)
+
+// DefaultEndpoint is the endpoint of the ZCS Azzurro third-party API.
+const DefaultEndpoint = "https://third.zcsazzurroportal.com:19003"
+
+const (
+	defaultMaxRetries     = 2
+	defaultInitialBackoff = time.Second
+	maxBackoff            = 30 * time.Second
+)
+
+// Client is a ZCS Azzurro API client.
+type Client struct {
+	httpClient *http.Client
+	endpoint   string
+	authKey    string
+	clientID   string
+	// maxRetries is the number of times a command is sent again after a
+	// transient failure, waiting initialBackoff, doubled each time.
+	maxRetries     int
+	initialBackoff time.Duration
+}
+
+// NewClient creates a new ZCS Azzurro API client. The endpoint defaults
+// to DefaultEndpoint when empty.
+func NewClient(authKey, clientID, endpoint string) *Client {
+	if endpoint == "" {
+		endpoint = DefaultEndpoint
+	}
+	return &Client{
+		httpClient:     &http.Client{Timeout: 30 * time.Second},
+		endpoint:       strings.TrimRight(endpoint, "/"),
+		authKey:        authKey,
+		clientID:       clientID,
+		maxRetries:     defaultMaxRetries,
+		initialBackoff: defaultInitialBackoff,
+	}
+}
+
+// TransportError is returned when a command can't reach the API.
+type TransportError struct {
+	Command string
+	Err     error
+}
+
+func (e *TransportError) Error() string {
+	return fmt.Sprintf("%s request failed: %v", e.Command, e.Err)
+}
+
+func (e *TransportError) Unwrap() error {
+	return e.Err
+}
+
+// AuthError is returned when the API rejects the client ID or the
+// authentication key.
+type AuthError struct {
+	StatusCode int
+	Command    string
+}
+
+func (e *AuthError) Error() string {
+	return fmt.Sprintf("%s rejected the credentials with status code %d", e.Command, e.StatusCode)
+}
+
+// NotFoundError is returned when the API doesn't know the thing.
+type NotFoundError struct {
+	Command  string
+	ThingKey string
+}
+
+func (e *NotFoundError) Error() string {
+	return fmt.Sprintf("%s: thing %s not found", e.Command, e.ThingKey)
+}
+
+// StatusError is returned when the API answers with an unexpected
+// status code.
+type StatusError struct {
+	StatusCode int
+	Command    string
+	Body       string
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("unexpected status code %d from %s: %s", e.StatusCode, e.Command, e.Body)
+}
+
+// CommandError is returned when the API answers a command with success
+// set to false.
+type CommandError struct {
+	Command string
+}
+
+func (e *CommandError) Error() string {
+	return fmt.Sprintf("%s failed", e.Command)
+}
+
+// temporary returns true if the command can succeed when sent again: the
+// API was unreachable, overloaded or failing.
+func temporary(err error) bool {
+	var transportErr *TransportError
+	var statusErr *StatusError
+	switch {
+	case errors.As(err, &transportErr):
+		return true
+	case errors.As(err, &statusErr):
+		return statusErr.StatusCode == http.StatusTooManyRequests || statusErr.StatusCode >= 500
+	default:
+		return false
+	}
+}
+
+// FetchRealtimeData returns the latest data of the thing.
+func (c *Client) FetchRealtimeData(ctx context.Context, thingKey string) (azzurro.InverterMetrics, error) {
+	params := map[string]string{
+		"thingKey":       thingKey,
+		"requiredValues": "*",
+	}
+
+	var value []map[string]azzurro.InverterMetrics
+	if err := c.command(ctx, "realtimeData", params, &value); err != nil {
+		return azzurro.InverterMetrics{}, err
+	}
+
+	for _, v := range value {
+		if metrics, found := v[thingKey]; found {
+			return metrics, nil
+		}
+	}
+	return azzurro.InverterMetrics{}, &NotFoundError{Command: "realtimeData", ThingKey: thingKey}
+}
+
+// command sends the command with its params and decodes the value of
+// the response params into v. The requests and the responses wrap the
+// command in an object named after it:
+//
+//	{"<command>": {"command": "<command>", "params": {...}}}
+//	{"<command>": {"params": {"value": ...}, "success": true}}
+//
+// The temporary failures are retried with an exponential backoff, until
+// the context is done.
+func (c *Client) command(ctx context.Context, command string, params any, v any) error {
+	type request struct {
+		Command string `json:"command"`
+		Params  any    `json:"params"`
+	}
+	data, err := json.Marshal(map[string]request{command: {Command: command, Params: params}})
+	if err != nil {
+		return err
+	}
+
+	backoff := c.initialBackoff
+	for attempt := 0; ; attempt++ {
+		err = c.send(ctx, command, data, v)
+		if err == nil || attempt >= c.maxRetries || !temporary(err) {
+			return err
+		}
+
+		timer := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return errors.Join(err, ctx.Err())
+		case <-timer.C:
+		}
+		backoff = min(2*backoff, maxBackoff)
+	}
+}
+
+// send sends the command once.
+func (c *Client) send(ctx context.Context, command string, data []byte, v any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.endpoint, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", c.authKey)
+	req.Header.Set("Client", c.clientID)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		if ctx.Err() != nil {
+			return err
+		}
+		return &TransportError{Command: command, Err: err}
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return &AuthError{StatusCode: resp.StatusCode, Command: command}
+	default:
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return &StatusError{StatusCode: resp.StatusCode, Command: command, Body: strings.TrimSpace(string(body))}
+	}
+
+	var response map[string]struct {
+		Params struct {
+			Value json.RawMessage `json:"value"`
+		} `json:"params"`
+		Success bool `json:"success"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return fmt.Errorf("parse %s response: %w", command, err)
+	}
+
+	result, found := response[command]
+	if !found || !result.Success {
+		return &CommandError{Command: command}
+	}
+	if v == nil || len(result.Params.Value) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(result.Params.Value, v); err != nil {
+		return fmt.Errorf("parse %s response: %w", command, err)
+	}
+	return nil
+}
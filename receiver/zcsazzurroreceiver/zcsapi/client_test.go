@@ -0,0 +1,195 @@
+package zcsapi
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestClient returns a client of a server answering the requests with
+// handler, without waiting between the retries.
+func newTestClient(t *testing.T, handler http.HandlerFunc) *Client {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	client := NewClient("my-auth-key", "my-client-id", server.URL)
+	client.initialBackoff = time.Millisecond
+	return client
+}
+
+func readResponse(t *testing.T) []byte {
+	t.Helper()
+
+	response, err := os.ReadFile("../testdata/response.json")
+	require.NoError(t, err)
+	return response
+}
+
+func TestClient_FetchRealtimeData(t *testing.T) {
+	response := readResponse(t)
+
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "my-auth-key", r.Header.Get("Authorization"))
+		assert.Equal(t, "my-client-id", r.Header.Get("Client"))
+
+		var request map[string]struct {
+			Command string            `json:"command"`
+			Params  map[string]string `json:"params"`
+		}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+		assert.Equal(t, "realtimeData", request["realtimeData"].Command)
+		assert.Equal(t, "my-serial-number", request["realtimeData"].Params["thingKey"])
+
+		_, _ = w.Write(response)
+	})
+
+	metrics, err := client.FetchRealtimeData(t.Context(), "my-serial-number")
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2024, 10, 22, 19, 46, 52, 0, time.UTC), metrics.LastUpdate)
+	assert.Equal(t, float64(950), metrics.PowerImporting)
+	assert.Equal(t, 20, metrics.BatterySoC)
+}
+
+func TestClient_FetchRealtimeDataErrors(t *testing.T) {
+	response := readResponse(t)
+
+	tests := []struct {
+		name     string
+		handler  http.HandlerFunc
+		thingKey string
+		check    func(t *testing.T, err error)
+	}{
+		{
+			name: "unauthorized",
+			handler: func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusUnauthorized)
+			},
+			check: func(t *testing.T, err error) {
+				var authErr *AuthError
+				require.ErrorAs(t, err, &authErr)
+				assert.Equal(t, http.StatusUnauthorized, authErr.StatusCode)
+			},
+		},
+		{
+			name: "unknown thing",
+			handler: func(w http.ResponseWriter, _ *http.Request) {
+				_, _ = w.Write(response)
+			},
+			thingKey: "other-serial-number",
+			check: func(t *testing.T, err error) {
+				var notFoundErr *NotFoundError
+				require.ErrorAs(t, err, &notFoundErr)
+				assert.Equal(t, "other-serial-number", notFoundErr.ThingKey)
+			},
+		},
+		{
+			name: "unsuccessful",
+			handler: func(w http.ResponseWriter, _ *http.Request) {
+				_, _ = w.Write([]byte(`{"realtimeData": {"params": {}, "success": false}}`))
+			},
+			check: func(t *testing.T, err error) {
+				var commandErr *CommandError
+				require.ErrorAs(t, err, &commandErr)
+				assert.Equal(t, "realtimeData", commandErr.Command)
+			},
+		},
+		{
+			name: "bad request",
+			handler: func(w http.ResponseWriter, _ *http.Request) {
+				http.Error(w, "invalid params", http.StatusBadRequest)
+			},
+			check: func(t *testing.T, err error) {
+				var statusErr *StatusError
+				require.ErrorAs(t, err, &statusErr)
+				assert.Equal(t, "invalid params", statusErr.Body)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests atomic.Int32
+			client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				requests.Add(1)
+				tt.handler(w, r)
+			})
+
+			thingKey := tt.thingKey
+			if thingKey == "" {
+				thingKey = "my-serial-number"
+			}
+			_, err := client.FetchRealtimeData(t.Context(), thingKey)
+			tt.check(t, err)
+			assert.Equal(t, int32(1), requests.Load(), "not retried")
+		})
+	}
+}
+
+func TestClient_Retry(t *testing.T) {
+	response := readResponse(t)
+
+	var requests atomic.Int32
+	client := newTestClient(t, func(w http.ResponseWriter, _ *http.Request) {
+		if requests.Add(1) <= 2 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write(response)
+	})
+
+	_, err := client.FetchRealtimeData(t.Context(), "my-serial-number")
+	require.NoError(t, err)
+	assert.Equal(t, int32(3), requests.Load())
+}
+
+func TestClient_RetryExhausted(t *testing.T) {
+	var requests atomic.Int32
+	client := newTestClient(t, func(w http.ResponseWriter, _ *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(http.StatusTooManyRequests)
+	})
+
+	_, err := client.FetchRealtimeData(t.Context(), "my-serial-number")
+	var statusErr *StatusError
+	require.ErrorAs(t, err, &statusErr)
+	assert.Equal(t, http.StatusTooManyRequests, statusErr.StatusCode)
+	assert.Equal(t, int32(1+defaultMaxRetries), requests.Load())
+}
+
+func TestClient_RetryCanceled(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	})
+	client.initialBackoff = time.Hour
+
+	ctx, cancel := context.WithTimeout(t.Context(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err := client.FetchRealtimeData(ctx, "my-serial-number")
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	var statusErr *StatusError
+	assert.ErrorAs(t, err, &statusErr)
+}
+
+func TestClient_TransportError(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	server.Close()
+
+	client := NewClient("my-auth-key", "my-client-id", server.URL)
+	client.initialBackoff = time.Millisecond
+
+	_, err := client.FetchRealtimeData(t.Context(), "my-serial-number")
+	var transportErr *TransportError
+	require.ErrorAs(t, err, &transportErr)
+	assert.True(t, temporary(err))
+}